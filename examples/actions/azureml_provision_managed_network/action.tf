@@ -0,0 +1,22 @@
+action "azureml_provision_managed_network" "example" {
+  config {
+    resource_group_name = "example"
+    workspace_name      = "example"
+    include_spark       = true
+  }
+}
+
+# Provision the managed virtual network once the outbound rules are applied
+resource "terraform_data" "outbound_rules" {
+  input = [
+    azureml_workspace_outbound_rule.pypi.id,
+    azureml_workspace_outbound_rule.storage.id,
+  ]
+
+  lifecycle {
+    action_trigger {
+      events  = [after_create, after_update]
+      actions = [action.azureml_provision_managed_network.example]
+    }
+  }
+}
@@ -1,4 +1,5 @@
-data "azureml_datastores" "example" {
+data "azureml_datastore" "example" {
   resource_group_name = "example"
   workspace_name      = "example"
-}
+  name                = "example"
+}
\ No newline at end of file
@@ -1,5 +1,4 @@
-data "azureml_datastore" "example" {
+data "azureml_datastores" "example" {
   resource_group_name = "example"
   workspace_name      = "example"
-  name                = "example"
-}
\ No newline at end of file
+}
@@ -0,0 +1,13 @@
+data "azureml_model" "latest" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "churn"
+  label               = "latest"
+}
+
+data "azureml_model" "production" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "churn"
+  label               = "Production"
+}
@@ -0,0 +1,10 @@
+data "azureml_online_endpoint_keys" "churn" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  endpoint_name       = "churn-endpoint"
+}
+
+output "churn_endpoint_primary_key" {
+  value     = data.azureml_online_endpoint_keys.churn.primary_key
+  sensitive = true
+}
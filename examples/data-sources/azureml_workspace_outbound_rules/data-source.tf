@@ -0,0 +1,8 @@
+data "azureml_workspace_outbound_rules" "example" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+}
+
+output "allowed_fqdns" {
+  value = [for r in data.azureml_workspace_outbound_rules.example.rules : r.fqdn if r.type == "FQDN"]
+}
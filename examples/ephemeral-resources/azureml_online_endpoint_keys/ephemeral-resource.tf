@@ -0,0 +1,13 @@
+ephemeral "azureml_online_endpoint_keys" "churn" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  endpoint_name       = "churn-endpoint"
+}
+
+# Write-only arguments accept ephemeral values, which are never persisted to the state
+resource "azurerm_key_vault_secret" "churn_endpoint_key" {
+  name             = "churn-endpoint-key"
+  key_vault_id     = azurerm_key_vault.example.id
+  value_wo         = ephemeral.azureml_online_endpoint_keys.churn.primary_key
+  value_wo_version = 1
+}
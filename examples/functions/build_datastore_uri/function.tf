@@ -0,0 +1,12 @@
+output "training_data_uri" {
+  # azureml://datastores/example/paths/data/train/
+  value = provider::azureml::build_datastore_uri(azureml_datastore.example.name, "data/train/")
+}
+
+output "training_data_long_uri" {
+  value = provider::azureml::build_datastore_uri(
+    azureml_datastore.example.name,
+    "data/train/",
+    provider::azureml::workspace_id(var.subscription_id, "example-rg", "example-ws"),
+  )
+}
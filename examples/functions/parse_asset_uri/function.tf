@@ -0,0 +1,8 @@
+locals {
+  # { name = "example", version = "3", label = null }
+  model = provider::azureml::parse_asset_uri("azureml:example:3")
+}
+
+output "model_version" {
+  value = local.model.version
+}
@@ -0,0 +1,7 @@
+locals {
+  datastore = provider::azureml::parse_datastore_id(azureml_datastore.example.id)
+}
+
+output "datastore_workspace_name" {
+  value = local.datastore.workspace_name
+}
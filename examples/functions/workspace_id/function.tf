@@ -0,0 +1,4 @@
+output "workspace_id" {
+  # /subscriptions/<subscription-id>/resourceGroups/example-rg/providers/Microsoft.MachineLearningServices/workspaces/example-ws
+  value = provider::azureml::workspace_id(var.subscription_id, "example-rg", "example-ws")
+}
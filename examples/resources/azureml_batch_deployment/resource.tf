@@ -0,0 +1,28 @@
+resource "azureml_batch_deployment" "churn_v7" {
+  resource_group_name = azureml_batch_endpoint.nightly.resource_group_name
+  workspace_name      = azureml_batch_endpoint.nightly.workspace_name
+  endpoint_name       = azureml_batch_endpoint.nightly.name
+  name                = "churn-v7"
+
+  model_id       = azureml_model.churn.id
+  environment_id = azureml_environment.sklearn.id
+  compute_id     = "/subscriptions/.../workspaces/example/computes/cpu-cluster"
+  instance_count = 2
+
+  code_configuration {
+    code_id        = "/subscriptions/.../workspaces/example/codes/churn-scoring/versions/1"
+    scoring_script = "batch_score.py"
+  }
+
+  mini_batch_size              = 20
+  max_concurrency_per_instance = 4
+  output_action                = "AppendRow"
+  output_file_name             = "predictions.csv"
+  error_threshold              = 10
+  logging_level                = "Warning"
+
+  retry_settings {
+    max_retries     = 5
+    timeout_seconds = 300
+  }
+}
@@ -0,0 +1,12 @@
+resource "azureml_batch_endpoint" "nightly" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "nightly-scoring"
+
+  description             = "Nightly churn scoring"
+  default_deployment_name = "churn-v7"
+
+  tags = {
+    team = "data-science"
+  }
+}
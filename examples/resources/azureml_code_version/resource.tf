@@ -0,0 +1,11 @@
+resource "azureml_code_version" "train" {
+  resource_group_name = "example-rg"
+  workspace_name      = "example-ws"
+  name                = "train"
+  auto_increment      = true
+
+  source_dir      = "${path.module}/src"
+  ignore_patterns = ["tests/", "*.ipynb"]
+
+  description = "Training script"
+}
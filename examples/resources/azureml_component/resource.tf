@@ -0,0 +1,37 @@
+resource "azureml_component" "train" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "train_churn"
+  version             = "3"
+  description         = "Trains the churn classifier"
+
+  spec = <<-EOT
+    $schema: https://azuremlschemas.azureedge.net/latest/commandComponent.schema.json
+    type: command
+    display_name: Train churn classifier
+    inputs:
+      training_data:
+        type: uri_folder
+      epochs:
+        type: integer
+        default: 10
+    outputs:
+      model:
+        type: mlflow_model
+    code: azureml:churn-training:1
+    environment: azureml:${azureml_environment.sklearn.name}:${azureml_environment.sklearn.version}
+    command: >-
+      python train.py
+      --data $${{inputs.training_data}}
+      --epochs $${{inputs.epochs}}
+      --model $${{outputs.model}}
+  EOT
+}
+
+resource "azureml_component" "score" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "score_churn"
+  version             = "1"
+  spec_path           = "${path.module}/components/score.yml"
+}
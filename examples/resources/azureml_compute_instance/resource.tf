@@ -0,0 +1,36 @@
+resource "azureml_compute_instance" "example" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "example-ci"
+  vm_size             = "Standard_DS3_v2"
+
+  assigned_user {
+    object_id = "00000000-0000-0000-0000-000000000000"
+    tenant_id = "00000000-0000-0000-0000-000000000000"
+  }
+
+  ssh {
+    public_key = file("~/.ssh/id_rsa.pub")
+  }
+
+  startup_script {
+    source = "workspaceStorage"
+    data   = "Users/example/startup.sh"
+  }
+
+  idle_time_before_shutdown_minutes = 60
+
+  schedule {
+    action          = "Start"
+    cron_expression = "0 8 * * 1-5"
+    time_zone       = "W. Europe Standard Time"
+  }
+
+  schedule {
+    action          = "Stop"
+    cron_expression = "0 19 * * 1-5"
+    time_zone       = "W. Europe Standard Time"
+  }
+
+  desired_state = "Running"
+}
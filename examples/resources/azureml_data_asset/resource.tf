@@ -0,0 +1,24 @@
+resource "azureml_data_asset" "train" {
+  resource_group_name = azureml_datastore.example.resource_group_name
+  workspace_name      = azureml_datastore.example.workspace_name
+  name                = "train"
+  auto_increment      = true
+
+  type        = "uri_folder"
+  path        = "azureml://datastores/${azureml_datastore.example.name}/paths/datasets/train/"
+  description = "Training dataset"
+
+  tags = {
+    source = "warehouse"
+  }
+}
+
+resource "azureml_data_asset" "labels" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "labels"
+  version             = "3"
+
+  type = "uri_file"
+  path = "https://example.blob.core.windows.net/data/labels.csv"
+}
@@ -0,0 +1,19 @@
+moved {
+  from = azurerm_machine_learning_datastore_blobstorage.example
+  to   = azureml_datastore.example
+}
+
+resource "azureml_datastore" "example" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "example"
+  storage_type        = "AzureBlob"
+
+  storage_account_name   = "example"
+  storage_container_name = "example"
+
+  auth = {
+    credentials_type = "AccountKey"
+    account_key      = var.account_key
+  }
+}
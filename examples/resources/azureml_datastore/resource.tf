@@ -9,7 +9,7 @@ resource "azureml_datastore" "example" {
   storage_container_name = "example"
 
   auth = {
-    credentials_type = "AzureDataLakeGen2"
+    credentials_type = "ServicePrincipal"
     client_id        = "client-id"
     client_secret    = "client-secret"
     tenant_id        = "tenant-id"
@@ -0,0 +1,28 @@
+resource "azureml_environment" "training" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "training"
+  version             = "1"
+  description         = "Training environment"
+
+  image           = "mcr.microsoft.com/azureml/openmpi4.1.0-ubuntu20.04"
+  conda_file_path = "${path.module}/environments/training/conda.yml"
+
+  tags = {
+    team = "data-science"
+  }
+}
+
+resource "azureml_environment" "inference" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "inference"
+  version             = "1"
+
+  build {
+    context_uri     = "azureml://datastores/workspaceblobstore/paths/environments/inference/"
+    dockerfile_path = "Dockerfile"
+  }
+
+  wait_for_build = true
+}
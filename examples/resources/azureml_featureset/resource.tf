@@ -0,0 +1,31 @@
+resource "azureml_featureset" "transactions" {
+  resource_group_name = azureml_featurestore.example.resource_group_name
+  featurestore_name   = azureml_featurestore.example.name
+  name                = "transactions"
+  auto_increment      = true
+
+  specification_path = "azureml://datastores/workspaceblobstore/paths/featuresets/transactions/spec"
+  entities           = [azureml_featurestore_entity.account.asset_id]
+
+  materialization {
+    store_type    = "OnlineAndOffline"
+    instance_type = "standard_e8s_v3"
+
+    spark_configuration = {
+      "spark.driver.cores"    = "4"
+      "spark.executor.cores"  = "4"
+      "spark.executor.memory" = "36g"
+    }
+
+    schedule {
+      frequency  = "Day"
+      interval   = 1
+      start_time = "2024-01-01T02:00:00"
+    }
+
+    notification_emails = ["ml-team@example.com"]
+    notification_events = ["JobFailed"]
+  }
+
+  description = "7 and 3 days rolling aggregations of the transactions of an account"
+}
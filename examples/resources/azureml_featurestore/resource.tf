@@ -0,0 +1,26 @@
+resource "azureml_featurestore" "example" {
+  resource_group_name = "example"
+  name                = "example-featurestore"
+  location            = "westeurope"
+
+  storage_account_id = azurerm_storage_account.example.id
+  key_vault_id       = azurerm_key_vault.example.id
+
+  identity {
+    type         = "SystemAssigned,UserAssigned"
+    identity_ids = [azurerm_user_assigned_identity.materialization.id]
+  }
+
+  materialization_identity {
+    id        = azurerm_user_assigned_identity.materialization.id
+    client_id = azurerm_user_assigned_identity.materialization.client_id
+  }
+
+  offline_store {
+    target = azurerm_storage_container.offline_store.resource_manager_id
+  }
+
+  online_store {
+    target = azurerm_redis_cache.online_store.id
+  }
+}
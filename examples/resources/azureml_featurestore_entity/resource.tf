@@ -0,0 +1,13 @@
+resource "azureml_featurestore_entity" "account" {
+  resource_group_name = azureml_featurestore.example.resource_group_name
+  featurestore_name   = azureml_featurestore.example.name
+  name                = "account"
+  version             = "1"
+
+  index_column {
+    name = "accountID"
+    type = "String"
+  }
+
+  description = "Bank account"
+}
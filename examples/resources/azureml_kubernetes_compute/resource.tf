@@ -0,0 +1,30 @@
+resource "azureml_kubernetes_compute" "aks" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "aks-inference"
+  resource_id         = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.ContainerService/managedClusters/example"
+
+  namespace             = "azureml"
+  default_instance_type = "cpu-small"
+
+  instance_type {
+    name           = "cpu-small"
+    cpu_request    = "500m"
+    memory_request = "1Gi"
+    cpu_limit      = "1"
+    memory_limit   = "2Gi"
+  }
+
+  instance_type {
+    name          = "gpu"
+    memory_limit  = "32Gi"
+    gpu_limit     = 1
+    node_selector = {
+      agentpool = "gpu"
+    }
+  }
+
+  identity {
+    type = "SystemAssigned"
+  }
+}
@@ -0,0 +1,26 @@
+resource "azureml_model" "churn" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "churn"
+  version             = "7"
+
+  model_type  = "mlflow_model"
+  path        = "azureml://jobs/example-job/outputs/artifacts/paths/model/"
+  description = "Churn classifier"
+  stage       = "Production"
+
+  flavor {
+    name = "python_function"
+    data = {
+      loader_module = "mlflow.sklearn"
+    }
+  }
+
+  properties = {
+    training_job = "example-job"
+  }
+
+  tags = {
+    framework = "sklearn"
+  }
+}
@@ -0,0 +1,34 @@
+resource "azureml_online_deployment" "blue" {
+  resource_group_name = azureml_online_endpoint.churn.resource_group_name
+  workspace_name      = azureml_online_endpoint.churn.workspace_name
+  endpoint_name       = azureml_online_endpoint.churn.name
+  name                = "blue"
+
+  model          = azureml_model.churn.id
+  environment_id = azureml_environment.sklearn.id
+  instance_type  = "Standard_DS3_v2"
+  instance_count = 2
+
+  code_configuration {
+    code_id        = "/subscriptions/.../workspaces/example/codes/churn-scoring/versions/1"
+    scoring_script = "score.py"
+  }
+
+  scale_settings {
+    type = "Default"
+  }
+
+  liveness_probe {
+    initial_delay_seconds = 30
+    period_seconds        = 10
+  }
+
+  request_settings {
+    max_concurrent_requests_per_instance = 2
+    request_timeout_ms                   = 10000
+  }
+
+  environment_variables = {
+    LOG_LEVEL = "INFO"
+  }
+}
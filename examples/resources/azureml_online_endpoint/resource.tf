@@ -0,0 +1,17 @@
+resource "azureml_online_endpoint" "churn" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "churn-endpoint"
+
+  description           = "Churn scoring endpoint"
+  auth_mode             = "Key"
+  public_network_access = "Enabled"
+
+  identity {
+    type = "SystemAssigned"
+  }
+
+  traffic = {
+    blue = 100
+  }
+}
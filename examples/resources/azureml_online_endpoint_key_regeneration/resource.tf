@@ -0,0 +1,14 @@
+resource "time_rotating" "churn_endpoint_key" {
+  rotation_days = 30
+}
+
+resource "azureml_online_endpoint_key_regeneration" "churn_primary" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  endpoint_name       = "churn-endpoint"
+  key_type            = "Primary"
+
+  triggers = {
+    rotation = time_rotating.churn_endpoint_key.id
+  }
+}
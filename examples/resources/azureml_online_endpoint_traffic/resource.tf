@@ -0,0 +1,14 @@
+resource "azureml_online_endpoint_traffic" "churn" {
+  resource_group_name = azureml_online_endpoint.churn.resource_group_name
+  workspace_name      = azureml_online_endpoint.churn.workspace_name
+  endpoint_name       = azureml_online_endpoint.churn.name
+
+  traffic = {
+    (azureml_online_deployment.blue.name)  = 90
+    (azureml_online_deployment.green.name) = 10
+  }
+
+  mirror_traffic = {
+    (azureml_online_deployment.red.name) = 20
+  }
+}
@@ -0,0 +1,29 @@
+resource "azureml_registry" "shared" {
+  resource_group_name = "example"
+  name                = "shared-registry"
+  location            = "westeurope"
+
+  region {
+    location = "westeurope"
+  }
+
+  region {
+    location             = "northeurope"
+    storage_account_type = "Standard_ZRS"
+    acr_sku              = "Premium"
+  }
+
+  tags = {
+    team = "ml-platform"
+  }
+}
+
+resource "azureml_model" "shared_churn" {
+  resource_group_name = azureml_registry.shared.resource_group_name
+  registry_name       = azureml_registry.shared.name
+  name                = "churn"
+  version             = "1"
+
+  model_type = "mlflow_model"
+  path       = "azureml://jobs/example-job/outputs/artifacts/paths/model/"
+}
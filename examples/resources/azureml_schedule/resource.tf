@@ -0,0 +1,40 @@
+resource "azureml_schedule" "nightly_retraining" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "nightly-retraining"
+  display_name        = "Nightly retraining"
+  is_enabled          = true
+
+  cron {
+    expression = "0 2 * * *"
+  }
+  time_zone = "W. Europe Standard Time"
+
+  create_job = <<-EOT
+    jobType: Pipeline
+    displayName: churn-retraining
+    experimentName: churn
+    settings:
+      defaultCompute: /subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.MachineLearningServices/workspaces/example/computes/cpu-cluster
+    jobs:
+      train:
+        type: command
+        componentId: azureml:train:1
+  EOT
+}
+
+resource "azureml_schedule" "weekly_report" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "weekly-report"
+
+  recurrence {
+    frequency = "Week"
+    interval  = 1
+    week_days = ["Monday"]
+    hours     = [6]
+    minutes   = [30]
+  }
+
+  create_job_path = "${path.module}/jobs/weekly-report.yaml"
+}
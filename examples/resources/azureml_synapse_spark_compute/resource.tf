@@ -0,0 +1,10 @@
+resource "azureml_synapse_spark_compute" "spark" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "synapse-spark"
+  resource_id         = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Synapse/workspaces/example/bigDataPools/example"
+
+  identity {
+    type = "SystemAssigned"
+  }
+}
@@ -0,0 +1,10 @@
+resource "azureml_virtual_machine_compute" "dsvm" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "dsvm"
+  resource_id         = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Compute/virtualMachines/example"
+
+  admin_username  = "azureuser"
+  ssh_private_key = file("~/.ssh/dsvm")
+  ssh_public_key  = file("~/.ssh/dsvm.pub")
+}
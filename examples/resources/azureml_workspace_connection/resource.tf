@@ -0,0 +1,38 @@
+variable "git_pat" {
+  type      = string
+  sensitive = true
+}
+
+resource "azureml_workspace_connection" "git" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "example-repo"
+
+  category  = "Git"
+  target    = "https://github.com/example/example-repo"
+  auth_type = "PAT"
+
+  credentials {
+    pat = var.git_pat
+  }
+}
+
+resource "azureml_workspace_connection" "openai" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "example-openai"
+
+  category  = "AzureOpenAI"
+  target    = "https://example.openai.azure.com/"
+  auth_type = "ManagedIdentity"
+
+  credentials {
+    client_id   = "00000000-0000-0000-0000-000000000000"
+    resource_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.ManagedIdentity/userAssignedIdentities/example"
+  }
+
+  metadata = {
+    ApiType    = "Azure"
+    ApiVersion = "2024-02-01"
+  }
+}
@@ -0,0 +1,30 @@
+resource "azureml_workspace_outbound_rule" "pypi" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "pypi"
+
+  fqdn = "pypi.org"
+}
+
+resource "azureml_workspace_outbound_rule" "storage" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "example-storage"
+
+  private_endpoint {
+    service_resource_id = "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/example"
+    subresource_target  = "blob"
+  }
+}
+
+resource "azureml_workspace_outbound_rule" "monitor" {
+  resource_group_name = "example"
+  workspace_name      = "example"
+  name                = "azure-monitor"
+
+  service_tag {
+    service_tag = "AzureMonitor"
+    protocol    = "TCP"
+    port_ranges = "443"
+  }
+}
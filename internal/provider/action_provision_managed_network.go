@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/action/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"time"
+)
+
+// managedNetworkProvisioningTimeout is the maximum time waited for the provisioning of a managed virtual network.
+const managedNetworkProvisioningTimeout = 60 * time.Minute
+
+var _ action.ActionWithConfigure = &provisionManagedNetworkAction{}
+
+// provisionManagedNetworkAction provisions the managed virtual network of a workspace, which is otherwise
+// provisioned by Azure ML only when the first compute is created.
+type provisionManagedNetworkAction struct {
+	client *apiClient
+}
+
+type provisionManagedNetworkActionModel struct {
+	ResourceGroupName types.String `tfsdk:"resource_group_name"`
+	WorkspaceName     types.String `tfsdk:"workspace_name"`
+	IncludeSpark      types.Bool   `tfsdk:"include_spark"`
+}
+
+func newProvisionManagedNetworkAction() action.Action {
+	return &provisionManagedNetworkAction{}
+}
+
+func (a *provisionManagedNetworkAction) Metadata(_ context.Context, req action.MetadataRequest, resp *action.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provision_managed_network"
+}
+
+func (a *provisionManagedNetworkAction) Schema(_ context.Context, _ action.SchemaRequest, resp *action.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Provisions the managed virtual network of an Azure ML Workspace, applying its outbound " +
+			"rules, and waits until the network is active. Azure ML otherwise provisions the managed virtual network " +
+			"only when the first compute of the workspace is created. Requires Terraform 1.14 or later.",
+		Attributes: map[string]schema.Attribute{
+			"resource_group_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the resource group of the Azure ML Workspace.",
+			},
+			"workspace_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the Azure ML Workspace.",
+			},
+			"include_spark": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Should the managed virtual network also be provisioned for serverless Spark jobs? Defaults to `false`.",
+			},
+		},
+	}
+}
+
+func (a *provisionManagedNetworkAction) Configure(_ context.Context, req action.ConfigureRequest, resp *action.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *apiClient, got %T.", req.ProviderData),
+		)
+		return
+	}
+	a.client = client
+}
+
+func (a *provisionManagedNetworkAction) Invoke(ctx context.Context, req action.InvokeRequest, resp *action.InvokeResponse) {
+	resp.Diagnostics.Append(configuredClientDiagnostics(a.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data provisionManagedNetworkActionModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workspaceName := data.WorkspaceName.ValueString()
+	path := a.client.arm.workspaceId(data.ResourceGroupName.ValueString(), workspaceName)
+	body := &provisionManagedNetworkRequest{IncludeSpark: data.IncludeSpark.ValueBool()}
+	operation, err := a.client.arm.post(ctx, path+"/provisionManagedNetwork", body, nil)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error provisioning the managed virtual network of workspace %s", workspaceName),
+			err.Error(),
+		)
+		return
+	}
+
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("Waiting for the managed virtual network of workspace %s to be active", workspaceName),
+	})
+	if err := waitForManagedNetwork(ctx, a.client.arm, operation, path, body.IncludeSpark, managedNetworkProvisioningTimeout); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Error waiting for the managed virtual network of workspace %s to be active", workspaceName),
+			err.Error(),
+		)
+		return
+	}
+	resp.SendProgress(action.InvokeProgressEvent{
+		Message: fmt.Sprintf("The managed virtual network of workspace %s is active", workspaceName),
+	})
+}
+
+// waitForManagedNetwork waits for the provisioning operation whose response is provided as argument and until the
+// managed virtual network of the workspace at the path provided as argument is active and, if includeSpark is set,
+// ready for serverless Spark jobs.
+func waitForManagedNetwork(ctx context.Context, c *armClient, operation *armResponse, workspacePath string, includeSpark bool, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	if err := waitForOperation(ctx, c, operation, 0); err != nil {
+		return err
+	}
+	return pollUntilDone(ctx, "the managed virtual network", 0, func(ctx context.Context) (bool, time.Duration, error) {
+		ws := new(managedNetworkWorkspace)
+		if err := c.get(ctx, workspacePath, ws); err != nil {
+			return false, 0, err
+		}
+		network := ws.Properties.ManagedNetwork
+		if network == nil || network.Status == nil || network.Status.Status != "Active" {
+			return false, 0, nil
+		}
+		return !includeSpark || network.Status.SparkReady, 0, nil
+	})
+}
+
+type provisionManagedNetworkRequest struct {
+	IncludeSpark bool `json:"includeSpark"`
+}
+
+// managedNetworkWorkspace contains the subset of the properties of an Azure ML Workspace describing its managed
+// virtual network.
+type managedNetworkWorkspace struct {
+	Properties struct {
+		ManagedNetwork *struct {
+			IsolationMode string `json:"isolationMode"`
+			Status        *struct {
+				Status     string `json:"status"`
+				SparkReady bool   `json:"sparkReady"`
+			} `json:"status"`
+		} `json:"managedNetwork"`
+	} `json:"properties"`
+}
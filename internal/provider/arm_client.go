@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/AzureAD/microsoft-authentication-library-for-go/apps/confidential"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	armBaseUrl    = "https://management.azure.com"
+	armOauthScope = "https://management.azure.com/.default"
+	amlApiVersion = "2024-04-01"
+
+	amlWorkspaceIdFormat = "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.MachineLearningServices/workspaces/%s"
+	amlRegistryIdFormat  = "/subscriptions/%s/resourceGroups/%s/providers/Microsoft.MachineLearningServices/registries/%s"
+)
+
+// armClient is a minimal client of the Azure Resource Manager REST APIs. It is used for managing all the
+// Azure ML objects, so that every request is logged by the loggingTransport.
+type armClient struct {
+	baseUrl        string
+	subscriptionId string
+	httpClient     *http.Client
+	getToken       func(ctx context.Context) (string, error)
+}
+
+type armResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+type resourceNotFoundError struct {
+	resourceId string
+}
+
+func (e resourceNotFoundError) Error() string {
+	return fmt.Sprintf("resource %s not found", e.resourceId)
+}
+
+type armResponseError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e armResponseError) Error() string {
+	return fmt.Sprintf("HTTP Response is in error [status code %d]: %s", e.StatusCode, e.Body)
+}
+
+func newArmClient(clientId, clientSecret, tenantId, subscriptionId string) (*armClient, error) {
+	credential, err := confidential.NewCredFromSecret(clientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	authority := fmt.Sprintf("https://login.microsoftonline.com/%s", tenantId)
+	msalClient, err := confidential.New(clientId, credential, confidential.WithAuthority(authority))
+	if err != nil {
+		return nil, err
+	}
+
+	return &armClient{
+		baseUrl:        armBaseUrl,
+		subscriptionId: subscriptionId,
+		httpClient:     &http.Client{Transport: newLoggingTransport(armLogSubsystem, http.DefaultTransport)},
+		getToken: func(ctx context.Context) (string, error) {
+			scopes := []string{armOauthScope}
+			authResult, err := msalClient.AcquireTokenSilent(ctx, scopes)
+			if err != nil {
+				authResult, err = msalClient.AcquireTokenByCredential(ctx, scopes)
+			}
+			return authResult.AccessToken, err
+		},
+	}, nil
+}
+
+// workspaceId returns the ARM ID of the Azure ML Workspace provided as argument.
+func (c *armClient) workspaceId(resourceGroupName, workspaceName string) string {
+	return fmt.Sprintf(amlWorkspaceIdFormat, c.subscriptionId, resourceGroupName, workspaceName)
+}
+
+// registryId returns the ARM ID of the Azure ML Registry provided as argument.
+func (c *armClient) registryId(resourceGroupName, registryName string) string {
+	return fmt.Sprintf(amlRegistryIdFormat, c.subscriptionId, resourceGroupName, registryName)
+}
+
+// do sends a request to the ARM API at the path provided as argument, which must be either a resource ID
+// or an absolute URL. The default Azure ML API version is added to the query unless the path already
+// specifies one.
+func (c *armClient) do(ctx context.Context, method, path string, requestBody interface{}) (*armResponse, error) {
+	url := path
+	if !strings.HasPrefix(path, "https://") && !strings.HasPrefix(path, "http://") {
+		url = c.baseUrl + path
+	}
+	if !strings.Contains(url, "api-version=") {
+		separator := "?"
+		if strings.Contains(url, "?") {
+			separator = "&"
+		}
+		url = fmt.Sprintf("%s%sapi-version=%s", url, separator, amlApiVersion)
+	}
+
+	var body *bytes.Buffer
+	if requestBody != nil {
+		b, err := json.Marshal(requestBody)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewBuffer(b)
+	} else {
+		body = new(bytes.Buffer)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	token, err := c.getToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", token))
+	if requestBody != nil {
+		req.Header.Add("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &armResponse{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       respBody,
+	}, nil
+}
+
+// get retrieves the resource at the path provided as argument and unmarshals it into out.
+// A resourceNotFoundError is returned if the resource does not exist.
+func (c *armClient) get(ctx context.Context, path string, out interface{}) error {
+	resp, err := c.do(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return &resourceNotFoundError{path}
+	}
+	if resp.StatusCode != http.StatusOK {
+		return &armResponseError{resp.StatusCode, string(resp.Body)}
+	}
+	return unmarshalArmResponse(resp, out)
+}
+
+// put creates or replaces the resource at the path provided as argument. The response body, if any,
+// is unmarshalled into out.
+func (c *armClient) put(ctx context.Context, path string, in, out interface{}) (*armResponse, error) {
+	return c.send(ctx, http.MethodPut, path, in, out)
+}
+
+// patch updates the resource at the path provided as argument. The response body, if any, is
+// unmarshalled into out.
+func (c *armClient) patch(ctx context.Context, path string, in, out interface{}) (*armResponse, error) {
+	return c.send(ctx, http.MethodPatch, path, in, out)
+}
+
+// post invokes the action at the path provided as argument. The response body, if any, is
+// unmarshalled into out.
+func (c *armClient) post(ctx context.Context, path string, in, out interface{}) (*armResponse, error) {
+	return c.send(ctx, http.MethodPost, path, in, out)
+}
+
+// delete deletes the resource at the path provided as argument. A resourceNotFoundError is returned
+// if the resource does not exist.
+func (c *armClient) delete(ctx context.Context, path string) (*armResponse, error) {
+	resp, err := c.do(ctx, http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return resp, &resourceNotFoundError{path}
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp, &armResponseError{resp.StatusCode, string(resp.Body)}
+	}
+	return resp, nil
+}
+
+func (c *armClient) send(ctx context.Context, method, path string, in, out interface{}) (*armResponse, error) {
+	resp, err := c.do(ctx, method, path, in)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return resp, &armResponseError{resp.StatusCode, string(resp.Body)}
+	}
+	return resp, unmarshalArmResponse(resp, out)
+}
+
+func unmarshalArmResponse(resp *armResponse, out interface{}) error {
+	if out == nil || len(bytes.TrimSpace(resp.Body)) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Body, out); err != nil {
+		return fmt.Errorf("unable to parse response: %w", err)
+	}
+	return nil
+}
+
+// armResourceId is the reference to another ARM resource used in request and response bodies.
+type armResourceId struct {
+	Id string `json:"id"`
+}
+
+// armResourceList is a page of a list of ARM resources.
+type armResourceList struct {
+	Value []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"value"`
+	NextLink string `json:"nextLink"`
+}
+
+// armWorkspace contains the subset of the properties of an Azure ML Workspace needed by the provider.
+type armWorkspace struct {
+	Id       string `json:"id"`
+	Name     string `json:"name"`
+	Location string `json:"location"`
+}
+
+// getWorkspace retrieves the Azure ML Workspace provided as argument.
+func (c *armClient) getWorkspace(ctx context.Context, resourceGroupName, workspaceName string) (*armWorkspace, error) {
+	ws := new(armWorkspace)
+	if err := c.get(ctx, c.workspaceId(resourceGroupName, workspaceName), ws); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestArmClient(server *httptest.Server) *armClient {
+	return &armClient{
+		baseUrl:        server.URL,
+		subscriptionId: "sub",
+		httpClient:     server.Client(),
+		getToken: func(ctx context.Context) (string, error) {
+			return "token", nil
+		},
+	}
+}
+
+func TestArmClientGet(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("unexpected authorization header %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Query().Get("api-version") != amlApiVersion {
+			t.Errorf("unexpected api version %q", r.URL.Query().Get("api-version"))
+		}
+		switch r.URL.Path {
+		case "/found":
+			_, _ = w.Write([]byte(`{"id": "id-1", "name": "ws", "location": "westeurope"}`))
+		case "/error":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error": {"code": "AuthorizationFailed"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c := newTestArmClient(server)
+
+	ws := new(armWorkspace)
+	if err := c.get(context.Background(), "/found", ws); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ws.Id != "id-1" || ws.Location != "westeurope" {
+		t.Errorf("unexpected workspace %+v", ws)
+	}
+
+	var notFoundErr *resourceNotFoundError
+	if err := c.get(context.Background(), "/missing", ws); !errors.As(err, &notFoundErr) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	var respErr *armResponseError
+	if err := c.get(context.Background(), "/error", ws); !errors.As(err, &respErr) || respErr.StatusCode != http.StatusForbidden {
+		t.Errorf("expected response error, got %v", err)
+	}
+}
+
+func TestArmClientPut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("unexpected method %s", r.Method)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("unexpected content type %q", r.Header.Get("Content-Type"))
+		}
+		if r.URL.Query().Get("underlyingResourceAction") != "Delete" {
+			t.Errorf("query parameters of the path have not been preserved: %s", r.URL.RawQuery)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+	c := newTestArmClient(server)
+
+	out := new(armWorkspace)
+	resp, err := c.put(context.Background(), "/ws?underlyingResourceAction=Delete", armWorkspace{Name: "ws"}, out)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusCreated || out.Name != "ws" {
+		t.Errorf("unexpected response %d %+v", resp.StatusCode, out)
+	}
+
+	var sent map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &sent); err != nil || sent["name"] != "ws" {
+		t.Errorf("unexpected request body %s", resp.Body)
+	}
+}
@@ -0,0 +1,291 @@
+package provider
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"regexp"
+	"strings"
+)
+
+// armStorageErrorPattern matches the messages of the user errors caused by the access to a storage.
+var armStorageErrorPattern = regexp.MustCompile(`(?i)storage|blob|container|credential|account key|sas token`)
+
+// armErrorResponse is the body of the unsuccessful responses of the ARM APIs.
+type armErrorResponse struct {
+	Error *armErrorDetail `json:"error"`
+}
+
+// armErrorDetail is the error envelope of the ARM APIs.
+type armErrorDetail struct {
+	Code       string           `json:"code"`
+	Message    string           `json:"message"`
+	Target     string           `json:"target,omitempty"`
+	Details    []armErrorDetail `json:"details,omitempty"`
+	InnerError *armInnerError   `json:"innerError,omitempty"`
+}
+
+// armInnerError is the chain of increasingly specific codes with which Azure ML qualifies its errors.
+type armInnerError struct {
+	Code       string         `json:"code"`
+	InnerError *armInnerError `json:"innerError,omitempty"`
+}
+
+// innerCodes returns the codes of the chain of inner errors of the error.
+func (e *armErrorDetail) innerCodes() []string {
+	var codes []string
+	for inner := e.InnerError; inner != nil; inner = inner.InnerError {
+		if inner.Code != "" {
+			codes = append(codes, inner.Code)
+		}
+	}
+	return codes
+}
+
+// walk calls f with the code and the message of the error, of its details and of its inner errors.
+func (e *armErrorDetail) walk(f func(code, message string)) {
+	f(e.Code, e.Message)
+	for _, code := range e.innerCodes() {
+		f(code, "")
+	}
+	for i := range e.Details {
+		e.Details[i].walk(f)
+	}
+}
+
+// parseArmError returns the ARM error envelope carried by the error provided as argument, either as the body of an
+// unsuccessful response or as the outcome of a long-running operation, and false if there is none.
+func parseArmError(err error) (*armErrorDetail, bool) {
+	if err == nil {
+		return nil, false
+	}
+	var opErr *operationError
+	if errors.As(err, &opErr) && opErr.Code != "" {
+		return &opErr.armErrorDetail, true
+	}
+	var respErr *armResponseError
+	if !errors.As(err, &respErr) {
+		return nil, false
+	}
+	resp := new(armErrorResponse)
+	if json.Unmarshal([]byte(respErr.Body), resp) != nil || resp.Error == nil || resp.Error.Code == "" {
+		return nil, false
+	}
+	return resp.Error, true
+}
+
+// armErrorKind is a kind of ARM error for which a remediation can be suggested.
+type armErrorKind int
+
+const (
+	armErrorUnknown armErrorKind = iota
+	armErrorAuthorization
+	armErrorResourceGroupNotFound
+	armErrorWorkspaceNotFound
+	armErrorQuota
+	armErrorStorageAccess
+)
+
+// armErrorPaths maps the kinds of ARM errors to the arguments of a resource that usually cause them.
+type armErrorPaths map[armErrorKind]cty.Path
+
+var armErrorRemediations = map[armErrorKind]struct {
+	title       string
+	remediation string
+}{
+	armErrorAuthorization: {
+		"authorization failed",
+		"The service principal configured in the provider is not allowed to perform the operation. Assign it a role " +
+			"granting the missing permission, such as AzureML Data Scientist or Contributor, on the workspace or on its " +
+			"resource group. Role assignments can take a few minutes to be effective.",
+	},
+	armErrorResourceGroupNotFound: {
+		"resource group not found",
+		"Check the name of the resource group and that it belongs to the subscription configured in the provider.",
+	},
+	armErrorWorkspaceNotFound: {
+		"workspace not found",
+		"Check the names of the workspace and of its resource group, and that the workspace belongs to the " +
+			"subscription configured in the provider.",
+	},
+	armErrorQuota: {
+		"quota exceeded",
+		"The subscription does not have enough quota for the requested resources. Request a quota increase for the " +
+			"VM family in the region from the Quotas page of the Azure portal, or choose a different size or region.",
+	},
+	armErrorStorageAccess: {
+		"storage not accessible",
+		"Azure ML could not access the storage with the credentials provided. Check that the credentials are valid " +
+			"and not expired, that the identity they belong to has access to the storage (e.g. with the Storage Blob " +
+			"Data Reader role), and that the network rules of the storage account allow the access from Azure ML.",
+	},
+}
+
+// classifyArmError returns the kind of the ARM error provided as argument, looking at the codes and the messages of
+// the error, of its details and of its inner errors.
+func classifyArmError(detail *armErrorDetail) armErrorKind {
+	found := map[armErrorKind]bool{}
+	userError, storageMessage := false, false
+	detail.walk(func(code, message string) {
+		userError = userError || code == "UserError"
+		storageMessage = storageMessage || armStorageErrorPattern.MatchString(code+" "+message)
+		switch {
+		case code == "AuthorizationFailed" || code == "LinkedAuthorizationFailed":
+			found[armErrorAuthorization] = true
+		case code == "ResourceGroupNotFound":
+			found[armErrorResourceGroupNotFound] = true
+		case (code == "ResourceNotFound" || code == "NotFound") && strings.Contains(strings.ToLower(message), "workspace"):
+			found[armErrorWorkspaceNotFound] = true
+		case strings.Contains(strings.ToLower(code+" "+message), "quota"):
+			found[armErrorQuota] = true
+		}
+	})
+	// Azure ML reports the failures to access a storage as user errors, whose cause is often in the details
+	found[armErrorStorageAccess] = userError && storageMessage
+	// The most fundamental cause is reported when several are found
+	for _, kind := range []armErrorKind{
+		armErrorAuthorization,
+		armErrorResourceGroupNotFound,
+		armErrorWorkspaceNotFound,
+		armErrorQuota,
+		armErrorStorageAccess,
+	} {
+		if found[kind] {
+			return kind
+		}
+	}
+	return armErrorUnknown
+}
+
+// armErrorDiagnostics returns the diagnostics describing the error provided as argument. ARM errors are described by
+// their message, details and inner errors and, for the known kinds of errors, by a remediation and by the path of
+// the argument of the resource that usually causes them. Other errors are reported as they are.
+func armErrorDiagnostics(summary string, err error, paths armErrorPaths) diag.Diagnostics {
+	detail, ok := parseArmError(err)
+	if !ok {
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  summary,
+			Detail:   err.Error(),
+		}}
+	}
+
+	d := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  summary,
+		Detail:   formatArmError(detail),
+	}
+	if kind := classifyArmError(detail); kind != armErrorUnknown {
+		remediation := armErrorRemediations[kind]
+		d.Summary = fmt.Sprintf("%s: %s", summary, remediation.title)
+		d.Detail = d.Detail + "\n\n" + remediation.remediation
+		d.AttributePath = paths[kind]
+	}
+	return diag.Diagnostics{d}
+}
+
+// armErrorFrameworkDiagnostics is the terraform-plugin-framework counterpart of armErrorDiagnostics.
+func armErrorFrameworkDiagnostics(summary string, err error, paths armErrorPaths) fwdiag.Diagnostics {
+	var diags fwdiag.Diagnostics
+	for _, d := range armErrorDiagnostics(summary, err, paths) {
+		if p, ok := frameworkPath(d.AttributePath); ok {
+			diags.AddAttributeError(p, d.Summary, d.Detail)
+		} else {
+			diags.AddError(d.Summary, d.Detail)
+		}
+	}
+	return diags
+}
+
+// frameworkPath converts a cty.Path made of attribute names and list indexes into a terraform-plugin-framework
+// path, returning false if the path is empty or cannot be converted.
+func frameworkPath(p cty.Path) (path.Path, bool) {
+	if len(p) == 0 {
+		return path.Empty(), false
+	}
+	result := path.Empty()
+	for _, step := range p {
+		switch s := step.(type) {
+		case cty.GetAttrStep:
+			result = result.AtName(s.Name)
+		case cty.IndexStep:
+			if s.Key.Type() != cty.Number {
+				return path.Empty(), false
+			}
+			i, _ := s.Key.AsBigFloat().Int64()
+			result = result.AtListIndex(int(i))
+		default:
+			return path.Empty(), false
+		}
+	}
+	return result, true
+}
+
+// formatArmError returns a readable description of the ARM error provided as argument.
+func formatArmError(detail *armErrorDetail) string {
+	b := &strings.Builder{}
+	if detail.Message != "" {
+		b.WriteString(detail.Message)
+	} else {
+		b.WriteString(detail.Code)
+	}
+	fmt.Fprintf(b, "\n\nError code: %s", detail.Code)
+	if codes := detail.innerCodes(); len(codes) > 0 {
+		fmt.Fprintf(b, " (%s)", strings.Join(codes, " > "))
+	}
+	if detail.Target != "" {
+		fmt.Fprintf(b, "\nTarget: %s", detail.Target)
+	}
+	if len(detail.Details) > 0 {
+		b.WriteString("\nDetails:")
+		for _, d := range detail.Details {
+			writeArmErrorDetail(b, d, "  ")
+		}
+	}
+	return b.String()
+}
+
+func writeArmErrorDetail(b *strings.Builder, detail armErrorDetail, indent string) {
+	if detail.Code == "" && detail.Message == "" {
+		return
+	}
+	if indent == "" {
+		b.WriteString(": ")
+	} else {
+		b.WriteString("\n" + indent + "- ")
+	}
+	if detail.Code != "" {
+		b.WriteString(detail.Code)
+		if codes := detail.innerCodes(); len(codes) > 0 {
+			fmt.Fprintf(b, " (%s)", strings.Join(codes, " > "))
+		}
+		if detail.Message != "" {
+			b.WriteString(": ")
+		}
+	}
+	b.WriteString(detail.Message)
+	if detail.Target != "" {
+		fmt.Fprintf(b, " (target: %s)", detail.Target)
+	}
+	for _, d := range detail.Details {
+		writeArmErrorDetail(b, d, indent+"  ")
+	}
+}
+
+// workspaceErrorPaths returns the paths of the arguments causing the ARM errors of the resources belonging to an
+// Azure ML Workspace, merged with the resource specific ones provided as argument.
+func workspaceErrorPaths(paths armErrorPaths) armErrorPaths {
+	result := armErrorPaths{
+		armErrorAuthorization:         cty.GetAttrPath("workspace_name"),
+		armErrorResourceGroupNotFound: cty.GetAttrPath("resource_group_name"),
+		armErrorWorkspaceNotFound:     cty.GetAttrPath("workspace_name"),
+	}
+	for kind, path := range paths {
+		result[kind] = path
+	}
+	return result
+}
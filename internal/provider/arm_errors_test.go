@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	fwdiag "github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+const testArmNestedError = `{
+  "error": {
+    "code": "UserError",
+    "message": "Validation of the datastore failed.",
+    "target": "credentials",
+    "details": [
+      {
+        "code": "Unauthorized",
+        "message": "No identity was found on the storage account.",
+        "details": [{"code": "StorageAccessDenied", "message": "This request is not authorized to perform this operation."}]
+      }
+    ],
+    "innerError": {"code": "BadArgument", "innerError": {"code": "DatastoreValidationFailed"}}
+  }
+}`
+
+func TestParseArmError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		code string
+	}{
+		{"arm client", &armResponseError{http.StatusBadRequest, testArmNestedError}, "UserError"},
+		{"wrapped", fmt.Errorf("unable to create: %w", &armResponseError{http.StatusNotFound,
+			`{"error": {"code": "ResourceGroupNotFound", "message": "Resource group 'rg' could not be found."}}`}),
+			"ResourceGroupNotFound"},
+		{"operation", fmt.Errorf("error waiting: %w", &operationError{
+			armErrorDetail: armErrorDetail{Code: "QuotaExceeded", Message: "not enough quota"},
+			Status:         "Failed",
+		}), "QuotaExceeded"},
+	}
+	for _, c := range cases {
+		detail, ok := parseArmError(c.err)
+		if !ok {
+			t.Errorf("%s: the ARM error has not been parsed from %v", c.name, c.err)
+			continue
+		}
+		if detail.Code != c.code {
+			t.Errorf("%s: unexpected code %q, expected %q", c.name, detail.Code, c.code)
+		}
+	}
+
+	for _, err := range []error{
+		nil,
+		errors.New("connection refused"),
+		errors.New(`HTTP Response is in error [status code 403]: {"error": {"code": "AuthorizationFailed"}}`),
+		&armResponseError{http.StatusInternalServerError, "<html>Internal error</html>"},
+		&armResponseError{http.StatusBadRequest, `{"message": "no envelope"}`},
+		&operationError{Status: "Canceled"},
+	} {
+		if _, ok := parseArmError(err); ok {
+			t.Errorf("an ARM error has been parsed from %v", err)
+		}
+	}
+}
+
+func TestParseArmErrorNested(t *testing.T) {
+	detail, ok := parseArmError(&armResponseError{http.StatusBadRequest, testArmNestedError})
+	if !ok {
+		t.Fatalf("the ARM error has not been parsed")
+	}
+	if codes := strings.Join(detail.innerCodes(), ","); codes != "BadArgument,DatastoreValidationFailed" {
+		t.Errorf("unexpected inner codes %q", codes)
+	}
+	if len(detail.Details) != 1 || len(detail.Details[0].Details) != 1 ||
+		detail.Details[0].Details[0].Code != "StorageAccessDenied" {
+		t.Errorf("unexpected details %+v", detail.Details)
+	}
+
+	formatted := formatArmError(detail)
+	for _, expected := range []string{
+		"Validation of the datastore failed.",
+		"Error code: UserError (BadArgument > DatastoreValidationFailed)",
+		"Target: credentials",
+		"\n  - Unauthorized: No identity was found on the storage account.",
+		"\n    - StorageAccessDenied: This request is not authorized to perform this operation.",
+	} {
+		if !strings.Contains(formatted, expected) {
+			t.Errorf("%q not found in the formatted error:\n%s", expected, formatted)
+		}
+	}
+}
+
+func TestClassifyArmError(t *testing.T) {
+	cases := []struct {
+		detail   armErrorDetail
+		expected armErrorKind
+	}{
+		{armErrorDetail{Code: "AuthorizationFailed", Message: "The client does not have authorization"}, armErrorAuthorization},
+		{armErrorDetail{Code: "LinkedAuthorizationFailed"}, armErrorAuthorization},
+		{armErrorDetail{Code: "ResourceGroupNotFound"}, armErrorResourceGroupNotFound},
+		{armErrorDetail{Code: "ResourceNotFound", Message: "The Resource 'Microsoft.MachineLearningServices/workspaces/ws' was not found."}, armErrorWorkspaceNotFound},
+		{armErrorDetail{Code: "ResourceNotFound", Message: "The datastore was not found."}, armErrorUnknown},
+		{armErrorDetail{Code: "BadRequest", Details: []armErrorDetail{{Code: "QuotaExceeded"}}}, armErrorQuota},
+		{armErrorDetail{Code: "UserError", Message: "Not enough quota available for Standard_DS3_v2."}, armErrorQuota},
+		{armErrorDetail{Code: "UserError", Message: "Unable to access the storage account with the given credentials."}, armErrorStorageAccess},
+		{armErrorDetail{Code: "UserError", Message: "The name is not valid."}, armErrorUnknown},
+		{
+			// The authorization failure is the root cause of the storage access one
+			armErrorDetail{
+				Code:    "UserError",
+				Message: "Unable to access the blob container.",
+				Details: []armErrorDetail{{Code: "AuthorizationFailed"}},
+			},
+			armErrorAuthorization,
+		},
+	}
+	for _, c := range cases {
+		if got := classifyArmError(&c.detail); got != c.expected {
+			t.Errorf("classifyArmError(%+v) = %v, expected %v", c.detail, got, c.expected)
+		}
+	}
+}
+
+func TestArmErrorDiagnostics(t *testing.T) {
+	err := &armResponseError{http.StatusBadRequest, testArmNestedError}
+	diags := armErrorDiagnostics("Error creating datastore ds", err, datastoreErrorPaths)
+	if len(diags) != 1 {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	d := diags[0]
+	if d.Summary != "Error creating datastore ds: storage not accessible" {
+		t.Errorf("unexpected summary %q", d.Summary)
+	}
+	if !strings.Contains(d.Detail, "Validation of the datastore failed.") ||
+		!strings.HasSuffix(d.Detail, armErrorRemediations[armErrorStorageAccess].remediation) {
+		t.Errorf("unexpected detail %q", d.Detail)
+	}
+	if !d.AttributePath.Equals(cty.GetAttrPath("auth")) {
+		t.Errorf("unexpected attribute path %v", d.AttributePath)
+	}
+
+	err = &armResponseError{http.StatusNotFound,
+		`{"error": {"code": "ResourceNotFound", "message": "The workspace ws was not found."}}`}
+	d = armErrorDiagnostics("Error reading datastore ds", err, datastoreErrorPaths)[0]
+	if d.Summary != "Error reading datastore ds: workspace not found" ||
+		!d.AttributePath.Equals(cty.GetAttrPath("workspace_name")) {
+		t.Errorf("unexpected diagnostic %+v", d)
+	}
+
+	err = &armResponseError{http.StatusConflict, `{"error": {"code": "Conflict", "message": "Busy."}}`}
+	d = armErrorDiagnostics("Error updating datastore ds", err, datastoreErrorPaths)[0]
+	if d.Summary != "Error updating datastore ds" || d.AttributePath != nil || d.Detail != "Busy.\n\nError code: Conflict" {
+		t.Errorf("unexpected diagnostic %+v", d)
+	}
+
+	d = armErrorDiagnostics("Error deleting datastore ds", errors.New("connection reset"), datastoreErrorPaths)[0]
+	if d.Summary != "Error deleting datastore ds" || d.Detail != "connection reset" || d.AttributePath != nil {
+		t.Errorf("unexpected diagnostic %+v", d)
+	}
+}
+
+func TestArmErrorFrameworkDiagnostics(t *testing.T) {
+	err := &armResponseError{http.StatusForbidden, `{"error": {"code": "AuthorizationFailed", "message": "denied"}}`}
+	diags := armErrorFrameworkDiagnostics("Error creating datastore ds", err, datastoreErrorPaths)
+	if len(diags) != 1 {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	d, ok := diags[0].(fwdiag.DiagnosticWithPath)
+	if !ok || !d.Path().Equal(path.Root("workspace_name")) {
+		t.Fatalf("unexpected diagnostic %+v", diags[0])
+	}
+	if d.Summary() != "Error creating datastore ds: authorization failed" {
+		t.Errorf("unexpected summary %q", d.Summary())
+	}
+
+	if p, ok := frameworkPath(cty.GetAttrPath("auth").IndexInt(0).GetAttr("client_id")); !ok ||
+		!p.Equal(path.Root("auth").AtListIndex(0).AtName("client_id")) {
+		t.Errorf("unexpected path %v", p)
+	}
+	if _, ok := frameworkPath(nil); ok {
+		t.Errorf("an empty path has been converted")
+	}
+}
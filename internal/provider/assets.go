@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// assetCreationTimeout bounds the wait for the asynchronous creation and deletion of assets, which is
+// otherwise bounded by the timeouts of the resource operations.
+const assetCreationTimeout = 30 * time.Minute
+
+// assetContainerPath returns the ARM ID of the container of the asset of the Azure ML Workspace provided as
+// argument. The assetType argument is the collection to which the asset belongs to (e.g. "environments").
+func assetContainerPath(c *armClient, resourceGroupName, workspaceName, assetType, name string) string {
+	return fmt.Sprintf("%s/%s/%s", c.workspaceId(resourceGroupName, workspaceName), assetType, name)
+}
+
+// resourceAssetContainerPath returns the ARM ID of the container of an asset which belongs either to an
+// Azure ML Workspace or to an Azure ML Registry, depending on which of the workspace_name and registry_name
+// arguments of the resource is set.
+func resourceAssetContainerPath(c *armClient, d *schema.ResourceData, assetType string) string {
+	resourceGroupName := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+	if registryName := d.Get("registry_name").(string); registryName != "" {
+		return fmt.Sprintf("%s/%s/%s", c.registryId(resourceGroupName, registryName), assetType, name)
+	}
+	return assetContainerPath(c, resourceGroupName, d.Get("workspace_name").(string), assetType, name)
+}
+
+// assetVersionPath returns the ARM ID of a version of the asset container provided as argument.
+func assetVersionPath(containerPath, version string) string {
+	return fmt.Sprintf("%s/versions/%s", containerPath, version)
+}
+
+type assetContainerResource struct {
+	Id         string                   `json:"id,omitempty"`
+	Name       string                   `json:"name,omitempty"`
+	Properties assetContainerProperties `json:"properties"`
+}
+
+type assetContainerProperties struct {
+	DataType      string            `json:"dataType,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	Tags          map[string]string `json:"tags,omitempty"`
+	IsArchived    bool              `json:"isArchived,omitempty"`
+	LatestVersion string            `json:"latestVersion,omitempty"`
+	NextVersion   string            `json:"nextVersion,omitempty"`
+}
+
+type assetVersionList struct {
+	Value []struct {
+		Id   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"value"`
+	NextLink string `json:"nextLink,omitempty"`
+}
+
+// customizeDiffAssetVersion checks that exactly one of the version or the auto_increment arguments of an
+// asset version is set.
+func customizeDiffAssetVersion(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	versionIsSet := !d.GetRawConfig().GetAttr("version").IsNull()
+	autoIncrement := d.Get("auto_increment").(bool)
+	if !autoIncrement && !versionIsSet {
+		return fmt.Errorf("%q is required when %q is false", "version", "auto_increment")
+	}
+	if autoIncrement && versionIsSet {
+		return fmt.Errorf("%q cannot be set when %q is true", "version", "auto_increment")
+	}
+	return nil
+}
+
+// ensureAssetContainer creates the asset container at the path provided as argument, unless it already exists.
+func ensureAssetContainer(ctx context.Context, c *armClient, containerPath string, container *assetContainerResource) error {
+	err := c.get(ctx, containerPath, new(assetContainerResource))
+	if err == nil {
+		return nil
+	}
+	var notFoundErr *resourceNotFoundError
+	if !errors.As(err, &notFoundErr) {
+		return err
+	}
+	resp, err := c.put(ctx, containerPath, container, nil)
+	if err != nil {
+		return err
+	}
+	// Registries create the containers asynchronously
+	if resp.StatusCode == http.StatusAccepted {
+		return waitForCreation(ctx, c, resp, containerPath, assetCreationTimeout)
+	}
+	return nil
+}
+
+// putAssetVersion creates or updates the asset version at the path provided as argument, waiting for its
+// creation if it is accepted asynchronously, as it happens for the assets of registries.
+func putAssetVersion(ctx context.Context, c *armClient, path string, in interface{}) error {
+	resp, err := c.put(ctx, path, in, nil)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		return waitForCreation(ctx, c, resp, path, assetCreationTimeout)
+	}
+	return nil
+}
+
+// putProvisionedAssetVersion creates or updates the asset version at the path provided as argument and waits
+// for its provisioning, for the assets which are provisioned asynchronously also in workspaces, such as the
+// entities and the feature sets of feature stores.
+func putProvisionedAssetVersion(ctx context.Context, c *armClient, path string, in interface{}, timeout time.Duration) error {
+	resp, err := c.put(ctx, path, in, nil)
+	if err != nil {
+		return err
+	}
+	return waitForCreation(ctx, c, resp, path, timeout)
+}
+
+// nextAssetVersion returns the version that Azure ML would assign to the next version of the asset container
+// provided as argument.
+func nextAssetVersion(ctx context.Context, c *armClient, containerPath string) (string, error) {
+	container := new(assetContainerResource)
+	err := c.get(ctx, containerPath, container)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return "1", nil
+		}
+		return "", err
+	}
+	if container.Properties.NextVersion != "" {
+		return container.Properties.NextVersion, nil
+	}
+	if container.Properties.LatestVersion == "" {
+		return "1", nil
+	}
+	latest, err := strconv.Atoi(container.Properties.LatestVersion)
+	if err != nil {
+		return "", fmt.Errorf(
+			"unable to increment version %q of %s: only numeric versions can be incremented",
+			container.Properties.LatestVersion,
+			containerPath,
+		)
+	}
+	return strconv.Itoa(latest + 1), nil
+}
+
+// archiveAssetVersion archives the asset version at the path provided as argument, for the assets whose
+// versions cannot be deleted.
+func archiveAssetVersion(ctx context.Context, c *armClient, path string) error {
+	var version map[string]interface{}
+	if err := c.get(ctx, path, &version); err != nil {
+		return err
+	}
+	props, ok := version["properties"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("unable to archive %s: the asset version has no properties", path)
+	}
+	if archived, _ := props["isArchived"].(bool); archived {
+		return nil
+	}
+	props["isArchived"] = true
+	_, err := c.put(ctx, path, map[string]interface{}{"properties": props}, nil)
+	return err
+}
+
+// deleteAssetVersion deletes the version of the asset container provided as argument. The container is
+// deleted as well if it has no versions left.
+func deleteAssetVersion(ctx context.Context, c *armClient, containerPath, version string) error {
+	path := assetVersionPath(containerPath, version)
+	resp, err := c.delete(ctx, path)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		if err := waitForDeletion(ctx, c, resp, path, assetCreationTimeout); err != nil {
+			return err
+		}
+	}
+
+	versions := new(assetVersionList)
+	if err := c.get(ctx, containerPath+"/versions", versions); err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil
+		}
+		return err
+	}
+	if len(versions.Value) > 0 || versions.NextLink != "" {
+		return nil
+	}
+
+	if _, err := c.delete(ctx, containerPath); err != nil {
+		var notFoundErr *resourceNotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+)
+
+// attachedComputeSchema returns the schema of a compute which attaches to an Azure ML Workspace an existing
+// Azure resource, extended with the arguments specific to the type of the compute. All the arguments force
+// the replacement of the compute, since attached computes cannot be updated.
+func attachedComputeSchema(kind, resourceIdDescription string, withIdentity bool, extra map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"resource_group_name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  fmt.Sprintf("The name of the resource group of the Azure ML Workspace to which the %s is attached.", kind),
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"workspace_name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  fmt.Sprintf("The name of the Azure ML Workspace to which the %s is attached.", kind),
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+			Description: fmt.Sprintf(
+				"The name of the %s. It must be between 2 and 16 characters long, start with a letter and contain "+
+					"only letters, digits and hyphens.",
+				kind,
+			),
+			ForceNew: true,
+			ValidateFunc: validation.StringMatch(
+				regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9-]{1,15}$"),
+				"must be between 2 and 16 characters long, start with a letter and contain only letters, digits and hyphens",
+			),
+		},
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: fmt.Sprintf("The ID of the %s.", kind),
+		},
+		"location": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			Description: fmt.Sprintf(
+				"The Azure region of the %s. Defaults to the location of the Azure ML Workspace.",
+				kind,
+			),
+			ForceNew: true,
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "",
+			Description: fmt.Sprintf("The description of the %s.", kind),
+			ForceNew:    true,
+		},
+		"resource_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  resourceIdDescription,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+	}
+	if withIdentity {
+		s["identity"] = managedIdentitySchema(true)
+	}
+	for k, v := range extra {
+		s[k] = v
+	}
+	return s
+}
+
+// attachCompute attaches to the workspace the existing resource referenced by the resource_id argument,
+// with the properties specific to the compute type provided as argument, and waits for its provisioning.
+func attachCompute(ctx context.Context, d *schema.ResourceData, meta interface{}, computeType string, properties interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	location := d.Get("location").(string)
+	if location == "" {
+		ws, err := client.arm.getWorkspace(ctx, resourceGroupName, workspaceName)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("unable to retrieve the location of workspace %s: %w", workspaceName, err))
+		}
+		location = ws.Location
+	}
+
+	var rawProperties json.RawMessage
+	if properties != nil {
+		b, err := json.Marshal(properties)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		rawProperties = b
+	}
+	compute := &attachedComputeResource{
+		Location: location,
+		Properties: attachedCompute{
+			ComputeType: computeType,
+			Description: d.Get("description").(string),
+			ResourceId:  d.Get("resource_id").(string),
+			Properties:  rawProperties,
+		},
+	}
+	if v, ok := d.GetOk("identity"); ok {
+		compute.Identity = expandManagedIdentity(v.([]interface{}))
+	}
+
+	path := computePath(client.arm, resourceGroupName, workspaceName, name)
+	resp, err := client.arm.put(ctx, path, compute, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(path)
+	created, err := waitForComputeProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for compute %s to be attached: %w", name, err))
+	}
+
+	d.SetId(created.Id)
+	return nil
+}
+
+// readAttachedCompute retrieves the attached compute of the resource and sets its common arguments. It returns
+// nil if the compute does not exist anymore or its provisioning failed, in which case the ID of the resource is
+// cleared.
+func readAttachedCompute(ctx context.Context, d *schema.ResourceData, meta interface{}, withIdentity bool) (*attachedComputeResource, diag.Diagnostics) {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	compute := new(attachedComputeResource)
+	err := getProvisioned(ctx, client.arm, computePath(client.arm, resourceGroupName, workspaceName, name), compute)
+	if err != nil {
+		if diags, ok := failedProvisioningDiagnostics(d, fmt.Sprintf("compute %s", name), err); ok {
+			return nil, diags
+		}
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+			return nil, nil
+		}
+		return nil, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error reading compute %s", name),
+			Detail:   err.Error(),
+		}}
+	}
+
+	d.SetId(compute.Id)
+	if err := d.Set("location", compute.Location); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if err := d.Set("description", compute.Properties.Description); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if err := d.Set("resource_id", compute.Properties.ResourceId); err != nil {
+		return nil, diag.FromErr(err)
+	}
+	if withIdentity {
+		if err := d.Set("identity", flattenManagedIdentity(compute.Identity)); err != nil {
+			return nil, diag.FromErr(err)
+		}
+	}
+	return compute, nil
+}
+
+// detachCompute detaches the compute of the resource from the workspace, without deleting the underlying
+// Azure resource.
+func detachCompute(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	path := computePath(client.arm, resourceGroupName, workspaceName, name)
+
+	resp, err := client.arm.delete(ctx, path+"?underlyingResourceAction=Detach")
+	if err == nil {
+		err = waitForDeletion(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutDelete))
+	}
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error detaching compute %s.", name),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+type attachedComputeResource struct {
+	Id         string              `json:"id,omitempty"`
+	Name       string              `json:"name,omitempty"`
+	Location   string              `json:"location,omitempty"`
+	Identity   *armManagedIdentity `json:"identity,omitempty"`
+	Properties attachedCompute     `json:"properties"`
+}
+
+type attachedCompute struct {
+	ComputeType       string          `json:"computeType"`
+	Description       string          `json:"description,omitempty"`
+	ResourceId        string          `json:"resourceId"`
+	ProvisioningState string          `json:"provisioningState,omitempty"`
+	Properties        json.RawMessage `json:"properties,omitempty"`
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const testComputeId = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/computes/compute"
+
+// testComputeServer is an ARM server managing a single attached compute, whose GET returns the compute provided
+// to newTestComputeServer until it is deleted.
+type testComputeServer struct {
+	*httptest.Server
+	mu          sync.Mutex
+	compute     string
+	deleted     bool
+	putBody     map[string]interface{}
+	deleteQuery url.Values
+}
+
+func newTestComputeServer(t *testing.T, compute string) *testComputeServer {
+	s := &testComputeServer{compute: compute}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !strings.HasSuffix(r.URL.Path, "/workspaces/ws/computes/compute") {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := ioutil.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &s.putBody); err != nil {
+				t.Errorf("unable to decode the request body: %v", err)
+			}
+			_, _ = w.Write([]byte(s.compute))
+		case http.MethodDelete:
+			if s.deleted {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			s.deleteQuery = r.URL.Query()
+			s.deleted = true
+			w.WriteHeader(http.StatusOK)
+		default:
+			if s.deleted {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(s.compute))
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testComputeServer) setCompute(compute string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.compute = compute
+}
+
+func (s *testComputeServer) client() *apiClient {
+	return &apiClient{arm: newTestArmClient(s.Server)}
+}
+
+func TestDetachCompute(t *testing.T) {
+	server := newTestComputeServer(t, `{"id": "`+testComputeId+`", "properties": {"provisioningState": "Succeeded"}}`)
+	r := resourceVirtualMachineCompute()
+	d := r.TestResourceData()
+	d.SetId(testComputeId)
+	for name, value := range map[string]string{"resource_group_name": "rg", "workspace_name": "ws", "name": "compute"} {
+		if err := d.Set(name, value); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+
+	if diags := detachCompute(context.Background(), d, server.client()); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	if action := server.deleteQuery.Get("underlyingResourceAction"); action != "Detach" {
+		t.Errorf("unexpected underlying resource action %q", action)
+	}
+
+	// A compute detached outside of Terraform is not an error
+	if diags := detachCompute(context.Background(), d, server.client()); diags.HasError() {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+}
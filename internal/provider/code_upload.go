@@ -0,0 +1,283 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// codeIgnoreFiles are the files of the root of a source directory from which the ignore patterns are read, in
+// order of precedence: as done by the Azure ML CLI, .gitignore is ignored when .amlignore exists.
+var codeIgnoreFiles = []string{".amlignore", ".gitignore"}
+
+// blobStorageApiVersion is the version of the Azure Blob Storage REST API used for uploading code.
+const blobStorageApiVersion = "2021-08-06"
+
+// ignoreRule is a pattern in the .gitignore syntax.
+type ignoreRule struct {
+	regexp  *regexp.Regexp
+	negate  bool
+	dirOnly bool
+}
+
+// parseIgnoreRules parses the patterns provided as argument, in the .gitignore syntax. Empty lines and comments
+// are skipped.
+func parseIgnoreRules(patterns []string) ([]ignoreRule, error) {
+	rules := make([]ignoreRule, 0, len(patterns))
+	for _, p := range patterns {
+		p = strings.TrimRight(p, " \t\r")
+		if p == "" || strings.HasPrefix(p, "#") {
+			continue
+		}
+		rule := ignoreRule{}
+		if strings.HasPrefix(p, "!") {
+			rule.negate = true
+			p = p[1:]
+		} else if strings.HasPrefix(p, `\`) {
+			p = p[1:]
+		}
+		if strings.HasSuffix(p, "/") {
+			rule.dirOnly = true
+			p = strings.TrimRight(p, "/")
+		}
+		// Patterns without a slash match the name of the files at any level of the directory
+		if !strings.Contains(p, "/") {
+			p = "**/" + p
+		}
+		r, err := regexp.Compile(ignorePatternToRegexp(strings.TrimPrefix(p, "/")))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %w", p, err)
+		}
+		rule.regexp = r
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// ignorePatternToRegexp converts a .gitignore pattern into a regular expression matching the slash separated
+// paths relative to the source directory.
+func ignorePatternToRegexp(pattern string) string {
+	b := &strings.Builder{}
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "/**") && i+3 == len(pattern):
+			b.WriteString("/.*")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		case c == '[':
+			if j := strings.IndexByte(pattern[i:], ']'); j > 0 {
+				class := pattern[i+1 : i+j]
+				if strings.HasPrefix(class, "!") {
+					class = "^" + class[1:]
+				}
+				b.WriteString("[" + class + "]")
+				i += j
+			} else {
+				b.WriteString(`\[`)
+			}
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			b.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return b.String()
+}
+
+// isIgnored returns true if the slash separated path relative to the source directory is ignored by the rules,
+// the last matching rule taking precedence as in .gitignore.
+func isIgnored(rules []ignoreRule, relPath string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.regexp.MatchString(relPath) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// readCodeIgnorePatterns returns the patterns of the ignore file of the source directory, if any.
+func readCodeIgnorePatterns(sourceDir string) ([]string, error) {
+	for _, name := range codeIgnoreFiles {
+		f, err := os.Open(filepath.Join(sourceDir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		var patterns []string
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			patterns = append(patterns, scanner.Text())
+		}
+		return patterns, scanner.Err()
+	}
+	return nil, nil
+}
+
+// listCodeFiles returns the sorted, slash separated paths relative to the source directory of the files which
+// are not ignored by the ignore file of the directory, by the additional patterns provided as argument or,
+// since it is never part of the code, by being in the .git directory.
+func listCodeFiles(sourceDir string, ignorePatterns []string) ([]string, error) {
+	info, err := os.Stat(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is not a directory", sourceDir)
+	}
+
+	filePatterns, err := readCodeIgnorePatterns(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	patterns := append([]string{".git/"}, filePatterns...)
+	rules, err := parseIgnoreRules(append(patterns, ignorePatterns...))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0)
+	err = filepath.Walk(sourceDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(sourceDir, p)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if isIgnored(rules, rel, info.IsDir()) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// codeContentHash returns a hash of the paths and of the content of the files of the source directory provided
+// as argument, which does not depend on the order in which the files are listed nor on their metadata.
+func codeContentHash(sourceDir string, files []string) (string, error) {
+	sorted := append([]string(nil), files...)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	for _, rel := range sorted {
+		f, err := os.Open(filepath.Join(sourceDir, filepath.FromSlash(rel)))
+		if err != nil {
+			return "", err
+		}
+		fileHash := sha256.New()
+		_, err = io.Copy(fileHash, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(h, "%s\x00%x\x00", rel, fileHash.Sum(nil))
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+// uploadCodeFiles uploads the files of the source directory provided as argument as block blobs below the
+// blob directory referenced by the SAS URI provided as argument.
+func uploadCodeFiles(ctx context.Context, httpClient *http.Client, sasUri, sourceDir string, files []string) error {
+	base, err := url.Parse(sasUri)
+	if err != nil {
+		return fmt.Errorf("invalid SAS URI: %w", err)
+	}
+	for _, rel := range files {
+		if err := uploadBlob(ctx, httpClient, base, sourceDir, rel); err != nil {
+			return fmt.Errorf("unable to upload %s: %w", rel, err)
+		}
+	}
+	return nil
+}
+
+func uploadBlob(ctx context.Context, httpClient *http.Client, base *url.URL, sourceDir, rel string) error {
+	f, err := os.Open(filepath.Join(sourceDir, filepath.FromSlash(rel)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	blobUrl := *base
+	blobUrl.Path = path.Join(base.Path, rel)
+	blobUrl.RawPath = ""
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, blobUrl.String(), f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = info.Size()
+	if info.Size() == 0 {
+		req.Body = http.NoBody
+	}
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+	req.Header.Set("x-ms-version", blobStorageApiVersion)
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("HTTP Response is in error [status code %d]: %s", resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// blobDirectoryUri returns the URI of the blob directory referenced by the SAS URI provided as argument, without
+// the SAS token.
+func blobDirectoryUri(sasUri string) (string, error) {
+	u, err := url.Parse(sasUri)
+	if err != nil {
+		return "", fmt.Errorf("invalid SAS URI: %w", err)
+	}
+	u.RawQuery = ""
+	return strings.TrimSuffix(u.String(), "/") + "/", nil
+}
@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// blobStorageStandIn is a local stand-in of the Azure Blob Storage service storing the uploaded block blobs.
+type blobStorageStandIn struct {
+	mu    sync.Mutex
+	blobs map[string]string
+	sas   string
+}
+
+func newBlobStorageStandIn(t *testing.T, sas string) (*blobStorageStandIn, *httptest.Server) {
+	s := &blobStorageStandIn{blobs: map[string]string{}, sas: sas}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		if r.URL.RawQuery != s.sas {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("AuthenticationFailed"))
+			return
+		}
+		if r.Header.Get("x-ms-blob-type") != "BlockBlob" || r.Header.Get("x-ms-version") == "" {
+			t.Errorf("unexpected headers %v", r.Header)
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		s.mu.Lock()
+		s.blobs[r.URL.Path] = string(body)
+		s.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+	}))
+	return s, server
+}
+
+func writeCodeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestIsIgnored(t *testing.T) {
+	rules, err := parseIgnoreRules([]string{
+		"# comment",
+		"",
+		"*.pyc",
+		"build/",
+		"/data",
+		"docs/**/*.md",
+		"logs/*",
+		"!logs/keep.txt",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cases := []struct {
+		path    string
+		isDir   bool
+		ignored bool
+	}{
+		{"main.pyc", false, true},
+		{"pkg/module.pyc", false, true},
+		{"main.py", false, false},
+		{"build", true, true},
+		{"src/build", true, true},
+		{"build", false, false},
+		{"data", true, true},
+		{"src/data", true, false},
+		{"docs/index.md", false, true},
+		{"docs/api/index.md", false, true},
+		{"docs/index.rst", false, false},
+		{"logs/run.txt", false, true},
+		{"logs/keep.txt", false, false},
+	}
+	for _, c := range cases {
+		if got := isIgnored(rules, c.path, c.isDir); got != c.ignored {
+			t.Errorf("isIgnored(%q, %v) = %v, expected %v", c.path, c.isDir, got, c.ignored)
+		}
+	}
+}
+
+func TestListCodeFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeCodeFiles(t, dir, map[string]string{
+		"main.py":          "print(1)",
+		"pkg/util.py":      "x = 1",
+		"pkg/util.pyc":     "bytecode",
+		"data/train.csv":   "a,b",
+		"notes.txt":        "notes",
+		".git/HEAD":        "ref: refs/heads/main",
+		".gitignore":       "*.pyc\n",
+		".amlignore":       "data/\n",
+		"tests/test_it.py": "assert True",
+	})
+
+	// .amlignore takes precedence over .gitignore
+	files, err := listCodeFiles(dir, []string{"tests/", "notes.txt"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{".amlignore", ".gitignore", "main.py", "pkg/util.py", "pkg/util.pyc"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("unexpected files %v, expected %v", files, expected)
+	}
+
+	if err := os.Remove(filepath.Join(dir, ".amlignore")); err != nil {
+		t.Fatal(err)
+	}
+	files, err = listCodeFiles(dir, []string{"!notes.txt"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = []string{".gitignore", "data/train.csv", "main.py", "notes.txt", "pkg/util.py", "tests/test_it.py"}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("unexpected files %v, expected %v", files, expected)
+	}
+
+	if _, err := listCodeFiles(filepath.Join(dir, "main.py"), nil); err == nil {
+		t.Errorf("expected error listing a file")
+	}
+}
+
+func TestCodeContentHash(t *testing.T) {
+	dir := t.TempDir()
+	writeCodeFiles(t, dir, map[string]string{"a.py": "a", "b/c.py": "c"})
+
+	hash, err := codeContentHash(dir, []string{"b/c.py", "a.py"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	same, _ := codeContentHash(dir, []string{"a.py", "b/c.py"})
+	if hash != same {
+		t.Errorf("hash depends on the order of the files")
+	}
+
+	writeCodeFiles(t, dir, map[string]string{"b/c.py": "changed"})
+	changed, _ := codeContentHash(dir, []string{"a.py", "b/c.py"})
+	if changed == hash {
+		t.Errorf("hash did not change with the content")
+	}
+
+	writeCodeFiles(t, dir, map[string]string{"b/d.py": "changed"})
+	renamed, _ := codeContentHash(dir, []string{"a.py", "b/d.py"})
+	if renamed == changed {
+		t.Errorf("hash did not change with the path")
+	}
+}
+
+func TestUploadCodeVersion(t *testing.T) {
+	dir := t.TempDir()
+	writeCodeFiles(t, dir, map[string]string{"main.py": "print(1)", "pkg/util.py": "x = 1", "empty.txt": ""})
+
+	storage, blobServer := newBlobStorageStandIn(t, "sv=2021&sig=secret")
+	defer blobServer.Close()
+
+	var sasUri string
+	armServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasSuffix(r.URL.Path, "/codes/train/versions/1/startPendingUpload") {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		request := new(pendingUploadRequest)
+		_ = json.NewDecoder(r.Body).Decode(request)
+		if request.PendingUploadType != "TemporaryBlobReference" {
+			t.Errorf("unexpected pending upload type %q", request.PendingUploadType)
+		}
+		_ = json.NewEncoder(w).Encode(&pendingUploadResponse{
+			PendingUploadId: "upload-1",
+			BlobReferenceForConsumption: &blobReference{
+				Credential: &blobReferenceCredential{CredentialType: "SAS", SasUri: sasUri},
+			},
+		})
+	}))
+	defer armServer.Close()
+	sasUri = blobServer.URL + "/azureml-blobstore/LocalUpload/upload-1/train?sv=2021&sig=secret"
+
+	c := newTestArmClient(armServer)
+	c.httpClient = http.DefaultClient
+	files := []string{"empty.txt", "main.py", "pkg/util.py"}
+	codeUri, err := uploadCodeVersion(context.Background(), c, "/ws/codes/train/versions/1", dir, files)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if codeUri != blobServer.URL+"/azureml-blobstore/LocalUpload/upload-1/train/" {
+		t.Errorf("unexpected code URI %q", codeUri)
+	}
+	expected := map[string]string{
+		"/azureml-blobstore/LocalUpload/upload-1/train/empty.txt":   "",
+		"/azureml-blobstore/LocalUpload/upload-1/train/main.py":     "print(1)",
+		"/azureml-blobstore/LocalUpload/upload-1/train/pkg/util.py": "x = 1",
+	}
+	if !reflect.DeepEqual(storage.blobs, expected) {
+		t.Errorf("unexpected blobs %v", storage.blobs)
+	}
+
+	sasUri = blobServer.URL + "/azureml-blobstore/LocalUpload/upload-1/train?sig=expired"
+	if _, err := uploadCodeVersion(context.Background(), c, "/ws/codes/train/versions/1", dir, files); err == nil ||
+		!strings.Contains(err.Error(), "status code 403") {
+		t.Errorf("expected upload error, got %v", err)
+	}
+}
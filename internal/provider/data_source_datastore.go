@@ -3,173 +3,185 @@ package provider
 import (
 	"context"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/orobix/azureml-go-sdk/workspace"
 )
 
-func dataSourceDatastore() *schema.Resource {
-	return &schema.Resource{
-		Description: "Use this resource to access the information of a specific Datastore of a certain Azure ML " +
-			"Workspace. Authentication credentials are not included in the provided information.",
+var _ datasource.DataSourceWithConfigure = &datastoreDataSource{}
 
-		ReadContext: dataSourceDatastoreRead,
+// datastoreDataSource provides the information of a datastore of an Azure ML Workspace, excluding its
+// credentials.
+type datastoreDataSource struct {
+	client *apiClient
+}
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+type datastoreDataSourceModel struct {
+	ResourceGroupName    types.String `tfsdk:"resource_group_name"`
+	WorkspaceName        types.String `tfsdk:"workspace_name"`
+	Name                 types.String `tfsdk:"name"`
+	Id                   types.String `tfsdk:"id"`
+	Description          types.String `tfsdk:"description"`
+	IsDefault            types.Bool   `tfsdk:"is_default"`
+	StorageType          types.String `tfsdk:"storage_type"`
+	StorageAccountName   types.String `tfsdk:"storage_account_name"`
+	StorageContainerName types.String `tfsdk:"storage_container_name"`
+	CredentialsType      types.String `tfsdk:"credentials_type"`
+	CreationDate         types.String `tfsdk:"creation_date"`
+	CreationUser         types.String `tfsdk:"creation_user"`
+	CreationUserType     types.String `tfsdk:"creation_user_type"`
+	LastModifiedDate     types.String `tfsdk:"last_modified_date"`
+	LastModifiedUser     types.String `tfsdk:"last_modified_user"`
+	LastModifiedUserType types.String `tfsdk:"last_modified_user_type"`
+}
+
+func newDatastoreDataSource() datasource.DataSource {
+	return &datastoreDataSource{}
+}
 
-		Schema: map[string]*schema.Schema{
-			"resource_group_name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The name of the resource group of the Azure ML Workspace to which the datastore belongs to.",
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-			"workspace_name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The name of the Azure ML Workspace to which the datastore belongs to.",
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-			"name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The name of the datastore.",
-				ValidateFunc: validation.StringIsNotEmpty,
-			},
-			"id": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The ID of the datastore.",
-			},
-			"description": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The description of the datastore.",
-			},
-			"is_default": {
-				Type:        schema.TypeBool,
-				Computed:    true,
-				Description: "Is the datastore the default datastore of the Azure ML Workspace?",
-			},
-			"storage_type": {
-				Type:     schema.TypeString,
+func (d *datastoreDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_datastore"
+}
+
+func (d *datastoreDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this resource to access the information of a specific Datastore of a certain Azure ML " +
+			"Workspace. Authentication credentials are not included in the provided information.",
+		Attributes: map[string]schema.Attribute{
+			"resource_group_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the resource group of the Azure ML Workspace to which the datastore belongs to.",
+				Validators:          []validator.String{StringNotEmptyValidator{}},
+			},
+			"workspace_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the Azure ML Workspace to which the datastore belongs to.",
+				Validators:          []validator.String{StringNotEmptyValidator{}},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the datastore.",
+				Validators:          []validator.String{StringNotEmptyValidator{}},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the datastore.",
+			},
+			"description": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The description of the datastore.",
+			},
+			"is_default": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Is the datastore the default datastore of the Azure ML Workspace?",
+			},
+			"storage_type": schema.StringAttribute{
 				Computed: true,
-				Description: fmt.Sprintf(
-					"The type of the storage to which the datstore is linked to. Possible values are: %+q",
+				MarkdownDescription: fmt.Sprintf(
+					"The type of the storage to which the datastore is linked to. Possible values are: %+q.",
 					GetAllowedStorageTypes(),
 				),
 			},
-			"storage_account_name": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The name of the Storage Account to which the datastore is linked to.",
+			"storage_account_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the Storage Account to which the datastore is linked to.",
 			},
-			"storage_container_name": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The name of the Storage Container to which the datastore is linked to.",
+			"storage_container_name": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The name of the Storage Container to which the datastore is linked to.",
 			},
-			"credentials_type": {
-				Type:     schema.TypeString,
+			"credentials_type": schema.StringAttribute{
 				Computed: true,
-				Description: fmt.Sprintf(
-					"The type of credentials used for authenticating with the underlying storage. ",
-					//NewDatastoreCredentialsTypeValidator().allowedTypes,
+				MarkdownDescription: fmt.Sprintf(
+					"The type of credentials used for authenticating with the underlying storage. Possible values are: %+q.",
+					GetAllowedCredentialTypes(),
 				),
 			},
-			"creation_date": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The timestamp corresponding to the creation of the datastore.",
+			"creation_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp corresponding to the creation of the datastore.",
 			},
-			"creation_user": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The user that created the datastore.",
+			"creation_user": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The user that created the datastore.",
 			},
-			"creation_user_type": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The kind of user that created the datastore (Service Principal or User).",
+			"creation_user_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The kind of user that created the datastore (Service Principal or User).",
 			},
-			"last_modified_date": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The timestamp corresponding to the last update of the datastore.",
+			"last_modified_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp corresponding to the last update of the datastore.",
 			},
-			"last_modified_user": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The user that last updated the datastore.",
+			"last_modified_user": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The user that last updated the datastore.",
 			},
-			"last_modified_user_type": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The kind of user that last updated the datastore (Service Principal or User).",
+			"last_modified_user_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The kind of user that last updated the datastore (Service Principal or User).",
 			},
 		},
 	}
 }
 
-func dataSourceDatastoreRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := meta.(*apiClient)
-	name := d.Get("name").(string)
-	resourceGroupName := d.Get("resource_group_name").(string)
-	workspaceName := d.Get("workspace_name").(string)
-
-	ds, err := client.ws.GetDatastore(resourceGroupName, workspaceName, name)
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error retrieving datastore %s", name),
-			Detail:   err.Error(),
-		})
-		return diags
-	}
-
-	if err := d.Set("description", ds.Description); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("is_default", ds.IsDefault); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("storage_type", ds.StorageType); err != nil {
-		return diag.FromErr(err)
+func (d *datastoreDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
 	}
-	if err := d.Set("storage_account_name", ds.StorageAccountName); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("storage_container_name", ds.StorageContainerName); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("credentials_type", ds.Auth.CredentialsType); err != nil {
-		return diag.FromErr(err)
+	client, ok := req.ProviderData.(*apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *apiClient, got %T.", req.ProviderData),
+		)
+		return
 	}
+	d.client = client
+}
 
-	if err := d.Set("creation_date", ds.SystemData.CreationDate.Format(defaultDateFormat)); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("creation_user", ds.SystemData.CreationUser); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("creation_user_type", ds.SystemData.CreationUserType); err != nil {
-		return diag.FromErr(err)
+func (d *datastoreDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	resp.Diagnostics.Append(configuredClientDiagnostics(d.client)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if err := d.Set("last_modified_date", ds.SystemData.CreationDate.Format(defaultDateFormat)); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("last_modified_user", ds.SystemData.LastModifiedUser); err != nil {
-		return diag.FromErr(err)
+	var data datastoreDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-	if err := d.Set("last_modified_user_type", ds.SystemData.LastModifiedUserType); err != nil {
-		return diag.FromErr(err)
+
+	name := data.Name.ValueString()
+	ds, err := getDatastore(ctx, d.client.arm, data.ResourceGroupName.ValueString(), data.WorkspaceName.ValueString(), name)
+	if err != nil {
+		resp.Diagnostics.Append(armErrorFrameworkDiagnostics(
+			fmt.Sprintf("Error retrieving datastore %s", name),
+			err,
+			workspaceErrorPaths(nil),
+		)...)
+		return
 	}
 
-	d.SetId(ds.Id)
+	data.setDatastore(ds)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
 
-	return diags
+func (m *datastoreDataSourceModel) setDatastore(ds *workspace.Datastore) {
+	m.Id = types.StringValue(ds.Id)
+	m.Name = types.StringValue(ds.Name)
+	m.Description = types.StringValue(ds.Description)
+	m.IsDefault = types.BoolValue(ds.IsDefault)
+	m.StorageType = types.StringValue(ds.StorageType)
+	m.StorageAccountName = types.StringValue(ds.StorageAccountName)
+	m.StorageContainerName = types.StringValue(ds.StorageContainerName)
+	m.CredentialsType = types.StringValue(ds.Auth.CredentialsType)
+	m.CreationDate = types.StringValue(ds.SystemData.CreationDate.Format(defaultDateFormat))
+	m.CreationUser = types.StringValue(ds.SystemData.CreationUser)
+	m.CreationUserType = types.StringValue(ds.SystemData.CreationUserType)
+	m.LastModifiedDate = types.StringValue(ds.SystemData.LastModifiedDate.Format(defaultDateFormat))
+	m.LastModifiedUser = types.StringValue(ds.SystemData.LastModifiedUser)
+	m.LastModifiedUserType = types.StringValue(ds.SystemData.LastModifiedUserType)
 }
@@ -3,123 +3,130 @@ package provider
 import (
 	"context"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/orobix/azureml-go-sdk/workspace"
 	"strconv"
 )
 
-func dataSourceDatastores() *schema.Resource {
-	return &schema.Resource{
-		Description: "Use this resource to retrieve the list of Datastores of a certain Azure ML " +
-			"Workspace. Authentication credentials are not included in the provided information.",
+var _ datasource.DataSourceWithConfigure = &datastoresDataSource{}
 
-		ReadContext: dataSourceDatastoresRead,
+// datastoresDataSource provides the list of the datastores of an Azure ML Workspace, excluding their
+// credentials.
+type datastoresDataSource struct {
+	client *apiClient
+}
 
-		Schema: map[string]*schema.Schema{
-			"resource_group_name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The name of the resource group of the Azure ML Workspace to which the datastore belongs to.",
-				ValidateFunc: validation.StringIsNotEmpty,
+type datastoresDataSourceModel struct {
+	ResourceGroupName types.String               `tfsdk:"resource_group_name"`
+	WorkspaceName     types.String               `tfsdk:"workspace_name"`
+	Id                types.String               `tfsdk:"id"`
+	Datastores        []datastoreDataSourceModel `tfsdk:"datastores"`
+}
+
+func newDatastoresDataSource() datasource.DataSource {
+	return &datastoresDataSource{}
+}
+
+func (d *datastoresDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_datastores"
+}
+
+func (d *datastoresDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this resource to retrieve the list of Datastores of a certain Azure ML " +
+			"Workspace. Authentication credentials are not included in the provided information.",
+		Attributes: map[string]schema.Attribute{
+			"resource_group_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the resource group of the Azure ML Workspace to which the datastore belongs to.",
+				Validators:          []validator.String{StringNotEmptyValidator{}},
 			},
-			"workspace_name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The name of the Azure ML Workspace to which the datastore belongs to.",
-				ValidateFunc: validation.StringIsNotEmpty,
+			"workspace_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the Azure ML Workspace to which the datastore belongs to.",
+				Validators:          []validator.String{StringNotEmptyValidator{}},
 			},
-			"datastores": {
-				Type:     schema.TypeList,
-				Computed: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"resource_group_name": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The name of the resource group of the Azure ML Workspace to which the datastore belongs to.",
-						},
-						"workspace_name": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The name of the Azure ML Workspace to which the datastore belongs to.",
-						},
-						"name": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The name of the datastore.",
-						},
-						"id": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The ID of the datastore.",
-						},
-						"description": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The description of the datastore.",
-						},
-						"is_default": {
-							Type:        schema.TypeBool,
-							Computed:    true,
-							Description: "Is the datastore the default datastore of the Azure ML Workspace?",
-						},
-						"storage_type": {
-							Type:     schema.TypeString,
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this data source.",
+			},
+			"datastores": schema.ListNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "The datastores of the Azure ML Workspace.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"resource_group_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the resource group of the Azure ML Workspace to which the datastore belongs to.",
+						},
+						"workspace_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the Azure ML Workspace to which the datastore belongs to.",
+						},
+						"name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the datastore.",
+						},
+						"id": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The ID of the datastore.",
+						},
+						"description": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The description of the datastore.",
+						},
+						"is_default": schema.BoolAttribute{
+							Computed:            true,
+							MarkdownDescription: "Is the datastore the default datastore of the Azure ML Workspace?",
+						},
+						"storage_type": schema.StringAttribute{
 							Computed: true,
-							Description: fmt.Sprintf(
-								"The type of the storage to which the datstore is linked to. Possible values are: %+q",
+							MarkdownDescription: fmt.Sprintf(
+								"The type of the storage to which the datastore is linked to. Possible values are: %+q.",
 								GetAllowedStorageTypes(),
 							),
 						},
-						"storage_account_name": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The name of the Storage Account to which the datastore is linked to.",
+						"storage_account_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the Storage Account to which the datastore is linked to.",
 						},
-						"storage_container_name": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The name of the Storage Container to which the datastore is linked to.",
+						"storage_container_name": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The name of the Storage Container to which the datastore is linked to.",
 						},
-						"credentials_type": {
-							Type:     schema.TypeString,
+						"credentials_type": schema.StringAttribute{
 							Computed: true,
-							Description: fmt.Sprintf(
-								"The type of credentials used for authenticating with the underlying storage. Possible values are: %+q",
+							MarkdownDescription: fmt.Sprintf(
+								"The type of credentials used for authenticating with the underlying storage. Possible values are: %+q.",
 								GetAllowedCredentialTypes(),
 							),
 						},
-						"creation_date": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The timestamp corresponding to the creation of the datastore.",
+						"creation_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The timestamp corresponding to the creation of the datastore.",
 						},
-						"creation_user": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The user that created the datastore.",
+						"creation_user": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The user that created the datastore.",
 						},
-						"creation_user_type": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The kind of user that created the datastore (Service Principal or User).",
+						"creation_user_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The kind of user that created the datastore (Service Principal or User).",
 						},
-						"last_modified_date": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The timestamp corresponding to the last update of the datastore.",
+						"last_modified_date": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The timestamp corresponding to the last update of the datastore.",
 						},
-						"last_modified_user": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The user that last updated the datastore.",
+						"last_modified_user": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The user that last updated the datastore.",
 						},
-						"last_modified_user_type": {
-							Type:        schema.TypeString,
-							Computed:    true,
-							Description: "The kind of user that last updated the datastore (Service Principal or User).",
+						"last_modified_user_type": schema.StringAttribute{
+							Computed:            true,
+							MarkdownDescription: "The kind of user that last updated the datastore (Service Principal or User).",
 						},
 					},
 				},
@@ -128,71 +135,58 @@ func dataSourceDatastores() *schema.Resource {
 	}
 }
 
-func dataSourceDatastoresRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := meta.(*apiClient)
-	resourceGroupName := d.Get("resource_group_name").(string)
-	workspaceName := d.Get("workspace_name").(string)
+func (d *datastoresDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *apiClient, got %T.", req.ProviderData),
+		)
+		return
+	}
+	d.client = client
+}
 
-	dsl, err := client.ws.GetDatastores(resourceGroupName, workspaceName)
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error retrieving datastores.",
-			Detail:   err.Error(),
-		})
-		return diags
+func (d *datastoresDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	resp.Diagnostics.Append(configuredClientDiagnostics(d.client)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	values, err := listFromDatastores(dsl)
-	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  "Error retrieving datastores.",
-			Detail:   err.Error(),
-		})
-		return diags
+	var data datastoresDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	if err := d.Set("datastores", values); err != nil {
-		return diag.FromErr(err)
+	resourceGroupName := data.ResourceGroupName.ValueString()
+	workspaceName := data.WorkspaceName.ValueString()
+	dsl, err := listDatastores(ctx, d.client.arm, resourceGroupName, workspaceName)
+	if err != nil {
+		resp.Diagnostics.Append(armErrorFrameworkDiagnostics("Error retrieving datastores.", err, workspaceErrorPaths(nil))...)
+		return
 	}
 
-	id, err := hash(fmt.Sprintf("%s%s%d", resourceGroupName, workspaceName, len(values)))
+	data.Datastores = listFromDatastores(resourceGroupName, workspaceName, dsl)
+	id, err := hash(fmt.Sprintf("%s%s%d", resourceGroupName, workspaceName, len(dsl)))
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.AddError("Error retrieving datastores.", err.Error())
+		return
 	}
-	d.SetId(strconv.Itoa(int(id)))
+	data.Id = types.StringValue(strconv.Itoa(int(id)))
 
-	return diags
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func listFromDatastores(dsl []workspace.Datastore) ([]map[string]interface{}, error) {
-	result := make([]map[string]interface{}, len(dsl))
-	for i, ds := range dsl {
-		v, err := fromDatastore(ds)
-		if err != nil {
-			return nil, fmt.Errorf("unable to parse datastore: %w", err)
-		}
-		result[i] = v
+func listFromDatastores(resourceGroupName, workspaceName string, dsl []workspace.Datastore) []datastoreDataSourceModel {
+	result := make([]datastoreDataSourceModel, len(dsl))
+	for i := range dsl {
+		result[i].ResourceGroupName = types.StringValue(resourceGroupName)
+		result[i].WorkspaceName = types.StringValue(workspaceName)
+		result[i].setDatastore(&dsl[i])
 	}
-	return result, nil
-}
-
-func fromDatastore(ds workspace.Datastore) (map[string]interface{}, error) {
-	return map[string]interface{}{
-		"name":                    ds.Name,
-		"description":             ds.Description,
-		"is_default":              ds.IsDefault,
-		"storage_type":            ds.StorageType,
-		"storage_account_name":    ds.StorageAccountName,
-		"storage_container_name":  ds.StorageContainerName,
-		"credentials_type":        ds.Auth.CredentialsType,
-		"creation_date":           ds.SystemData.CreationDate.Format(defaultDateFormat),
-		"creation_user":           ds.SystemData.CreationUser,
-		"creation_user_type":      ds.SystemData.CreationUserType,
-		"last_modified_date":      ds.SystemData.LastModifiedDate.Format(defaultDateFormat),
-		"last_modified_user":      ds.SystemData.LastModifiedUser,
-		"last_modified_user_type": ds.SystemData.LastModifiedUserType,
-	}, nil
+	return result
 }
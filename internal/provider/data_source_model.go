@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+)
+
+const modelLabelLatest = "latest"
+
+func dataSourceModel() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to access the information of a version of a Model of a certain Azure ML " +
+			"Workspace. The version can be either specified explicitly or resolved from a label.",
+
+		ReadContext: dataSourceModelRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the model belongs to.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the model belongs to.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the model.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The version of the model. Conflicts with `label`.",
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"version", "label"},
+			},
+			"label": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The label resolved to a concrete version: `latest` selects the most recent version of " +
+					"the model, any other value selects the most recent version whose `stage` is equal to the label.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the model version.",
+			},
+			"model_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the model.",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URI of the model artifacts.",
+			},
+			"flavors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The flavors of the model.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"data": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"properties": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The properties of the model version.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The description of the model version.",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The tags of the model version.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"stage": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The lifecycle stage of the model version.",
+			},
+			"is_archived": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Is the model version archived?",
+			},
+			"asset_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reference to the model version in the `azureml:<name>:<version>` form used by jobs.",
+			},
+		},
+	}
+}
+
+func dataSourceModelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "models", name)
+
+	version := d.Get("version").(string)
+	if label := d.Get("label").(string); label != "" {
+		v, err := resolveModelLabel(ctx, client.arm, containerPath, label)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error resolving label %q of model %s", label, name),
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		version = v
+	}
+
+	model := new(modelVersionResource)
+	if err := client.arm.get(ctx, assetVersionPath(containerPath, version), model); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error retrieving model %s version %s", name, version),
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	props := model.Properties
+	if err := d.Set("version", version); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("model_type", props.ModelType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("path", props.ModelUri); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("flavors", modelFlavorsToList(props.Flavors)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("properties", props.Properties); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", props.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("stage", props.Stage); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_archived", props.IsArchived); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("asset_id", fmt.Sprintf("azureml:%s:%s", name, version)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(model.Id)
+
+	return diags
+}
+
+// resolveModelLabel returns the version of the model container provided as argument referenced by the label.
+func resolveModelLabel(ctx context.Context, c *armClient, containerPath, label string) (string, error) {
+	if label == modelLabelLatest {
+		container := new(assetContainerResource)
+		if err := c.get(ctx, containerPath, container); err != nil {
+			return "", err
+		}
+		if container.Properties.LatestVersion == "" {
+			return "", fmt.Errorf("the model has no versions")
+		}
+		return container.Properties.LatestVersion, nil
+	}
+
+	query := url.Values{}
+	query.Set("stage", label)
+	query.Set("$orderBy", "createdtime desc")
+	query.Set("$top", "1")
+	versions := new(assetVersionList)
+	if err := c.get(ctx, fmt.Sprintf("%s/versions?%s", containerPath, query.Encode()), versions); err != nil {
+		return "", err
+	}
+	if len(versions.Value) == 0 {
+		return "", fmt.Errorf("no version of the model is in stage %q", label)
+	}
+	return versions.Value[0].Name, nil
+}
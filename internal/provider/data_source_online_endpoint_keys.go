@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+func dataSourceOnlineEndpointKeys() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to access the credentials for invoking an Online Endpoint. The keys are " +
+			"returned for the endpoints using the `Key` authentication mode, a token is returned for the endpoints " +
+			"using the `AMLToken` authentication mode. The credentials are stored in the Terraform state: use the " +
+			"`azureml_online_endpoint_keys` ephemeral resource to avoid persisting them.",
+
+		ReadContext: dataSourceOnlineEndpointKeysRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the endpoint belongs to.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the endpoint belongs to.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"endpoint_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the online endpoint.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the online endpoint.",
+			},
+			"auth_mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The authentication mode of the endpoint.",
+			},
+			"primary_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The primary key of the endpoint. Set only if `auth_mode` is `Key`.",
+			},
+			"secondary_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The secondary key of the endpoint. Set only if `auth_mode` is `Key`.",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The token for invoking the endpoint. Set only if `auth_mode` is `AMLToken`.",
+			},
+			"token_expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The expiration time of `token`, in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func dataSourceOnlineEndpointKeysRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	endpointName := d.Get("endpoint_name").(string)
+	path := onlineEndpointPath(
+		client.arm,
+		d.Get("resource_group_name").(string),
+		d.Get("workspace_name").(string),
+		endpointName,
+	)
+
+	endpoint := new(onlineEndpointResource)
+	if err := client.arm.get(ctx, path, endpoint); err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error reading online endpoint %s", endpointName),
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+
+	credentials := map[string]string{
+		"auth_mode":        endpoint.Properties.AuthMode,
+		"primary_key":      "",
+		"secondary_key":    "",
+		"token":            "",
+		"token_expires_at": "",
+	}
+	switch endpoint.Properties.AuthMode {
+	case "Key":
+		keys, err := listOnlineEndpointKeys(ctx, client.arm, path)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error retrieving the keys of online endpoint %s", endpointName),
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		credentials["primary_key"] = keys.PrimaryKey
+		credentials["secondary_key"] = keys.SecondaryKey
+	case "AMLToken":
+		token, err := getOnlineEndpointToken(ctx, client.arm, path)
+		if err != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error retrieving a token of online endpoint %s", endpointName),
+				Detail:   err.Error(),
+			})
+			return diags
+		}
+		credentials["token"] = token.AccessToken
+		credentials["token_expires_at"] = time.Unix(token.ExpiryTimeUtc, 0).UTC().Format(time.RFC3339)
+	default:
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Unsupported authentication mode of online endpoint %s", endpointName),
+			Detail: fmt.Sprintf(
+				"The endpoint uses the %s authentication mode: its callers must request a token to "+
+					"Microsoft Entra ID for the https://ml.azure.com scope.",
+				endpoint.Properties.AuthMode,
+			),
+		})
+		return diags
+	}
+
+	for name, value := range credentials {
+		if err := d.Set(name, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId(path)
+
+	return diags
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDataSourceOnlineEndpointKeysRead(t *testing.T) {
+	testCases := []struct {
+		authMode string
+		expected map[string]string
+	}{
+		{
+			authMode: "Key",
+			expected: map[string]string{"primary_key": "k1", "secondary_key": "k2", "token": "", "token_expires_at": ""},
+		},
+		{
+			authMode: "AMLToken",
+			expected: map[string]string{
+				"primary_key":      "",
+				"secondary_key":    "",
+				"token":            "t0ken",
+				"token_expires_at": "2024-01-01T00:00:00Z",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.authMode, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/onlineEndpoints/e"):
+					_, _ = fmt.Fprintf(w, `{"id": "e", "properties": {"authMode": %q}}`, tc.authMode)
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/onlineEndpoints/e/listKeys"):
+					_, _ = w.Write([]byte(`{"primaryKey": "k1", "secondaryKey": "k2"}`))
+				case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/onlineEndpoints/e/token"):
+					_, _ = w.Write([]byte(`{"accessToken": "t0ken", "tokenType": "Bearer", "expiryTimeUtc": 1704067200}`))
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+				}
+			}))
+			defer server.Close()
+
+			r := dataSourceOnlineEndpointKeys()
+			d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+				"resource_group_name": "rg",
+				"workspace_name":      "ws",
+				"endpoint_name":       "e",
+			})
+			if diags := r.ReadContext(context.Background(), d, &apiClient{arm: newTestArmClient(server)}); diags.HasError() {
+				t.Fatalf("unexpected diagnostics %v", diags)
+			}
+			if d.Get("auth_mode") != tc.authMode {
+				t.Errorf("unexpected authentication mode %q", d.Get("auth_mode"))
+			}
+			for name, expected := range tc.expected {
+				if d.Get(name) != expected {
+					t.Errorf("unexpected %s %q, expected %q", name, d.Get(name), expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceWorkspaceOutboundRules() *schema.Resource {
+	return &schema.Resource{
+		Description: "Use this data source to list the effective outbound rules of the managed virtual network of an " +
+			"Azure ML Workspace, including the ones required by Azure ML and the ones defined outside of Terraform.",
+
+		ReadContext: dataSourceWorkspaceOutboundRulesRead,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"rules": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The outbound rules of the managed virtual network of the workspace.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the outbound rule.",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the outbound rule, i.e. `FQDN`, `PrivateEndpoint` or `ServiceTag`.",
+						},
+						"category": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The category of the outbound rule, e.g. `Required`, `Recommended` or `UserDefined`.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the outbound rule, i.e. `Active` or `Inactive`.",
+						},
+						"fqdn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The fully qualified domain name allowed by the rule. Set only if `type` is `FQDN`.",
+						},
+						"private_endpoint": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The Azure resource reached through a private endpoint. Set only if `type` is `PrivateEndpoint`.",
+							Elem: &schema.Resource{
+								Schema: outboundRulePrivateEndpointSchema(true),
+							},
+						},
+						"service_tag": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The Azure service tag, protocol and ports allowed by the rule. Set only if `type` is `ServiceTag`.",
+							Elem: &schema.Resource{
+								Schema: outboundRuleServiceTagSchema(true),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceWorkspaceOutboundRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	workspaceId := client.arm.workspaceId(resourceGroupName, workspaceName)
+
+	rules := make([]interface{}, 0)
+	next := fmt.Sprintf("%s/outboundRules", workspaceId)
+	for next != "" {
+		page := new(outboundRuleList)
+		if err := client.arm.get(ctx, next, page); err != nil {
+			return diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error listing the outbound rules of workspace %s", workspaceName),
+				Detail:   err.Error(),
+			}}
+		}
+		for _, rule := range page.Value {
+			flattened, err := flattenOutboundRule(rule.Name, rule.Properties)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			rules = append(rules, flattened)
+		}
+		next = page.NextLink
+	}
+
+	d.SetId(workspaceId)
+	if err := d.Set("rules", rules); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+type outboundRuleList struct {
+	Value    []outboundRuleResource `json:"value"`
+	NextLink string                 `json:"nextLink"`
+}
@@ -0,0 +1,190 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/orobix/azureml-go-sdk/workspace"
+	"time"
+)
+
+// datastoreApiVersion is the version of the Azure ML API whose datastore schema is managed by the provider.
+const datastoreApiVersion = "2021-03-01-preview"
+
+// armDatastore is the schema of the datastores of the datastoreApiVersion of the Azure ML API.
+type armDatastore struct {
+	Id         string                  `json:"id,omitempty"`
+	Name       string                  `json:"name,omitempty"`
+	SystemData *armDatastoreSystemData `json:"systemData,omitempty"`
+	Properties armDatastoreProperties  `json:"properties"`
+}
+
+type armDatastoreProperties struct {
+	Description string               `json:"description"`
+	IsDefault   bool                 `json:"isDefault"`
+	Contents    armDatastoreContents `json:"contents"`
+}
+
+type armDatastoreContents struct {
+	ContentsType  string                   `json:"contentsType"`
+	AccountName   string                   `json:"accountName"`
+	ContainerName string                   `json:"containerName"`
+	Endpoint      string                   `json:"endpoint,omitempty"`
+	Protocol      string                   `json:"protocol,omitempty"`
+	Credentials   *armDatastoreCredentials `json:"credentials,omitempty"`
+}
+
+type armDatastoreCredentials struct {
+	CredentialsType string               `json:"credentialsType"`
+	ClientId        string               `json:"clientId,omitempty"`
+	TenantId        string               `json:"tenantId,omitempty"`
+	UserId          string               `json:"userId,omitempty"`
+	Secrets         *armDatastoreSecrets `json:"secrets,omitempty"`
+}
+
+// armDatastoreSecrets contains the secrets of the credentials of a datastore, which are never returned by Azure ML.
+type armDatastoreSecrets struct {
+	SecretsType  string `json:"secretsType"`
+	Key          string `json:"key,omitempty"`
+	ClientSecret string `json:"clientSecret,omitempty"`
+	Password     string `json:"password,omitempty"`
+}
+
+type armDatastoreSystemData struct {
+	CreatedAt          string `json:"createdAt"`
+	CreatedBy          string `json:"createdBy"`
+	CreatedByType      string `json:"createdByType"`
+	LastModifiedAt     string `json:"lastModifiedAt"`
+	LastModifiedBy     string `json:"lastModifiedBy"`
+	LastModifiedByType string `json:"lastModifiedByType"`
+}
+
+// armDatastoreList is a page of the list of the datastores of a workspace.
+type armDatastoreList struct {
+	Value    []armDatastore `json:"value"`
+	NextLink string         `json:"nextLink"`
+}
+
+// datastorePath returns the path of the datastore provided as argument, with the datastoreApiVersion.
+func datastorePath(c *armClient, resourceGroupName, workspaceName, name string) string {
+	return fmt.Sprintf("%s/datastores/%s?api-version=%s", c.workspaceId(resourceGroupName, workspaceName), name, datastoreApiVersion)
+}
+
+// getDatastore retrieves the datastore provided as argument. A resourceNotFoundError is returned if the datastore
+// does not exist.
+func getDatastore(ctx context.Context, c *armClient, resourceGroupName, workspaceName, name string) (*workspace.Datastore, error) {
+	ds := new(armDatastore)
+	if err := c.get(ctx, datastorePath(c, resourceGroupName, workspaceName, name), ds); err != nil {
+		return nil, err
+	}
+	return ds.toDatastore(), nil
+}
+
+// listDatastores retrieves all the datastores of the workspace provided as argument.
+func listDatastores(ctx context.Context, c *armClient, resourceGroupName, workspaceName string) ([]workspace.Datastore, error) {
+	datastores := make([]workspace.Datastore, 0)
+	next := fmt.Sprintf("%s/datastores?api-version=%s", c.workspaceId(resourceGroupName, workspaceName), datastoreApiVersion)
+	for next != "" {
+		page := new(armDatastoreList)
+		if err := c.get(ctx, next, page); err != nil {
+			return nil, err
+		}
+		for i := range page.Value {
+			datastores = append(datastores, *page.Value[i].toDatastore())
+		}
+		next = page.NextLink
+	}
+	return datastores, nil
+}
+
+// createOrUpdateDatastore creates the datastore provided as argument, or replaces it if it already exists.
+func createOrUpdateDatastore(
+	ctx context.Context,
+	c *armClient,
+	resourceGroupName, workspaceName string,
+	datastore *workspace.Datastore,
+) (*workspace.Datastore, error) {
+	if datastore.Name == "" {
+		return nil, fmt.Errorf("the datastore name cannot be empty")
+	}
+	created := new(armDatastore)
+	path := datastorePath(c, resourceGroupName, workspaceName, datastore.Name)
+	if _, err := c.put(ctx, path, newArmDatastore(datastore), created); err != nil {
+		return nil, err
+	}
+	return created.toDatastore(), nil
+}
+
+// deleteDatastore deletes the datastore provided as argument. A resourceNotFoundError is returned if the datastore
+// does not exist.
+func deleteDatastore(ctx context.Context, c *armClient, resourceGroupName, workspaceName, name string) error {
+	_, err := c.delete(ctx, datastorePath(c, resourceGroupName, workspaceName, name))
+	return err
+}
+
+// newArmDatastore returns the request body creating the datastore provided as argument.
+func newArmDatastore(datastore *workspace.Datastore) *armDatastore {
+	ds := &armDatastore{
+		Properties: armDatastoreProperties{
+			Description: datastore.Description,
+			IsDefault:   datastore.IsDefault,
+			Contents: armDatastoreContents{
+				ContentsType:  datastore.StorageType,
+				AccountName:   datastore.StorageAccountName,
+				ContainerName: datastore.StorageContainerName,
+				Endpoint:      "core.windows.net",
+				Protocol:      "https",
+			},
+		},
+	}
+	if auth := datastore.Auth; auth != nil {
+		ds.Properties.Contents.Credentials = &armDatastoreCredentials{
+			CredentialsType: auth.CredentialsType,
+			ClientId:        auth.ClientId,
+			TenantId:        auth.TenantId,
+			UserId:          auth.SqlUserName,
+			Secrets: &armDatastoreSecrets{
+				SecretsType:  auth.CredentialsType,
+				Key:          auth.AccountKey,
+				ClientSecret: auth.ClientSecret,
+				Password:     auth.SqlUserPassword,
+			},
+		}
+	}
+	return ds
+}
+
+// toDatastore converts the datastore to the model shared with the azureml-go-sdk. The secrets of its credentials
+// are left empty, as Azure ML never returns them.
+func (ds *armDatastore) toDatastore() *workspace.Datastore {
+	datastore := &workspace.Datastore{
+		Id:                   ds.Id,
+		Name:                 ds.Name,
+		IsDefault:            ds.Properties.IsDefault,
+		Description:          ds.Properties.Description,
+		StorageType:          ds.Properties.Contents.ContentsType,
+		StorageAccountName:   ds.Properties.Contents.AccountName,
+		StorageContainerName: ds.Properties.Contents.ContainerName,
+		SystemData:           &workspace.SystemData{},
+		Auth:                 &workspace.DatastoreAuth{},
+	}
+	if credentials := ds.Properties.Contents.Credentials; credentials != nil {
+		datastore.Auth.CredentialsType = credentials.CredentialsType
+		datastore.Auth.ClientId = credentials.ClientId
+		datastore.Auth.TenantId = credentials.TenantId
+		datastore.Auth.SqlUserName = credentials.UserId
+	}
+	if systemData := ds.SystemData; systemData != nil {
+		// Like the azureml-go-sdk, the dates that cannot be parsed are left empty instead of failing the read
+		creationDate, _ := time.Parse(time.RFC3339Nano, systemData.CreatedAt)
+		lastModifiedDate, _ := time.Parse(time.RFC3339Nano, systemData.LastModifiedAt)
+		datastore.SystemData = &workspace.SystemData{
+			CreationDate:         creationDate,
+			CreationUser:         systemData.CreatedBy,
+			CreationUserType:     systemData.CreatedByType,
+			LastModifiedDate:     lastModifiedDate,
+			LastModifiedUser:     systemData.LastModifiedBy,
+			LastModifiedUserType: systemData.LastModifiedByType,
+		}
+	}
+	return datastore
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/orobix/azureml-go-sdk/workspace"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testSqlDatastore is the response of Azure ML to the GET of a datastore with SQL authentication.
+const testSqlDatastore = `{
+  "id": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/sqlstore",
+  "name": "sqlstore",
+  "type": "Microsoft.MachineLearningServices/workspaces/datastores",
+  "properties": {
+    "contents": {
+      "contentsType": "AzureSqlDatabase",
+      "credentials": {"credentialsType": "SqlAdmin", "userId": "admin"},
+      "databaseName": "db",
+      "endpoint": "database.windows.net",
+      "portNumber": 1433,
+      "serverName": "server"
+    },
+    "description": "SQL database",
+    "isDefault": false,
+    "linkedInfo": null,
+    "properties": {},
+    "tags": {}
+  },
+  "systemData": {
+    "createdAt": "2021-05-04T10:20:30.1234567+00:00",
+    "createdBy": "user@example.com",
+    "createdByType": "User",
+    "lastModifiedAt": "2021-06-01T08:00:00Z",
+    "lastModifiedBy": "app-id",
+    "lastModifiedByType": "Application"
+  }
+}`
+
+func TestGetDatastore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/workspaces/ws/datastores/sqlstore") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testSqlDatastore))
+	}))
+	defer server.Close()
+	c := newTestArmClient(server)
+
+	ds, err := getDatastore(context.Background(), c, "rg", "ws", "sqlstore")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ds.Name != "sqlstore" || ds.StorageType != "AzureSqlDatabase" || ds.Description != "SQL database" {
+		t.Errorf("unexpected datastore %+v", ds)
+	}
+	if ds.Auth.CredentialsType != "SqlAdmin" || ds.Auth.SqlUserName != "admin" {
+		t.Errorf("unexpected auth %+v", ds.Auth)
+	}
+	if !ds.SystemData.CreationDate.Equal(time.Date(2021, 5, 4, 10, 20, 30, 123456700, time.UTC)) ||
+		ds.SystemData.LastModifiedUser != "app-id" || ds.SystemData.LastModifiedUserType != "Application" {
+		t.Errorf("unexpected system data %+v", ds.SystemData)
+	}
+
+	_, err = getDatastore(context.Background(), c, "rg", "ws", "other")
+	var notFoundErr *resourceNotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Errorf("expected a resourceNotFoundError, got %v", err)
+	}
+}
+
+func TestListDatastores(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			_, _ = w.Write([]byte(`{"value": [` + testSqlDatastore + `]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"value": [{"name": "workspaceblobstore", "properties": {"isDefault": true}}], ` +
+			`"nextLink": "` + server.URL + r.URL.Path + `?api-version=` + datastoreApiVersion + `&page=2"}`))
+	}))
+	defer server.Close()
+
+	datastores, err := listDatastores(context.Background(), newTestArmClient(server), "rg", "ws")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(datastores) != 2 || datastores[0].Name != "workspaceblobstore" || !datastores[0].IsDefault ||
+		datastores[1].Auth.SqlUserName != "admin" {
+		t.Errorf("unexpected datastores %+v", datastores)
+	}
+}
+
+func TestCreateOrUpdateDatastore(t *testing.T) {
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Query().Get("api-version") != datastoreApiVersion {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		if err := json.Unmarshal(b, &body); err != nil {
+			t.Errorf("unable to decode the request body: %v", err)
+		}
+		_, _ = w.Write([]byte(testSqlDatastore))
+	}))
+	defer server.Close()
+
+	datastore := &workspace.Datastore{
+		Name:        "sqlstore",
+		StorageType: "AzureSqlDatabase",
+		Auth:        &workspace.DatastoreAuth{CredentialsType: "SqlAdmin", SqlUserName: "admin", SqlUserPassword: "s3cret"},
+	}
+	created, err := createOrUpdateDatastore(context.Background(), newTestArmClient(server), "rg", "ws", datastore)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if created.Auth.SqlUserName != "admin" {
+		t.Errorf("unexpected datastore %+v", created)
+	}
+
+	expected := map[string]interface{}{
+		"credentialsType": "SqlAdmin",
+		"userId":          "admin",
+		"secrets":         map[string]interface{}{"secretsType": "SqlAdmin", "password": "s3cret"},
+	}
+	contents := body["properties"].(map[string]interface{})["contents"].(map[string]interface{})
+	if !reflect.DeepEqual(contents["credentials"], expected) {
+		t.Errorf("unexpected credentials %v, expected %v", contents["credentials"], expected)
+	}
+	if _, ok := body["id"]; ok {
+		t.Errorf("unexpected ID in the request body %v", body)
+	}
+}
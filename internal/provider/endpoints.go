@@ -0,0 +1,107 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+)
+
+func IsValidEndpointName(val interface{}, key string) (warns []string, errs []error) {
+	return validation.StringMatch(
+		regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9-]{1,30}[a-zA-Z0-9]$"),
+		"must be between 3 and 32 characters long, start with a letter, end with a letter or a digit and "+
+			"contain only letters, digits and hyphens",
+	)(val, key)
+}
+
+// endpointDeploymentPath returns the ARM ID of a deployment of the online or batch endpoint provided as argument.
+func endpointDeploymentPath(endpointPath, name string) string {
+	return fmt.Sprintf("%s/deployments/%s", endpointPath, name)
+}
+
+// importEndpointDeployment returns an import function which sets the resource group name, the workspace name,
+// the endpoint name and the name of the imported deployment from its ID. The endpointType argument is the
+// collection to which the endpoint belongs to (e.g. "onlineEndpoints").
+func importEndpointDeployment(endpointType string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		resourceGroupName, workspaceName, segments, err := splitWorkspaceId(d.Id())
+		if err != nil || len(segments) != 4 || segments[0] != endpointType || segments[2] != "deployments" {
+			return nil, fmt.Errorf(
+				"invalid ID %q, expected format is "+
+					"/subscriptions/<subscription-id>/resourceGroups/<resource-group>/providers/"+
+					"Microsoft.MachineLearningServices/workspaces/<workspace>/%s/<endpoint>/deployments/<name>",
+				d.Id(),
+				endpointType,
+			)
+		}
+		if err := d.Set("resource_group_name", resourceGroupName); err != nil {
+			return nil, err
+		}
+		if err := d.Set("workspace_name", workspaceName); err != nil {
+			return nil, err
+		}
+		if err := d.Set("endpoint_name", segments[1]); err != nil {
+			return nil, err
+		}
+		if err := d.Set("name", segments[3]); err != nil {
+			return nil, err
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// codeConfigurationSchema returns the schema of the scoring code of an online or batch deployment.
+func codeConfigurationSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "The code used for scoring the requests.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"code_id": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "The ID of the code asset version containing the scoring script.",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				"scoring_script": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "The path of the scoring script, relative to the root of the code asset.",
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+		},
+	}
+}
+
+type codeConfiguration struct {
+	CodeId        string `json:"codeId"`
+	ScoringScript string `json:"scoringScript"`
+}
+
+func expandCodeConfiguration(l []interface{}) *codeConfiguration {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	data := l[0].(map[string]interface{})
+	return &codeConfiguration{
+		CodeId:        data["code_id"].(string),
+		ScoringScript: data["scoring_script"].(string),
+	}
+}
+
+func flattenCodeConfiguration(c *codeConfiguration) []interface{} {
+	if c == nil {
+		return make([]interface{}, 0)
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"code_id":        c.CodeId,
+			"scoring_script": c.ScoringScript,
+		},
+	}
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"time"
+)
+
+var _ ephemeral.EphemeralResourceWithConfigure = &onlineEndpointKeysEphemeralResource{}
+
+// onlineEndpointKeysEphemeralResource provides the credentials for invoking an online endpoint without
+// persisting them to the Terraform state.
+type onlineEndpointKeysEphemeralResource struct {
+	client *apiClient
+}
+
+type onlineEndpointKeysEphemeralResourceModel struct {
+	ResourceGroupName types.String `tfsdk:"resource_group_name"`
+	WorkspaceName     types.String `tfsdk:"workspace_name"`
+	EndpointName      types.String `tfsdk:"endpoint_name"`
+	AuthMode          types.String `tfsdk:"auth_mode"`
+	PrimaryKey        types.String `tfsdk:"primary_key"`
+	SecondaryKey      types.String `tfsdk:"secondary_key"`
+	Token             types.String `tfsdk:"token"`
+	TokenExpiresAt    types.String `tfsdk:"token_expires_at"`
+}
+
+func newOnlineEndpointKeysEphemeralResource() ephemeral.EphemeralResource {
+	return &onlineEndpointKeysEphemeralResource{}
+}
+
+func (r *onlineEndpointKeysEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_online_endpoint_keys"
+}
+
+func (r *onlineEndpointKeysEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this ephemeral resource to access the credentials for invoking an Online Endpoint " +
+			"without persisting them to the Terraform state. The keys are returned for the endpoints using the `Key` " +
+			"authentication mode, a token is returned for the endpoints using the `AMLToken` authentication mode. " +
+			"Requires Terraform 1.10 or later.",
+		Attributes: map[string]schema.Attribute{
+			"resource_group_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the resource group of the Azure ML Workspace to which the endpoint belongs to.",
+			},
+			"workspace_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the Azure ML Workspace to which the endpoint belongs to.",
+			},
+			"endpoint_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the online endpoint.",
+			},
+			"auth_mode": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The authentication mode of the endpoint.",
+			},
+			"primary_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The primary key of the endpoint. Set only if `auth_mode` is `Key`.",
+			},
+			"secondary_key": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The secondary key of the endpoint. Set only if `auth_mode` is `Key`.",
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The token for invoking the endpoint. Set only if `auth_mode` is `AMLToken`.",
+			},
+			"token_expires_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The expiration time of `token`, in RFC 3339 format.",
+			},
+		},
+	}
+}
+
+func (r *onlineEndpointKeysEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *apiClient, got %T.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *onlineEndpointKeysEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	resp.Diagnostics.Append(configuredClientDiagnostics(r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data onlineEndpointKeysEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpointName := data.EndpointName.ValueString()
+	path := onlineEndpointPath(
+		r.client.arm,
+		data.ResourceGroupName.ValueString(),
+		data.WorkspaceName.ValueString(),
+		endpointName,
+	)
+	endpoint := new(onlineEndpointResource)
+	if err := r.client.arm.get(ctx, path, endpoint); err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Error reading online endpoint %s", endpointName), err.Error())
+		return
+	}
+
+	data.AuthMode = types.StringValue(endpoint.Properties.AuthMode)
+	data.PrimaryKey = types.StringNull()
+	data.SecondaryKey = types.StringNull()
+	data.Token = types.StringNull()
+	data.TokenExpiresAt = types.StringNull()
+
+	switch endpoint.Properties.AuthMode {
+	case "Key":
+		keys, err := listOnlineEndpointKeys(ctx, r.client.arm, path)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Error retrieving the keys of online endpoint %s", endpointName),
+				err.Error(),
+			)
+			return
+		}
+		data.PrimaryKey = types.StringValue(keys.PrimaryKey)
+		data.SecondaryKey = types.StringValue(keys.SecondaryKey)
+	case "AMLToken":
+		token, err := getOnlineEndpointToken(ctx, r.client.arm, path)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Error retrieving a token of online endpoint %s", endpointName),
+				err.Error(),
+			)
+			return
+		}
+		data.Token = types.StringValue(token.AccessToken)
+		data.TokenExpiresAt = types.StringValue(time.Unix(token.ExpiryTimeUtc, 0).UTC().Format(time.RFC3339))
+	default:
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Unsupported authentication mode of online endpoint %s", endpointName),
+			fmt.Sprintf(
+				"The endpoint uses the %s authentication mode: its callers must request a token to "+
+					"Microsoft Entra ID for the https://ml.azure.com scope.",
+				endpoint.Properties.AuthMode,
+			),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
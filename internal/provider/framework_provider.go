@@ -0,0 +1,177 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
+	fwschema "github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-mux/tf5to6server"
+	"github.com/hashicorp/terraform-plugin-mux/tf6muxserver"
+)
+
+// NewMuxServer returns a server combining the SDKv2 provider with the terraform-plugin-framework one,
+// which implements the features not supported by SDKv2 (e.g. ephemeral resources, actions and nested
+// attributes) and to which the resources are progressively migrated. The server uses the protocol version 6,
+// required by nested attributes, to which the SDKv2 provider is upgraded.
+func NewMuxServer(ctx context.Context, version string) (func() tfprotov6.ProviderServer, error) {
+	sdkServer, err := tf5to6server.UpgradeServer(ctx, func() tfprotov5.ProviderServer {
+		return New(version)().GRPCProvider()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	muxServer, err := tf6muxserver.NewMuxServer(
+		ctx,
+		func() tfprotov6.ProviderServer { return sdkServer },
+		providerserver.NewProtocol6(NewFrameworkProvider(version)()),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return muxServer.ProviderServer, nil
+}
+
+// frameworkProvider is the terraform-plugin-framework implementation of the provider. Its configuration
+// must be kept identical to the one of the SDKv2 provider returned by New.
+type frameworkProvider struct {
+	version string
+}
+
+type frameworkProviderModel struct {
+	ClientId       types.String `tfsdk:"client_id"`
+	ClientSecret   types.String `tfsdk:"client_secret"`
+	TenantId       types.String `tfsdk:"tenant_id"`
+	SubscriptionId types.String `tfsdk:"subscription_id"`
+}
+
+var (
+	_ fwprovider.ProviderWithEphemeralResources = &frameworkProvider{}
+	_ fwprovider.ProviderWithActions            = &frameworkProvider{}
+	_ fwprovider.ProviderWithFunctions          = &frameworkProvider{}
+)
+
+func NewFrameworkProvider(version string) func() fwprovider.Provider {
+	return func() fwprovider.Provider {
+		return &frameworkProvider{version: version}
+	}
+}
+
+func (p *frameworkProvider) Metadata(_ context.Context, _ fwprovider.MetadataRequest, resp *fwprovider.MetadataResponse) {
+	resp.TypeName = "azureml"
+	resp.Version = p.version
+}
+
+func (p *frameworkProvider) Schema(_ context.Context, _ fwprovider.SchemaRequest, resp *fwprovider.SchemaResponse) {
+	resp.Schema = fwschema.Schema{
+		Attributes: map[string]fwschema.Attribute{
+			"client_id": fwschema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The application ID of the Service Principal used for authenticating with Azure Machine Learning.",
+			},
+			"client_secret": fwschema.StringAttribute{
+				Required:            true,
+				Sensitive:           true,
+				MarkdownDescription: "The client secret of the Service Principal used for authenticating with Azure Machine Learning.",
+			},
+			"tenant_id": fwschema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The ID of the home Tenant of the Service Principal used for authenticating with Azure Machine Learning.",
+			},
+			"subscription_id": fwschema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The Azure subscription ID on which the provider will operate.",
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req fwprovider.ConfigureRequest, resp *fwprovider.ConfigureResponse) {
+	var config frameworkProviderModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The configuration may contain unknown values during validation, the client is created only once
+	// every value is known.
+	if config.ClientId.IsUnknown() ||
+		config.ClientSecret.IsUnknown() ||
+		config.TenantId.IsUnknown() ||
+		config.SubscriptionId.IsUnknown() {
+		return
+	}
+
+	apiClient, err := newApiClient(
+		config.ClientId.ValueString(),
+		config.ClientSecret.ValueString(),
+		config.TenantId.ValueString(),
+		config.SubscriptionId.ValueString(),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create client", err.Error())
+		return
+	}
+
+	resp.DataSourceData = apiClient
+	resp.ResourceData = apiClient
+	resp.EphemeralResourceData = apiClient
+	resp.ActionData = apiClient
+}
+
+func (p *frameworkProvider) Resources(_ context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newDatastoreResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(_ context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{
+		newDatastoreDataSource,
+		newDatastoresDataSource,
+	}
+}
+
+func (p *frameworkProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		newOnlineEndpointKeysEphemeralResource,
+	}
+}
+
+func (p *frameworkProvider) Actions(_ context.Context) []func() action.Action {
+	return []func() action.Action{
+		newProvisionManagedNetworkAction,
+	}
+}
+
+func (p *frameworkProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		newParseDatastoreIdFunction,
+		newBuildDatastoreUriFunction,
+		newParseAssetUriFunction,
+		newWorkspaceIdFunction,
+	}
+}
+
+// configuredClientDiagnostics returns an error if the client provided as argument has not been configured, which
+// happens when the configuration of the provider contains values known only after apply.
+func configuredClientDiagnostics(client *apiClient) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if client == nil {
+		diags.AddError(
+			"Unconfigured provider",
+			"The provider has not been configured, as its configuration contains values known only after apply. "+
+				"Make sure that client_id, client_secret, tenant_id and subscription_id are known during plan.",
+		)
+	}
+	return diags
+}
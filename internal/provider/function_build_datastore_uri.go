@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"strings"
+)
+
+var _ function.Function = &buildDatastoreUriFunction{}
+
+// buildDatastoreUriFunction builds the azureml:// URI of a path of a datastore.
+type buildDatastoreUriFunction struct{}
+
+func newBuildDatastoreUriFunction() function.Function {
+	return &buildDatastoreUriFunction{}
+}
+
+func (f *buildDatastoreUriFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "build_datastore_uri"
+}
+
+func (f *buildDatastoreUriFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Builds the URI of a path of a datastore.",
+		MarkdownDescription: "Builds the URI of a path of a datastore, in the short form " +
+			"`azureml://datastores/<datastore>/paths/<path>`, or in the long form " +
+			"`azureml://subscriptions/<subscription-id>/resourcegroups/<resource-group>/workspaces/<workspace>/datastores/<datastore>/paths/<path>` " +
+			"if the ID of the workspace is provided.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "datastore_name",
+				MarkdownDescription: "The name of the datastore.",
+			},
+			function.StringParameter{
+				Name:                "path",
+				MarkdownDescription: "The path within the datastore.",
+			},
+		},
+		VariadicParameter: function.StringParameter{
+			Name:                "workspace_id",
+			MarkdownDescription: "The optional ID of the Azure ML Workspace to which the datastore belongs to.",
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *buildDatastoreUriFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var datastoreName, path string
+	var workspaceIds []string
+	resp.Error = req.Arguments.Get(ctx, &datastoreName, &path, &workspaceIds)
+	if resp.Error != nil {
+		return
+	}
+
+	if stringIsEmpty(datastoreName) || strings.Contains(datastoreName, "/") {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("invalid datastore name %q", datastoreName))
+		return
+	}
+	uri := datastoreUri{DatastoreName: datastoreName, Path: strings.TrimPrefix(path, "/")}
+	switch len(workspaceIds) {
+	case 0:
+	case 1:
+		resourceGroupName, workspaceName, segments, err := splitWorkspaceId(workspaceIds[0])
+		if err == nil && len(segments) > 0 {
+			err = fmt.Errorf("invalid Azure ML Workspace ID %q", workspaceIds[0])
+		}
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(2, err.Error())
+			return
+		}
+		uri.SubscriptionId = strings.Split(strings.Trim(workspaceIds[0], "/"), "/")[1]
+		uri.ResourceGroupName = resourceGroupName
+		uri.WorkspaceName = workspaceName
+	default:
+		resp.Error = function.NewArgumentFuncError(3, "at most one workspace ID can be provided")
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, uri.String())
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildDatastoreUriFunction(t *testing.T) {
+	workspaceId := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws"
+	cases := []struct {
+		arguments []string
+		expected  string
+	}{
+		{[]string{"ds", "data/train/"}, "azureml://datastores/ds/paths/data/train/"},
+		{[]string{"ds", "/data/train.csv"}, "azureml://datastores/ds/paths/data/train.csv"},
+		{
+			[]string{"ds", "data/train/", workspaceId},
+			"azureml://subscriptions/sub/resourcegroups/rg/workspaces/ws/datastores/ds/paths/data/train/",
+		},
+	}
+	for _, c := range cases {
+		result, funcErr := callTestFunction(t, "build_datastore_uri", c.arguments...)
+		if funcErr != nil {
+			t.Errorf("unexpected error for arguments %q: %s", c.arguments, funcErr.Text)
+			continue
+		}
+		var uri string
+		if err := result.As(&uri); err != nil || uri != c.expected {
+			t.Errorf("unexpected URI %q, expected %q", uri, c.expected)
+			continue
+		}
+
+		// The URI is parsed back into the arguments
+		parsed, err := parseDatastoreUri(uri)
+		if err != nil {
+			t.Errorf("unable to parse %q: %v", uri, err)
+			continue
+		}
+		if parsed.DatastoreName != "ds" || parsed.Path != strings.TrimPrefix(c.arguments[1], "/") {
+			t.Errorf("unexpected parsed URI %+v", parsed)
+		}
+		if len(c.arguments) == 3 && (parsed.SubscriptionId != "sub" || parsed.ResourceGroupName != "rg" || parsed.WorkspaceName != "ws") {
+			t.Errorf("unexpected workspace of the parsed URI %+v", parsed)
+		}
+	}
+
+	for _, arguments := range [][]string{
+		{"", "data"},
+		{"ds/paths", "data"},
+		{"ds", "data", "/subscriptions/sub/resourceGroups/rg"},
+		{"ds", "data", workspaceId + "/datastores/ds"},
+		{"ds", "data", workspaceId, workspaceId},
+	} {
+		if _, funcErr := callTestFunction(t, "build_datastore_uri", arguments...); funcErr == nil {
+			t.Errorf("expected error for arguments %q", arguments)
+		}
+	}
+}
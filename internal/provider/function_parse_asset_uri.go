@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"regexp"
+)
+
+var _ function.Function = &parseAssetUriFunction{}
+
+// assetUriRegexp matches the references to the assets of a workspace, either to a version (azureml:<name>:<version>)
+// or to a label (azureml:<name>@<label>).
+var assetUriRegexp = regexp.MustCompile(`^azureml:([^:@/]+)(?::([^:@/]+)|@([^:@/]+))$`)
+
+// parseAssetUriFunction splits the reference to an asset of a workspace into its components.
+type parseAssetUriFunction struct{}
+
+type assetUriModel struct {
+	Name    types.String `tfsdk:"name"`
+	Version types.String `tfsdk:"version"`
+	Label   types.String `tfsdk:"label"`
+}
+
+var assetUriAttributeTypes = map[string]attr.Type{
+	"name":    types.StringType,
+	"version": types.StringType,
+	"label":   types.StringType,
+}
+
+func newParseAssetUriFunction() function.Function {
+	return &parseAssetUriFunction{}
+}
+
+func (f *parseAssetUriFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_asset_uri"
+}
+
+func (f *parseAssetUriFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses the reference to an asset.",
+		MarkdownDescription: "Parses the reference to an asset of an Azure ML Workspace, such as a model or an " +
+			"environment, in the form `azureml:<name>:<version>` or `azureml:<name>@<label>`. Returns an object " +
+			"with its `name`, `version` and `label`, the latter two being null when not referenced.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "uri",
+				MarkdownDescription: "The reference to the asset.",
+			},
+		},
+		Return: function.ObjectReturn{AttributeTypes: assetUriAttributeTypes},
+	}
+}
+
+func (f *parseAssetUriFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var uri string
+	resp.Error = req.Arguments.Get(ctx, &uri)
+	if resp.Error != nil {
+		return
+	}
+
+	m := assetUriRegexp.FindStringSubmatch(uri)
+	if m == nil {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf(
+			"invalid asset URI %q, expected format is azureml:<name>:<version> or azureml:<name>@<label>",
+			uri,
+		))
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, assetUriModel{
+		Name:    types.StringValue(m[1]),
+		Version: stringValueOrNull(m[2]),
+		Label:   stringValueOrNull(m[3]),
+	})
+}
@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"testing"
+)
+
+func TestParseAssetUriFunction(t *testing.T) {
+	cases := map[string][3]string{
+		"azureml:my-model:3":        {"my-model", "3", ""},
+		"azureml:env_1:1.0.2":       {"env_1", "1.0.2", ""},
+		"azureml:my-model@latest":   {"my-model", "", "latest"},
+		"azureml:my.model@champion": {"my.model", "", "champion"},
+	}
+	for uri, expected := range cases {
+		result, funcErr := callTestFunction(t, "parse_asset_uri", uri)
+		if funcErr != nil {
+			t.Errorf("unexpected error for URI %q: %s", uri, funcErr.Text)
+			continue
+		}
+		attributes := testValueAttributes(t, result)
+		for i, name := range []string{"name", "version", "label"} {
+			if expected[i] == "" {
+				if !attributes[name].IsNull() {
+					t.Errorf("%s of %q is not null", name, uri)
+				}
+				continue
+			}
+			var v string
+			if err := attributes[name].As(&v); err != nil || v != expected[i] {
+				t.Errorf("unexpected %s of %q: %q, expected %q", name, uri, v, expected[i])
+			}
+		}
+
+		// The URI is built back from its components
+		built := "azureml:" + expected[0] + ":" + expected[1]
+		if expected[2] != "" {
+			built = "azureml:" + expected[0] + "@" + expected[2]
+		}
+		if built != uri {
+			t.Errorf("%q is not built back from its components: %q", uri, built)
+		}
+	}
+
+	for _, invalidUri := range []string{
+		"",
+		"my-model:3",
+		"azureml:my-model",
+		"azureml:my-model:",
+		"azureml:my-model@",
+		"azureml:my-model:3@latest",
+		"azureml://datastores/ds/paths/data",
+		"azureml:models/my-model:3",
+	} {
+		if _, funcErr := callTestFunction(t, "parse_asset_uri", invalidUri); funcErr == nil {
+			t.Errorf("expected error for URI %q", invalidUri)
+		}
+	}
+
+	// The null value of the optional components is typed
+	result, _ := callTestFunction(t, "parse_asset_uri", "azureml:m:1")
+	if label := testValueAttributes(t, result)["label"]; !label.Type().Is(tftypes.String) {
+		t.Errorf("unexpected type of the label %s", label.Type())
+	}
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"strings"
+)
+
+var _ function.Function = &parseDatastoreIdFunction{}
+
+// parseDatastoreIdFunction splits the ARM ID of a datastore into its components.
+type parseDatastoreIdFunction struct{}
+
+type datastoreIdModel struct {
+	SubscriptionId    types.String `tfsdk:"subscription_id"`
+	ResourceGroupName types.String `tfsdk:"resource_group_name"`
+	WorkspaceName     types.String `tfsdk:"workspace_name"`
+	Name              types.String `tfsdk:"name"`
+}
+
+var datastoreIdAttributeTypes = map[string]attr.Type{
+	"subscription_id":     types.StringType,
+	"resource_group_name": types.StringType,
+	"workspace_name":      types.StringType,
+	"name":                types.StringType,
+}
+
+func newParseDatastoreIdFunction() function.Function {
+	return &parseDatastoreIdFunction{}
+}
+
+func (f *parseDatastoreIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_datastore_id"
+}
+
+func (f *parseDatastoreIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Parses the ID of a datastore.",
+		MarkdownDescription: "Parses the ARM ID of a datastore of an Azure ML Workspace, returning an object with its " +
+			"`subscription_id`, `resource_group_name`, `workspace_name` and `name`.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "id",
+				MarkdownDescription: "The ID of the datastore.",
+			},
+		},
+		Return: function.ObjectReturn{AttributeTypes: datastoreIdAttributeTypes},
+	}
+}
+
+func (f *parseDatastoreIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var id string
+	resp.Error = req.Arguments.Get(ctx, &id)
+	if resp.Error != nil {
+		return
+	}
+
+	resourceGroupName, workspaceName, name, err := parseWorkspaceChildId(id, "datastores")
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+	// The ID has been validated, hence its second segment is the subscription ID
+	subscriptionId := strings.Split(strings.Trim(id, "/"), "/")[1]
+
+	resp.Error = resp.Result.Set(ctx, datastoreIdModel{
+		SubscriptionId:    types.StringValue(subscriptionId),
+		ResourceGroupName: types.StringValue(resourceGroupName),
+		WorkspaceName:     types.StringValue(workspaceName),
+		Name:              types.StringValue(name),
+	})
+}
@@ -0,0 +1,45 @@
+package provider
+
+import "testing"
+
+func TestParseDatastoreIdFunction(t *testing.T) {
+	id := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/ds"
+	result, funcErr := callTestFunction(t, "parse_datastore_id", id)
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %s", funcErr.Text)
+	}
+	attributes := testValueAttributes(t, result)
+	for name, expected := range map[string]string{
+		"subscription_id":     "sub",
+		"resource_group_name": "rg",
+		"workspace_name":      "ws",
+		"name":                "ds",
+	} {
+		var v string
+		if err := attributes[name].As(&v); err != nil || v != expected {
+			t.Errorf("unexpected %s %q, expected %q", name, v, expected)
+		}
+	}
+
+	// The ID is built back from the workspace ID returned by workspace_id
+	workspaceId, funcErr := callTestFunction(t, "workspace_id", "sub", "rg", "ws")
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %s", funcErr.Text)
+	}
+	var v string
+	if err := workspaceId.As(&v); err != nil || v+"/datastores/ds" != id {
+		t.Errorf("unexpected workspace ID %q", v)
+	}
+
+	for _, invalidId := range []string{
+		"",
+		"ds",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/computes/ds",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/ds/extra",
+	} {
+		if _, funcErr := callTestFunction(t, "parse_datastore_id", invalidId); funcErr == nil {
+			t.Errorf("expected error for ID %q", invalidId)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"strings"
+)
+
+var _ function.Function = &workspaceIdFunction{}
+
+// workspaceIdFunction builds the ARM ID of an Azure ML Workspace.
+type workspaceIdFunction struct{}
+
+func newWorkspaceIdFunction() function.Function {
+	return &workspaceIdFunction{}
+}
+
+func (f *workspaceIdFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "workspace_id"
+}
+
+func (f *workspaceIdFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary:             "Builds the ID of an Azure ML Workspace.",
+		MarkdownDescription: "Builds the ARM ID of an Azure ML Workspace from its subscription, resource group and name.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "subscription_id",
+				MarkdownDescription: "The ID of the subscription of the workspace.",
+			},
+			function.StringParameter{
+				Name:                "resource_group_name",
+				MarkdownDescription: "The name of the resource group of the workspace.",
+			},
+			function.StringParameter{
+				Name:                "workspace_name",
+				MarkdownDescription: "The name of the workspace.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *workspaceIdFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var subscriptionId, resourceGroupName, workspaceName string
+	resp.Error = req.Arguments.Get(ctx, &subscriptionId, &resourceGroupName, &workspaceName)
+	if resp.Error != nil {
+		return
+	}
+
+	for i, v := range []string{subscriptionId, resourceGroupName, workspaceName} {
+		if stringIsEmpty(v) || strings.Contains(v, "/") {
+			resp.Error = function.NewArgumentFuncError(int64(i), fmt.Sprintf("invalid ID segment %q", v))
+			return
+		}
+	}
+
+	resp.Error = resp.Result.Set(ctx, fmt.Sprintf(amlWorkspaceIdFormat, subscriptionId, resourceGroupName, workspaceName))
+}
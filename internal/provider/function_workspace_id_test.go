@@ -0,0 +1,31 @@
+package provider
+
+import "testing"
+
+func TestWorkspaceIdFunction(t *testing.T) {
+	result, funcErr := callTestFunction(t, "workspace_id", "sub", "rg", "ws")
+	if funcErr != nil {
+		t.Fatalf("unexpected error: %s", funcErr.Text)
+	}
+	var id string
+	if err := result.As(&id); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if id != "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws" {
+		t.Errorf("unexpected ID %q", id)
+	}
+	resourceGroupName, workspaceName, segments, err := splitWorkspaceId(id)
+	if err != nil || resourceGroupName != "rg" || workspaceName != "ws" || len(segments) != 0 {
+		t.Errorf("the ID %q is not parsed back: %q %q %v %v", id, resourceGroupName, workspaceName, segments, err)
+	}
+
+	for _, arguments := range [][]string{
+		{"", "rg", "ws"},
+		{"sub", " ", "ws"},
+		{"sub", "rg", "ws/datastores/ds"},
+	} {
+		if _, funcErr := callTestFunction(t, "workspace_id", arguments...); funcErr == nil {
+			t.Errorf("expected error for arguments %q", arguments)
+		}
+	}
+}
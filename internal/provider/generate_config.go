@@ -0,0 +1,287 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/orobix/azureml-go-sdk/workspace"
+	"github.com/zclconf/go-cty/cty"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// GenerateConfigOptions are the credentials used for reading the objects of an Azure ML Workspace and the
+// workspace whose configuration is generated by GenerateConfig.
+type GenerateConfigOptions struct {
+	ClientId          string
+	ClientSecret      string
+	TenantId          string
+	SubscriptionId    string
+	ResourceGroupName string
+	WorkspaceName     string
+}
+
+// generatedResource is a resource of the generated configuration labelled Label, imported from the object of the
+// workspace whose ID is Id.
+type generatedResource struct {
+	Type       string
+	Label      string
+	Id         string
+	Attributes []generatedAttribute
+}
+
+// generatedAttribute is an argument of a generated resource. The argument references the variable named Variable
+// when set, it is an object of the nested Attributes when they are not empty, and it is the literal Value otherwise.
+type generatedAttribute struct {
+	Name       string
+	Value      cty.Value
+	Variable   string
+	Attributes []generatedAttribute
+}
+
+// workspaceConfigGenerator returns the resources managing the objects of a kind belonging to an Azure ML Workspace.
+type workspaceConfigGenerator func(ctx context.Context, client *apiClient, resourceGroupName, workspaceName string) ([]generatedResource, error)
+
+// workspaceConfigGenerators are the generators of the objects supported by GenerateConfig, in the order in which
+// their resources are written.
+var workspaceConfigGenerators = []workspaceConfigGenerator{
+	generateDatastoresConfig,
+}
+
+// GenerateConfig writes the configuration managing the objects of an Azure ML Workspace, made of an import block
+// and of a resource for each object. The secrets, which are not returned by Azure ML, are set through write-only
+// arguments referencing ephemeral variables, so that planning the configuration after the import shows no changes.
+func GenerateConfig(ctx context.Context, w io.Writer, opts GenerateConfigOptions) error {
+	var missing []string
+	for name, value := range map[string]string{
+		"client ID":           opts.ClientId,
+		"client secret":       opts.ClientSecret,
+		"tenant ID":           opts.TenantId,
+		"subscription ID":     opts.SubscriptionId,
+		"resource group name": opts.ResourceGroupName,
+		"workspace name":      opts.WorkspaceName,
+	} {
+		if stringIsEmpty(value) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf("missing %s", strings.Join(missing, ", "))
+	}
+
+	client, err := newApiClient(opts.ClientId, opts.ClientSecret, opts.TenantId, opts.SubscriptionId)
+	if err != nil {
+		return err
+	}
+	var resources []generatedResource
+	for _, generator := range workspaceConfigGenerators {
+		r, err := generator(ctx, client, opts.ResourceGroupName, opts.WorkspaceName)
+		if err != nil {
+			return err
+		}
+		resources = append(resources, r...)
+	}
+
+	_, err = w.Write(renderGeneratedConfig(opts.ResourceGroupName, opts.WorkspaceName, resources))
+	return err
+}
+
+func generateDatastoresConfig(ctx context.Context, client *apiClient, resourceGroupName, workspaceName string) ([]generatedResource, error) {
+	datastores, err := listDatastores(ctx, client.arm, resourceGroupName, workspaceName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list the datastores of the workspace %s: %w", workspaceName, err)
+	}
+	names := make([]string, len(datastores))
+	for i, datastore := range datastores {
+		names[i] = datastore.Name
+	}
+	labels := uniqueHclIdentifiers(names)
+	resources := make([]generatedResource, 0, len(datastores))
+	for i, datastore := range datastores {
+		resources = append(resources, datastoreConfig(resourceGroupName, workspaceName, labels[i], datastore))
+	}
+	return resources, nil
+}
+
+// datastoreConfig returns the azureml_datastore resource labelled label managing the datastore provided as argument.
+// Only the arguments whose value differs from their default are set, as Terraform does not plan any change for them.
+func datastoreConfig(resourceGroupName, workspaceName, label string, datastore workspace.Datastore) generatedResource {
+	attributes := []generatedAttribute{
+		{Name: "resource_group_name", Value: cty.StringVal(resourceGroupName)},
+		{Name: "workspace_name", Value: cty.StringVal(workspaceName)},
+		{Name: "name", Value: cty.StringVal(datastore.Name)},
+	}
+	if datastore.Description != "" {
+		attributes = append(attributes, generatedAttribute{Name: "description", Value: cty.StringVal(datastore.Description)})
+	}
+	if datastore.IsDefault {
+		attributes = append(attributes, generatedAttribute{Name: "is_default", Value: cty.True})
+	}
+	attributes = append(attributes, generatedAttribute{Name: "storage_type", Value: cty.StringVal(datastore.StorageType)})
+	if datastore.StorageAccountName != "" {
+		attributes = append(attributes, generatedAttribute{
+			Name:  "storage_account_name",
+			Value: cty.StringVal(datastore.StorageAccountName),
+		})
+	}
+	if datastore.StorageContainerName != "" {
+		attributes = append(attributes, generatedAttribute{
+			Name:  "storage_container_name",
+			Value: cty.StringVal(datastore.StorageContainerName),
+		})
+	}
+
+	if datastore.Auth != nil {
+		auth := []generatedAttribute{
+			{Name: "credentials_type", Value: cty.StringVal(datastore.Auth.CredentialsType)},
+		}
+		for _, a := range []struct{ name, value string }{
+			{"tenant_id", datastore.Auth.TenantId},
+			{"client_id", datastore.Auth.ClientId},
+			{"sql_user_name", datastore.Auth.SqlUserName},
+		} {
+			if a.value != "" {
+				auth = append(auth, generatedAttribute{Name: a.name, Value: cty.StringVal(a.value)})
+			}
+		}
+		secret := map[string]string{
+			"AccountKey":       "account_key",
+			"ServicePrincipal": "client_secret",
+			"SqlAdmin":         "sql_user_password",
+		}[datastore.Auth.CredentialsType]
+		if secret != "" {
+			auth = append(auth, generatedAttribute{
+				Name:     secret + "_wo",
+				Variable: fmt.Sprintf("datastore_%s_%s", label, secret),
+			})
+		}
+		attributes = append(attributes, generatedAttribute{Name: "auth", Attributes: auth})
+	}
+
+	return generatedResource{
+		Type:       "azureml_datastore",
+		Label:      label,
+		Id:         datastore.Id,
+		Attributes: attributes,
+	}
+}
+
+var hclIdentifierInvalidCharsRegexp = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// hclIdentifier returns an identifier valid in HCL derived from the name provided as argument.
+func hclIdentifier(name string) string {
+	identifier := hclIdentifierInvalidCharsRegexp.ReplaceAllString(name, "_")
+	if identifier == "" || !(identifier[0] == '_' || identifier[0] >= 'A' && identifier[0] <= 'Z' ||
+		identifier[0] >= 'a' && identifier[0] <= 'z') {
+		identifier = "_" + identifier
+	}
+	return identifier
+}
+
+// uniqueHclIdentifiers returns the identifiers derived by hclIdentifier from the names provided as argument, made
+// unique with a numeric suffix. Distinct names such as "a.b" and "a b" can have the same identifier: the names that
+// are already valid identifiers keep them, and the suffixes of the others follow the order of the names.
+func uniqueHclIdentifiers(names []string) []string {
+	identifiers := make([]string, len(names))
+	used := map[string]bool{}
+	for i, name := range names {
+		if hclIdentifier(name) == name {
+			identifiers[i] = name
+			used[name] = true
+		}
+	}
+	for i, name := range names {
+		if identifiers[i] != "" {
+			continue
+		}
+		base := hclIdentifier(name)
+		identifier := base
+		for n := 2; used[identifier]; n++ {
+			identifier = fmt.Sprintf("%s_%d", base, n)
+		}
+		identifiers[i] = identifier
+		used[identifier] = true
+	}
+	return identifiers
+}
+
+// renderGeneratedConfig returns the configuration made of the variables referenced by the resources provided as
+// argument, and of an import block and the resource block for each of them.
+func renderGeneratedConfig(resourceGroupName, workspaceName string, resources []generatedResource) []byte {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body()
+	body.AppendUnstructuredTokens(hclwrite.Tokens{{
+		Type: hclsyntax.TokenComment,
+		Bytes: []byte(fmt.Sprintf(
+			"# Generated by terraform-provider-azureml from the Azure ML Workspace %s of the resource group %s.\n",
+			workspaceName,
+			resourceGroupName,
+		)),
+	}})
+
+	for _, r := range resources {
+		for _, variable := range generatedVariables(r.Attributes) {
+			body.AppendNewline()
+			block := body.AppendNewBlock("variable", []string{variable})
+			block.Body().SetAttributeTraversal("type", hcl.Traversal{hcl.TraverseRoot{Name: "string"}})
+			block.Body().SetAttributeValue("sensitive", cty.True)
+			block.Body().SetAttributeValue("ephemeral", cty.True)
+		}
+	}
+
+	// The labels of the resources of a type are made unique by their generator through uniqueHclIdentifiers
+	for _, r := range resources {
+		body.AppendNewline()
+		importBlock := body.AppendNewBlock("import", nil)
+		importBlock.Body().SetAttributeTraversal("to", hcl.Traversal{
+			hcl.TraverseRoot{Name: r.Type},
+			hcl.TraverseAttr{Name: r.Label},
+		})
+		importBlock.Body().SetAttributeValue("id", cty.StringVal(r.Id))
+
+		body.AppendNewline()
+		resourceBlock := body.AppendNewBlock("resource", []string{r.Type, r.Label})
+		for _, a := range r.Attributes {
+			resourceBlock.Body().SetAttributeRaw(a.Name, generatedAttributeTokens(a))
+		}
+	}
+
+	return hclwrite.Format(f.Bytes())
+}
+
+// generatedVariables returns the names of the variables referenced by the attributes provided as argument.
+func generatedVariables(attributes []generatedAttribute) []string {
+	var variables []string
+	for _, a := range attributes {
+		if a.Variable != "" {
+			variables = append(variables, a.Variable)
+		}
+		variables = append(variables, generatedVariables(a.Attributes)...)
+	}
+	return variables
+}
+
+func generatedAttributeTokens(a generatedAttribute) hclwrite.Tokens {
+	if a.Variable != "" {
+		return hclwrite.TokensForTraversal(hcl.Traversal{
+			hcl.TraverseRoot{Name: "var"},
+			hcl.TraverseAttr{Name: a.Variable},
+		})
+	}
+	if len(a.Attributes) > 0 {
+		var objectAttributes []hclwrite.ObjectAttrTokens
+		for _, nested := range a.Attributes {
+			objectAttributes = append(objectAttributes, hclwrite.ObjectAttrTokens{
+				Name:  hclwrite.TokensForIdentifier(nested.Name),
+				Value: generatedAttributeTokens(nested),
+			})
+		}
+		return hclwrite.TokensForObject(objectAttributes)
+	}
+	return hclwrite.TokensForValue(a.Value)
+}
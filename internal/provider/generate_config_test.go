@@ -0,0 +1,390 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/orobix/azureml-go-sdk/workspace"
+	"github.com/zclconf/go-cty/cty"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const testGeneratedConfig = `# Generated by terraform-provider-azureml from the Azure ML Workspace ws of the resource group rg.
+
+variable "datastore_training_data_client_secret" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+import {
+  to = azureml_datastore.training_data
+  id = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/training_data"
+}
+
+resource "azureml_datastore" "training_data" {
+  resource_group_name    = "rg"
+  workspace_name         = "ws"
+  name                   = "training_data"
+  description            = "Training \"data\""
+  storage_type           = "AzureBlob"
+  storage_account_name   = "account"
+  storage_container_name = "training"
+  auth = {
+    credentials_type = "ServicePrincipal"
+    tenant_id        = "tenant-id"
+    client_id        = "client-id"
+    client_secret_wo = var.datastore_training_data_client_secret
+  }
+}
+
+import {
+  to = azureml_datastore.workspaceblobstore
+  id = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/workspaceblobstore"
+}
+
+resource "azureml_datastore" "workspaceblobstore" {
+  resource_group_name    = "rg"
+  workspace_name         = "ws"
+  name                   = "workspaceblobstore"
+  is_default             = true
+  storage_type           = "AzureBlob"
+  storage_account_name   = "account"
+  storage_container_name = "azureml-blobstore"
+  auth = {
+    credentials_type = "None"
+  }
+}
+`
+
+// testGeneratedDatastoresResponse is the response of Azure ML to the list of the datastores of a workspace
+// authenticating with each type of credentials, whose secrets are never returned.
+const testGeneratedDatastoresResponse = `{
+  "value": [
+    {
+      "id": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/training_data",
+      "name": "training_data",
+      "type": "Microsoft.MachineLearningServices/workspaces/datastores",
+      "properties": {
+        "contents": {
+          "contentsType": "AzureBlob",
+          "accountName": "account",
+          "containerName": "training",
+          "credentials": {
+            "credentialsType": "ServicePrincipal",
+            "authorityUrl": "https://login.microsoftonline.com",
+            "clientId": "client-id",
+            "resourceUri": "https://storage.azure.com/",
+            "tenantId": "tenant-id"
+          },
+          "endpoint": "core.windows.net",
+          "protocol": "https"
+        },
+        "description": "Training \"data\"",
+        "isDefault": false,
+        "properties": {},
+        "tags": {}
+      },
+      "systemData": {
+        "createdAt": "2022-03-01T10:00:00.1234567+00:00",
+        "createdBy": "user",
+        "createdByType": "User",
+        "lastModifiedAt": "2022-04-01T10:00:00.1234567+00:00",
+        "lastModifiedBy": "user",
+        "lastModifiedByType": "User"
+      }
+    },
+    {
+      "id": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/workspaceblobstore",
+      "name": "workspaceblobstore",
+      "type": "Microsoft.MachineLearningServices/workspaces/datastores",
+      "properties": {
+        "contents": {
+          "contentsType": "AzureBlob",
+          "accountName": "account",
+          "containerName": "azureml-blobstore",
+          "credentials": {"credentialsType": "None"},
+          "endpoint": "core.windows.net",
+          "protocol": "https"
+        },
+        "isDefault": true,
+        "properties": {},
+        "tags": {}
+      },
+      "systemData": {"createdAt": "2022-03-01T10:00:00Z", "createdBy": "user", "createdByType": "User"}
+    },
+    {
+      "id": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/files",
+      "name": "files",
+      "type": "Microsoft.MachineLearningServices/workspaces/datastores",
+      "properties": {
+        "contents": {
+          "contentsType": "AzureFile",
+          "accountName": "account",
+          "containerName": "share",
+          "credentials": {"credentialsType": "AccountKey"},
+          "endpoint": "core.windows.net",
+          "protocol": "https"
+        },
+        "isDefault": false,
+        "properties": {},
+        "tags": {}
+      },
+      "systemData": {"createdAt": "2022-03-01T10:00:00Z", "createdBy": "user", "createdByType": "User"}
+    },
+    {
+      "id": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/sql",
+      "name": "sql",
+      "type": "Microsoft.MachineLearningServices/workspaces/datastores",
+      "properties": {
+        "contents": {
+          "contentsType": "AzureSqlDatabase",
+          "credentials": {"credentialsType": "SqlAdmin", "userId": "admin"},
+          "databaseName": "db",
+          "endpoint": "database.windows.net",
+          "portNumber": 1433,
+          "serverName": "server"
+        },
+        "isDefault": false,
+        "properties": {},
+        "tags": {}
+      },
+      "systemData": {"createdAt": "2022-03-01T10:00:00Z", "createdBy": "user", "createdByType": "User"}
+    }
+  ]
+}`
+
+// testGeneratedDatastores returns the datastores of testGeneratedDatastoresResponse as read by the provider.
+func testGeneratedDatastores(t *testing.T) []workspace.Datastore {
+	var page armDatastoreList
+	if err := json.Unmarshal([]byte(testGeneratedDatastoresResponse), &page); err != nil {
+		t.Fatalf("unable to parse the datastores: %v", err)
+	}
+	datastores := make([]workspace.Datastore, 0, len(page.Value))
+	for i := range page.Value {
+		datastores = append(datastores, *page.Value[i].toDatastore())
+	}
+	return datastores
+}
+
+func TestRenderGeneratedConfig(t *testing.T) {
+	datastores := testGeneratedDatastores(t)
+	resources := []generatedResource{
+		datastoreConfig("rg", "ws", "training_data", datastores[0]),
+		datastoreConfig("rg", "ws", "workspaceblobstore", datastores[1]),
+	}
+	if config := string(renderGeneratedConfig("rg", "ws", resources)); config != testGeneratedConfig {
+		t.Errorf("unexpected configuration:\n%s", config)
+	}
+
+	// The name of the SQL user is returned by Azure ML, unlike its password
+	sql := string(renderGeneratedConfig("rg", "ws", []generatedResource{datastoreConfig("rg", "ws", "sql", datastores[3])}))
+	if !regexp.MustCompile(`sql_user_name\s+= "admin"`).MatchString(sql) {
+		t.Errorf("the SQL user name has not been generated:\n%s", sql)
+	}
+}
+
+func TestHclIdentifier(t *testing.T) {
+	for name, expected := range map[string]string{
+		"workspaceblobstore": "workspaceblobstore",
+		"training-data":      "training-data",
+		"data.v2":            "data_v2",
+		"2022_data":          "_2022_data",
+	} {
+		if identifier := hclIdentifier(name); identifier != expected {
+			t.Errorf("unexpected identifier of %q: %q, expected %q", name, identifier, expected)
+		}
+	}
+}
+
+func TestUniqueHclIdentifiers(t *testing.T) {
+	identifiers := uniqueHclIdentifiers([]string{"a.b", "a b", "a_b", "a_b_2.", "c"})
+	expected := []string{"a_b_2", "a_b_3", "a_b", "a_b_2_", "c"}
+	if !reflect.DeepEqual(identifiers, expected) {
+		t.Errorf("unexpected identifiers %q, expected %q", identifiers, expected)
+	}
+}
+
+func TestGenerateDatastoresConfigCollidingNames(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"value": [
+  {"name": "a.b", "properties": {"contents": {"contentsType": "AzureBlob", "credentials": {"credentialsType": "AccountKey"}}}},
+  {"name": "a_b", "properties": {"contents": {"contentsType": "AzureBlob", "credentials": {"credentialsType": "AccountKey"}}}}
+]}`))
+	}))
+	defer server.Close()
+
+	resources, err := generateDatastoresConfig(context.Background(), &apiClient{arm: newTestArmClient(server)}, "rg", "ws")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := renderGeneratedConfig("rg", "ws", resources)
+	file, diags := hclsyntax.ParseConfig(text, "generated.tf", hcl.InitialPos)
+	if diags.HasErrors() {
+		t.Fatalf("invalid configuration: %s\n%s", diags.Error(), text)
+	}
+	labels := map[string]bool{}
+	for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+		if block.Type == "import" {
+			continue
+		}
+		label := strings.Join(block.Labels, ".")
+		if labels[label] {
+			t.Errorf("duplicate %s %s:\n%s", block.Type, label, text)
+		}
+		labels[label] = true
+	}
+	for _, label := range []string{
+		"azureml_datastore.a_b",
+		"azureml_datastore.a_b_2",
+		"datastore_a_b_account_key",
+		"datastore_a_b_2_account_key",
+	} {
+		if !labels[label] {
+			t.Errorf("missing block %s:\n%s", label, text)
+		}
+	}
+}
+
+// testCtyToTftypes converts the value of an evaluated HCL expression to the type provided as argument, in which
+// the attributes of the objects that are not set are null.
+func testCtyToTftypes(t *testing.T, value cty.Value, valueType tftypes.Type) tftypes.Value {
+	switch {
+	case valueType.Is(tftypes.String):
+		return tftypes.NewValue(valueType, value.AsString())
+	case valueType.Is(tftypes.Bool):
+		return tftypes.NewValue(valueType, value.True())
+	case valueType.Is(tftypes.Number):
+		return tftypes.NewValue(valueType, value.AsBigFloat())
+	}
+	objectType, ok := valueType.(tftypes.Object)
+	if !ok {
+		t.Fatalf("unsupported type %s", valueType)
+	}
+	attributes := map[string]tftypes.Value{}
+	for name, attributeType := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(attributeType, nil)
+		if value.Type().HasAttribute(name) {
+			attributes[name] = testCtyToTftypes(t, value.GetAttr(name), attributeType)
+		}
+	}
+	return tftypes.NewValue(valueType, attributes)
+}
+
+// TestGeneratedDatastoreConfigPlan checks that planning the generated configuration after importing the datastores
+// shows no changes.
+func TestGeneratedDatastoreConfigPlan(t *testing.T) {
+	ctx := context.Background()
+	server, stateType := newTestDatastoreServer(t)
+	schemaResp := &resource.SchemaResponse{}
+	(&datastoreResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	for _, datastore := range testGeneratedDatastores(t) {
+		t.Run(datastore.Name, func(t *testing.T) {
+			text := renderGeneratedConfig("rg", "ws", []generatedResource{datastoreConfig("rg", "ws", datastore.Name, datastore)})
+			file, diags := hclsyntax.ParseConfig(text, "generated.tf", hcl.InitialPos)
+			if diags.HasErrors() {
+				t.Fatalf("invalid configuration: %s\n%s", diags.Error(), text)
+			}
+
+			// The variables are set to secrets, which are never part of the plan
+			variables := map[string]cty.Value{}
+			var resourceBody *hclsyntax.Body
+			for _, block := range file.Body.(*hclsyntax.Body).Blocks {
+				switch block.Type {
+				case "variable":
+					variables[block.Labels[0]] = cty.StringVal("s3cret")
+				case "import":
+					id, diags := block.Body.Attributes["id"].Expr.Value(nil)
+					if diags.HasErrors() || id.AsString() != datastore.Id {
+						t.Errorf("unexpected imported ID %#v", id)
+					}
+				case "resource":
+					resourceBody = block.Body
+				}
+			}
+			evalCtx := &hcl.EvalContext{Variables: map[string]cty.Value{"var": cty.ObjectVal(variables)}}
+			attributes := map[string]cty.Value{}
+			for name, attribute := range resourceBody.Attributes {
+				value, diags := attribute.Expr.Value(evalCtx)
+				if diags.HasErrors() {
+					t.Fatalf("unable to evaluate %s: %s", name, diags.Error())
+				}
+				attributes[name] = value
+			}
+			config := testCtyToTftypes(t, cty.ObjectVal(attributes), stateType)
+
+			// The state of the imported datastore is the one written by ImportState and then by Read
+			data := datastoreResourceModel{
+				Id:                types.StringValue(datastore.Id),
+				ResourceGroupName: types.StringValue("rg"),
+				WorkspaceName:     types.StringValue("ws"),
+				Name:              types.StringValue(datastore.Name),
+				Auth:              types.ObjectNull(datastoreAuthAttributeTypes),
+			}
+			if diags := data.setDatastore(&datastore); diags.HasError() {
+				t.Fatalf("unexpected diagnostics %v", diags)
+			}
+			state := tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(stateType, nil)}
+			if diags := state.Set(ctx, &data); diags.HasError() {
+				t.Fatalf("unexpected diagnostics %v", diags)
+			}
+
+			// As Terraform does, the proposed new state takes the configured values and the prior values of the
+			// computed attributes that are not configured
+			proposed := testValueAttributes(t, config)
+			prior := testValueAttributes(t, state.Raw)
+			for name, value := range proposed {
+				if value.IsNull() && schemaResp.Schema.Attributes[name].IsComputed() {
+					proposed[name] = prior[name]
+				}
+			}
+
+			configValue, err := tfprotov6.NewDynamicValue(stateType, config)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			priorValue, err := tfprotov6.NewDynamicValue(stateType, state.Raw)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			proposedValue, err := tfprotov6.NewDynamicValue(stateType, tftypes.NewValue(stateType, proposed))
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "azureml_datastore",
+				PriorState:       &priorValue,
+				ProposedNewState: &proposedValue,
+				Config:           &configValue,
+			})
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+			if len(resp.RequiresReplace) > 0 {
+				t.Errorf("unexpected replacement caused by %v", resp.RequiresReplace)
+			}
+			planned, err := resp.PlannedState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if diffs, _ := state.Raw.Diff(planned); len(diffs) > 0 {
+				t.Errorf("unexpected changes planned: %v\n%s", diffs, text)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"sort"
+	"strings"
+)
+
+func GetAllowedManagedIdentityTypes() []string {
+	return []string{
+		"SystemAssigned",
+		"UserAssigned",
+		"SystemAssigned,UserAssigned",
+	}
+}
+
+// managedIdentitySchema returns the schema of the managed identity assigned to an ARM resource.
+func managedIdentitySchema(forceNew bool) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		MaxItems:    1,
+		ForceNew:    forceNew,
+		Description: "The managed identity assigned to the resource.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Type:     schema.TypeString,
+					Required: true,
+					Description: fmt.Sprintf(
+						"The type of the managed identity. Possible values are: %+q.",
+						GetAllowedManagedIdentityTypes(),
+					),
+					ForceNew:     forceNew,
+					ValidateFunc: validation.StringInSlice(GetAllowedManagedIdentityTypes(), false),
+				},
+				"identity_ids": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "The IDs of the user assigned identities.",
+					ForceNew:    forceNew,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringIsNotEmpty,
+					},
+				},
+				"principal_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The principal ID of the system assigned identity.",
+				},
+				"tenant_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The tenant ID of the system assigned identity.",
+				},
+			},
+		},
+	}
+}
+
+type armManagedIdentity struct {
+	Type                   string                             `json:"type"`
+	PrincipalId            string                             `json:"principalId,omitempty"`
+	TenantId               string                             `json:"tenantId,omitempty"`
+	UserAssignedIdentities map[string]armUserAssignedIdentity `json:"userAssignedIdentities,omitempty"`
+}
+
+type armUserAssignedIdentity struct {
+	PrincipalId string `json:"principalId,omitempty"`
+	ClientId    string `json:"clientId,omitempty"`
+}
+
+func expandManagedIdentity(l []interface{}) *armManagedIdentity {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	data := l[0].(map[string]interface{})
+	identity := &armManagedIdentity{Type: data["type"].(string)}
+	if ids := data["identity_ids"].(*schema.Set).List(); len(ids) > 0 {
+		identity.UserAssignedIdentities = make(map[string]armUserAssignedIdentity, len(ids))
+		for _, id := range ids {
+			identity.UserAssignedIdentities[id.(string)] = armUserAssignedIdentity{}
+		}
+	}
+	return identity
+}
+
+func flattenManagedIdentity(identity *armManagedIdentity) []interface{} {
+	if identity == nil || identity.Type == "" || identity.Type == "None" {
+		return make([]interface{}, 0)
+	}
+	ids := make([]string, 0, len(identity.UserAssignedIdentities))
+	for id := range identity.UserAssignedIdentities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	identityIds := make([]interface{}, len(ids))
+	for i, id := range ids {
+		identityIds[i] = id
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"type":         strings.ReplaceAll(identity.Type, " ", ""),
+			"identity_ids": identityIds,
+			"principal_id": identity.PrincipalId,
+			"tenant_id":    identity.TenantId,
+		},
+	}
+}
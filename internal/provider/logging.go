@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"bytes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// armLogSubsystem is the tflog subsystem of the requests sent by the armClient. Its level is inherited from
+	// TF_LOG_PROVIDER_AZUREML, unless it is set through TF_LOG_PROVIDER_AZUREML_ARM.
+	armLogSubsystem = "arm"
+	providerLogEnv  = "TF_LOG_PROVIDER_AZUREML"
+)
+
+// secretLogPatterns match the secrets which must not be logged, which are masked by their replacement.
+var secretLogPatterns = []struct {
+	regexp      *regexp.Regexp
+	replacement string
+}{
+	{
+		regexp.MustCompile(`(?i)("(?:client_?secret|account_?key|sql_user_password|password|key|pat|sas|sas_?token|sas_?uri|primary_?key|secondary_?key|access_?token)"\s*:\s*)"(?:[^"\\]|\\.)*"`),
+		`${1}"***"`,
+	},
+	{regexp.MustCompile(`(?i)(bearer\s+)[a-z0-9\-._~+/]+=*`), "${1}***"},
+	{regexp.MustCompile(`(?i)([?&]sig=)[^&"\s]+`), "${1}***"},
+}
+
+// maskSecrets returns the string provided as argument with the secrets it contains replaced by "***".
+func maskSecrets(s string) string {
+	for _, p := range secretLogPatterns {
+		s = p.regexp.ReplaceAllString(s, p.replacement)
+	}
+	return s
+}
+
+// loggingTransport is an http.RoundTripper logging the requests and the responses through tflog. The method, the
+// URL, the status, the Azure correlation and request IDs and the latency are logged at DEBUG, while the JSON bodies
+// are logged at TRACE. Secrets are masked.
+type loggingTransport struct {
+	subsystem string
+	transport http.RoundTripper
+}
+
+func newLoggingTransport(subsystem string, transport http.RoundTripper) *loggingTransport {
+	return &loggingTransport{subsystem: subsystem, transport: transport}
+}
+
+func (t *loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := tflog.NewSubsystem(req.Context(), t.subsystem, tflog.WithLevelFromEnv(providerLogEnv, t.subsystem))
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_url":    maskSecrets(req.URL.String()),
+	}
+	if id := req.Header.Get("x-ms-client-request-id"); id != "" {
+		fields["x_ms_client_request_id"] = id
+	}
+	tflog.SubsystemDebug(ctx, t.subsystem, "Sending HTTP request", fields)
+	if body := requestLogBody(req); body != "" {
+		tflog.SubsystemTrace(ctx, t.subsystem, "HTTP request body", withLogField(fields, "http_request_body", body))
+	}
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	fields["latency_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		tflog.SubsystemError(ctx, t.subsystem, "HTTP request failed", withLogField(fields, "error", maskSecrets(err.Error())))
+		return nil, err
+	}
+
+	fields["http_status_code"] = resp.StatusCode
+	for field, header := range map[string]string{
+		"x_ms_correlation_request_id": "x-ms-correlation-request-id",
+		"x_ms_request_id":             "x-ms-request-id",
+		"x_ms_client_request_id":      "x-ms-client-request-id",
+	} {
+		if v := resp.Header.Get(header); v != "" {
+			fields[field] = v
+		}
+	}
+	tflog.SubsystemDebug(ctx, t.subsystem, "Received HTTP response", fields)
+
+	if isJsonContent(resp.Header) && resp.Body != nil {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		tflog.SubsystemTrace(ctx, t.subsystem, "HTTP response body", withLogField(fields, "http_response_body", maskSecrets(string(body))))
+	}
+	return resp, nil
+}
+
+// requestLogBody returns the masked body of the request provided as argument, without consuming it, if it is JSON.
+func requestLogBody(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil || !isJsonContent(req.Header) {
+		return ""
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	b, err := io.ReadAll(body)
+	if err != nil {
+		return ""
+	}
+	return maskSecrets(string(b))
+}
+
+func isJsonContent(header http.Header) bool {
+	return strings.Contains(header.Get("Content-Type"), "json")
+}
+
+func withLogField(fields map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(fields)+1)
+	for k, v := range fields {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
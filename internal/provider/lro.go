@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// lroPollInterval is the interval between two polls of a long-running operation when the service does not
+// specify one through the Retry-After header.
+var lroPollInterval = 10 * time.Second
+
+// pollFunc polls a long-running operation once, returning whether it is completed and, if the service
+// specified it, how long to wait before polling again.
+type pollFunc func(ctx context.Context) (done bool, retryAfter time.Duration, err error)
+
+// pollUntilDone polls a long-running operation until it is completed, it fails or the context expires. The
+// timeout, if positive, further bounds the deadline of the context, which for resources is already set by
+// Terraform to the timeout of the operation. The description is used in the timeout errors.
+func pollUntilDone(ctx context.Context, description string, timeout time.Duration, poll pollFunc) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	for {
+		done, retryAfter, err := poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("timeout while waiting for %s: %w", description, ctx.Err())
+			}
+			return err
+		}
+		if done {
+			return nil
+		}
+		delay := lroPollInterval
+		if retryAfter > 0 {
+			delay = retryAfter
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("timeout while waiting for %s: %w", description, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// parseRetryAfter returns the delay specified by the Retry-After header provided as argument, either as a
+// number of seconds or as an HTTP date, or 0 if the header is missing or invalid.
+func parseRetryAfter(header http.Header) time.Duration {
+	v := strings.TrimSpace(header.Get("Retry-After"))
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// operationUrl returns the URL for polling the long-running operation started by the request whose response is
+// provided as argument, preferring the Azure-AsyncOperation header to the Location one, or an empty string if
+// the operation has been completed synchronously.
+func operationUrl(resp *armResponse) (string, bool) {
+	if resp == nil || (resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusAccepted) {
+		return "", false
+	}
+	if u := resp.Header.Get("Azure-AsyncOperation"); u != "" {
+		return u, true
+	}
+	if u := resp.Header.Get("Location"); u != "" {
+		return u, false
+	}
+	return "", false
+}
+
+// waitForOperation waits for the completion of the long-running operation started by the request whose response
+// is provided as argument, following its Azure-AsyncOperation or Location header. Nothing is done if the response
+// has none of them. An operationError describing the failure is returned if the operation does not succeed.
+func waitForOperation(ctx context.Context, c *armClient, resp *armResponse, timeout time.Duration) error {
+	u, isAsyncOperation := operationUrl(resp)
+	if u == "" {
+		return nil
+	}
+	retryAfter := parseRetryAfter(resp.Header)
+	first := true
+	return pollUntilDone(ctx, "operation "+u, timeout, func(ctx context.Context) (bool, time.Duration, error) {
+		// The first poll is delayed as requested by the response that started the operation
+		if first && retryAfter > 0 {
+			first = false
+			return false, retryAfter, nil
+		}
+		first = false
+		if isAsyncOperation {
+			return pollAsyncOperation(ctx, c, u)
+		}
+		return pollLocation(ctx, c, u)
+	})
+}
+
+// pollAsyncOperation polls the status of the operation at the URL of an Azure-AsyncOperation header.
+func pollAsyncOperation(ctx context.Context, c *armClient, u string) (bool, time.Duration, error) {
+	resp, err := c.do(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return false, 0, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return false, 0, &armResponseError{resp.StatusCode, string(resp.Body)}
+	}
+	status := new(operationStatus)
+	if err := unmarshalArmResponse(resp, status); err != nil {
+		return false, 0, err
+	}
+	switch strings.ToLower(status.Status) {
+	case "succeeded":
+		return true, 0, nil
+	case "failed", "canceled", "cancelled":
+		opErr := &operationError{Status: status.Status}
+		if status.Error != nil {
+			opErr.armErrorDetail = *status.Error
+		}
+		return false, 0, opErr
+	}
+	return false, parseRetryAfter(resp.Header), nil
+}
+
+// pollLocation polls the operation at the URL of a Location header, which is in progress as long as it
+// responds with 202 Accepted.
+func pollLocation(ctx context.Context, c *armClient, u string) (bool, time.Duration, error) {
+	resp, err := c.do(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return false, 0, err
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		return false, parseRetryAfter(resp.Header), nil
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		// Failed operations report their error with the same envelope as the Azure-AsyncOperation status
+		status := new(operationStatus)
+		if json.Unmarshal(resp.Body, status) == nil && status.Error != nil && status.Error.Code != "" {
+			return false, 0, &operationError{Status: "Failed", armErrorDetail: *status.Error}
+		}
+		return false, 0, &armResponseError{resp.StatusCode, string(resp.Body)}
+	}
+	return true, 0, nil
+}
+
+// isProvisioningInProgress returns true if the provisioning state provided as argument is not terminal.
+func isProvisioningInProgress(state string) bool {
+	switch state {
+	case "Creating", "Updating", "Provisioning", "Accepted":
+		return true
+	}
+	return false
+}
+
+// getProvisioned retrieves the resource at the path provided as argument and unmarshals it into out. If its
+// provisioning is still in progress, e.g. because a previous apply was interrupted while waiting for it, the
+// provisioning is waited for before retrieving the resource again, so that the refreshed state reflects the
+// outcome of the operation. An operationError is returned, after unmarshalling the resource, if its provisioning
+// ended unsuccessfully.
+func getProvisioned(ctx context.Context, c *armClient, path string, out interface{}) error {
+	var raw json.RawMessage
+	if err := c.get(ctx, path, &raw); err != nil {
+		return err
+	}
+	r := new(provisionedResource)
+	if err := json.Unmarshal(raw, r); err != nil {
+		return fmt.Errorf("unable to parse response: %w", err)
+	}
+	if isProvisioningInProgress(r.Properties.ProvisioningState) {
+		err := waitForProvisioning(ctx, c, nil, path, 0)
+		var opErr *operationError
+		if err != nil && !errors.As(err, &opErr) {
+			return err
+		}
+		if err := c.get(ctx, path, &raw); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(raw, r); err != nil {
+			return fmt.Errorf("unable to parse response: %w", err)
+		}
+	}
+	if err := json.Unmarshal(raw, out); err != nil {
+		return fmt.Errorf("unable to parse response: %w", err)
+	}
+	_, _, err := provisioningDone(path, r.Properties.ProvisioningState, true)
+	return err
+}
+
+// failedProvisioningDiagnostics handles the operationError returned by getProvisioned for the resource described
+// as argument. The resource is removed from the state, so that Terraform plans to create it again, and a warning
+// describing the failure is returned. False is returned if the error is not an operationError.
+func failedProvisioningDiagnostics(d *schema.ResourceData, description string, err error) (diag.Diagnostics, bool) {
+	var opErr *operationError
+	if !errors.As(err, &opErr) {
+		return nil, false
+	}
+	d.SetId("")
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("The provisioning of %s failed", description),
+		Detail: fmt.Sprintf(
+			"%s\n\nThe %s has been removed from the state and will be created again by the next apply.",
+			err.Error(),
+			description,
+		),
+	}}, true
+}
+
+// operationStatus is the status of a long-running operation returned by the Azure-AsyncOperation URL.
+type operationStatus struct {
+	Id     string          `json:"id,omitempty"`
+	Name   string          `json:"name,omitempty"`
+	Status string          `json:"status"`
+	Error  *armErrorDetail `json:"error,omitempty"`
+}
+
+// operationError is returned when a long-running operation ends unsuccessfully. The resource is set when the
+// failure is reported by the provisioning state of a resource rather than by the operation status.
+type operationError struct {
+	armErrorDetail
+	Status   string
+	Resource string
+}
+
+func (e *operationError) Error() string {
+	b := &strings.Builder{}
+	if e.Resource != "" {
+		fmt.Fprintf(b, "provisioning of %s ended with state %q", e.Resource, e.Status)
+	} else {
+		fmt.Fprintf(b, "operation ended with status %q", e.Status)
+	}
+	writeArmErrorDetail(b, e.armErrorDetail, "")
+	return b.String()
+}
@@ -0,0 +1,240 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setTestPollInterval(t *testing.T) {
+	interval := lroPollInterval
+	lroPollInterval = time.Millisecond
+	t.Cleanup(func() { lroPollInterval = interval })
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	cases := []struct {
+		value    string
+		expected time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{"-1", 0},
+		{"soon", 0},
+		{time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), 0},
+	}
+	for _, c := range cases {
+		header := http.Header{}
+		if c.value != "" {
+			header.Set("Retry-After", c.value)
+		}
+		if got := parseRetryAfter(header); got != c.expected {
+			t.Errorf("parseRetryAfter(%q) = %v, expected %v", c.value, got, c.expected)
+		}
+	}
+
+	header := http.Header{}
+	header.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	if got := parseRetryAfter(header); got <= 0 || got > time.Minute {
+		t.Errorf("unexpected delay %v for an HTTP date", got)
+	}
+}
+
+func TestWaitForOperationAsyncOperation(t *testing.T) {
+	setTestPollInterval(t)
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/operations/ok":
+			if atomic.AddInt32(&polls, 1) < 3 {
+				_, _ = w.Write([]byte(`{"status": "InProgress"}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"status": "Succeeded"}`))
+		case "/operations/failed":
+			_, _ = w.Write([]byte(`{"status": "Failed", "error": {"code": "DeploymentFailed", "message": "The deployment failed.",
+				"details": [{"code": "ImageBuildFailure", "message": "The image could not be built.", "target": "environment"}]}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	c := newTestArmClient(server)
+
+	resp := &armResponse{StatusCode: http.StatusCreated, Header: http.Header{}}
+	resp.Header.Set("Azure-AsyncOperation", server.URL+"/operations/ok")
+	resp.Header.Set("Location", server.URL+"/unused")
+	if err := waitForOperation(context.Background(), c, resp, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 3 {
+		t.Errorf("expected 3 polls, got %d", polls)
+	}
+
+	resp.Header.Set("Azure-AsyncOperation", server.URL+"/operations/failed")
+	err := waitForOperation(context.Background(), c, resp, time.Minute)
+	var opErr *operationError
+	if !errors.As(err, &opErr) {
+		t.Fatalf("expected operation error, got %v", err)
+	}
+	if opErr.Status != "Failed" || opErr.Code != "DeploymentFailed" || len(opErr.Details) != 1 {
+		t.Errorf("unexpected operation error %+v", opErr)
+	}
+	for _, s := range []string{"DeploymentFailed", "ImageBuildFailure: The image could not be built. (target: environment)"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("error %q does not contain %q", err.Error(), s)
+		}
+	}
+}
+
+func TestWaitForOperationLocation(t *testing.T) {
+	setTestPollInterval(t)
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/operations/ok":
+			if atomic.AddInt32(&polls, 1) < 2 {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "/operations/failed":
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"error": {"code": "QuotaExceeded", "message": "Not enough quota."}}`))
+		}
+	}))
+	defer server.Close()
+	c := newTestArmClient(server)
+
+	resp := &armResponse{StatusCode: http.StatusAccepted, Header: http.Header{}}
+	resp.Header.Set("Location", server.URL+"/operations/ok")
+	if err := waitForOperation(context.Background(), c, resp, time.Minute); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polls != 2 {
+		t.Errorf("expected 2 polls, got %d", polls)
+	}
+
+	resp.Header.Set("Location", server.URL+"/operations/failed")
+	var opErr *operationError
+	if err := waitForOperation(context.Background(), c, resp, time.Minute); !errors.As(err, &opErr) || opErr.Code != "QuotaExceeded" {
+		t.Errorf("expected operation error, got %v", err)
+	}
+
+	// Synchronous responses are not polled
+	if err := waitForOperation(context.Background(), c, &armResponse{StatusCode: http.StatusOK, Header: resp.Header}, time.Minute); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestWaitForOperationTimeout(t *testing.T) {
+	setTestPollInterval(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "InProgress"}`))
+	}))
+	defer server.Close()
+	c := newTestArmClient(server)
+
+	resp := &armResponse{StatusCode: http.StatusAccepted, Header: http.Header{}}
+	resp.Header.Set("Azure-AsyncOperation", server.URL+"/operations/slow")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := waitForOperation(ctx, c, resp, 0)
+	if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "timeout while waiting") {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+}
+
+func TestGetProvisionedResumesPolling(t *testing.T) {
+	setTestPollInterval(t)
+	var gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := "Creating"
+		if atomic.AddInt32(&gets, 1) > 3 {
+			state = "Succeeded"
+		}
+		_, _ = fmt.Fprintf(w, `{"id": "/endpoints/e1", "properties": {"provisioningState": %q}}`, state)
+	}))
+	defer server.Close()
+	c := newTestArmClient(server)
+
+	r := new(provisionedResource)
+	if err := getProvisioned(context.Background(), c, "/endpoints/e1", r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if r.Id != "/endpoints/e1" || r.Properties.ProvisioningState != "Succeeded" {
+		t.Errorf("unexpected resource %+v", r)
+	}
+
+	// Resources whose provisioning is completed are retrieved once
+	before := atomic.LoadInt32(&gets)
+	if err := getProvisioned(context.Background(), c, "/endpoints/e1", r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if requests := atomic.LoadInt32(&gets) - before; requests != 1 {
+		t.Errorf("expected 1 request, got %d", requests)
+	}
+}
+
+func TestGetProvisionedFailure(t *testing.T) {
+	setTestPollInterval(t)
+	var gets int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state := "Updating"
+		if atomic.AddInt32(&gets, 1) > 2 {
+			state = "Failed"
+		}
+		_, _ = fmt.Fprintf(w, `{"id": "/endpoints/e1", "properties": {"provisioningState": %q}}`, state)
+	}))
+	defer server.Close()
+	c := newTestArmClient(server)
+
+	// The interrupted provisioning resumed by the refresh fails
+	r := new(provisionedResource)
+	err := getProvisioned(context.Background(), c, "/endpoints/e1", r)
+	var opErr *operationError
+	if !errors.As(err, &opErr) || opErr.Status != "Failed" || opErr.Resource != "/endpoints/e1" {
+		t.Errorf("expected operation error, got %v", err)
+	}
+	if r.Properties.ProvisioningState != "Failed" {
+		t.Errorf("unexpected resource %+v", r)
+	}
+
+	// The provisioning already failed when the resource is refreshed
+	if err := getProvisioned(context.Background(), c, "/endpoints/e1", r); !errors.As(err, &opErr) {
+		t.Errorf("expected operation error, got %v", err)
+	}
+
+	// The failed resource is removed from the state, so that it is created again
+	d := resourceOnlineEndpoint().TestResourceData()
+	d.SetId("/endpoints/e1")
+	diags, ok := failedProvisioningDiagnostics(d, "online endpoint e1", err)
+	if !ok || d.Id() != "" || len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Errorf("unexpected diagnostics %v for ID %q", diags, d.Id())
+	}
+	if _, ok := failedProvisioningDiagnostics(d, "online endpoint e1", errors.New("connection reset")); ok {
+		t.Error("unexpected handling of an error other than an operationError")
+	}
+}
+
+func TestWaitForProvisioningFailure(t *testing.T) {
+	setTestPollInterval(t)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"properties": {"provisioningState": "Failed"}}`))
+	}))
+	defer server.Close()
+	c := newTestArmClient(server)
+
+	err := waitForProvisioning(context.Background(), c, nil, "/endpoints/e1", time.Minute)
+	var opErr *operationError
+	if !errors.As(err, &opErr) || opErr.Status != "Failed" || opErr.Resource != "/endpoints/e1" {
+		t.Errorf("expected operation error, got %v", err)
+	}
+}
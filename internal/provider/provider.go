@@ -2,10 +2,10 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"github.com/orobix/azureml-go-sdk/workspace"
 	"time"
 )
 
@@ -60,11 +60,33 @@ func New(version string) func() *schema.Provider {
 				},
 			},
 			DataSourcesMap: map[string]*schema.Resource{
-				"azureml_datastore":  dataSourceDatastore(),
-				"azureml_datastores": dataSourceDatastores(),
+				"azureml_model":                    dataSourceModel(),
+				"azureml_online_endpoint_keys":     dataSourceOnlineEndpointKeys(),
+				"azureml_workspace_outbound_rules": dataSourceWorkspaceOutboundRules(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
-				"azureml_datastore": resourceDatastore(),
+				"azureml_compute_instance":                 resourceComputeInstance(),
+				"azureml_environment":                      resourceEnvironment(),
+				"azureml_data_asset":                       resourceDataAsset(),
+				"azureml_model":                            resourceModel(),
+				"azureml_online_endpoint":                  resourceOnlineEndpoint(),
+				"azureml_online_deployment":                resourceOnlineDeployment(),
+				"azureml_online_endpoint_traffic":          resourceOnlineEndpointTraffic(),
+				"azureml_online_endpoint_key_regeneration": resourceOnlineEndpointKeyRegeneration(),
+				"azureml_batch_endpoint":                   resourceBatchEndpoint(),
+				"azureml_batch_deployment":                 resourceBatchDeployment(),
+				"azureml_component":                        resourceComponent(),
+				"azureml_registry":                         resourceRegistry(),
+				"azureml_workspace_connection":             resourceWorkspaceConnection(),
+				"azureml_schedule":                         resourceSchedule(),
+				"azureml_kubernetes_compute":               resourceKubernetesCompute(),
+				"azureml_synapse_spark_compute":            resourceSynapseSparkCompute(),
+				"azureml_virtual_machine_compute":          resourceVirtualMachineCompute(),
+				"azureml_workspace_outbound_rule":          resourceWorkspaceOutboundRule(),
+				"azureml_featurestore":                     resourceFeatureStore(),
+				"azureml_featurestore_entity":              resourceFeatureStoreEntity(),
+				"azureml_featureset":                       resourceFeatureSet(),
+				"azureml_code_version":                     resourceCodeVersion(),
 			},
 		}
 		p.ConfigureContextFunc = configure(version, p)
@@ -73,31 +95,38 @@ func New(version string) func() *schema.Provider {
 }
 
 type apiClient struct {
-	ws *workspace.Workspace
+	arm *armClient
 }
 
 func configure(version string, p *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(_ context.Context, r *schema.ResourceData) (interface{}, diag.Diagnostics) {
-		var apiClient = new(apiClient)
 		var diags diag.Diagnostics
 
-		ws, err := workspace.New(workspace.Config{
-			ClientId:       r.Get("client_id").(string),
-			ClientSecret:   r.Get("client_secret").(string),
-			TenantId:       r.Get("tenant_id").(string),
-			SubscriptionId: r.Get("subscription_id").(string),
-		}, false)
-
+		apiClient, err := newApiClient(
+			r.Get("client_id").(string),
+			r.Get("client_secret").(string),
+			r.Get("tenant_id").(string),
+			r.Get("subscription_id").(string),
+		)
 		if err != nil {
 			diags = append(diags, diag.Diagnostic{
 				Severity: diag.Error,
 				Summary:  "Unable to create client",
-				Detail:   "Unable to create azureml client:\n\n" + err.Error(),
+				Detail:   err.Error(),
 			})
 			return nil, diags
 		}
 
-		apiClient.ws = ws
 		return apiClient, diags
 	}
 }
+
+// newApiClient returns the clients shared by the SDKv2 and the framework providers.
+func newApiClient(clientId, clientSecret, tenantId, subscriptionId string) (*apiClient, error) {
+	arm, err := newArmClient(clientId, clientSecret, tenantId, subscriptionId)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create Azure Resource Manager client:\n\n%s", err.Error())
+	}
+
+	return &apiClient{arm: arm}, nil
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-framework/action"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"testing"
+)
+
+func TestProvider(t *testing.T) {
+	if err := New("dev")().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestMuxServer(t *testing.T) {
+	ctx := context.Background()
+	server, err := NewMuxServer(ctx, "dev")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	resp, err := server().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Errorf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	if _, ok := resp.ResourceSchemas["azureml_compute_instance"]; !ok {
+		t.Errorf("missing SDKv2 resource azureml_compute_instance")
+	}
+	if _, ok := resp.ResourceSchemas["azureml_datastore"]; !ok {
+		t.Errorf("missing framework resource azureml_datastore")
+	}
+	for _, name := range []string{"azureml_datastore", "azureml_datastores", "azureml_model"} {
+		if _, ok := resp.DataSourceSchemas[name]; !ok {
+			t.Errorf("missing data source %s", name)
+		}
+	}
+	if _, ok := resp.EphemeralResourceSchemas["azureml_online_endpoint_keys"]; !ok {
+		t.Errorf("missing ephemeral resource azureml_online_endpoint_keys")
+	}
+	if _, ok := resp.ActionSchemas["azureml_provision_managed_network"]; !ok {
+		t.Errorf("missing action azureml_provision_managed_network")
+	}
+	for _, name := range []string{"parse_datastore_id", "build_datastore_uri", "parse_asset_uri", "workspace_id"} {
+		if _, ok := resp.Functions[name]; !ok {
+			t.Errorf("missing function %s", name)
+		}
+	}
+}
+
+func TestUnconfiguredFrameworkClient(t *testing.T) {
+	ctx := context.Background()
+	// The provider data is nil when the provider configuration contains unknown values
+	keys := &onlineEndpointKeysEphemeralResource{}
+	keys.Configure(ctx, ephemeral.ConfigureRequest{}, &ephemeral.ConfigureResponse{})
+	openResp := &ephemeral.OpenResponse{}
+	keys.Open(ctx, ephemeral.OpenRequest{}, openResp)
+
+	datastore := &datastoreResource{}
+	datastore.Configure(ctx, resource.ConfigureRequest{}, &resource.ConfigureResponse{})
+	readResp := &resource.ReadResponse{}
+	datastore.Read(ctx, resource.ReadRequest{}, readResp)
+
+	provisionManagedNetwork := &provisionManagedNetworkAction{}
+	provisionManagedNetwork.Configure(ctx, action.ConfigureRequest{}, &action.ConfigureResponse{})
+	invokeResp := &action.InvokeResponse{}
+	provisionManagedNetwork.Invoke(ctx, action.InvokeRequest{}, invokeResp)
+
+	for name, diags := range map[string]diag.Diagnostics{
+		"azureml_online_endpoint_keys":      openResp.Diagnostics,
+		"azureml_datastore":                 readResp.Diagnostics,
+		"azureml_provision_managed_network": invokeResp.Diagnostics,
+	} {
+		if len(diags) != 1 || diags[0].Summary() != "Unconfigured provider" {
+			t.Errorf("%s: expected an unconfigured provider diagnostic, got %v", name, diags)
+		}
+	}
+}
+
+// callTestFunction calls the provider function provided as argument through the provider server, returning its
+// result and its error.
+func callTestFunction(t *testing.T, name string, arguments ...string) (tftypes.Value, *tfprotov6.FunctionError) {
+	ctx := context.Background()
+	server, err := NewMuxServer(ctx, "dev")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	// As Terraform does, the functions are discovered through the provider schema
+	schemas, err := server().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	definition, ok := schemas.Functions[name]
+	if !ok {
+		t.Fatalf("missing function %s", name)
+	}
+
+	var values []*tfprotov6.DynamicValue
+	for _, argument := range arguments {
+		value, err := tfprotov6.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, argument))
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		values = append(values, &value)
+	}
+	resp, err := server().CallFunction(ctx, &tfprotov6.CallFunctionRequest{Name: name, Arguments: values})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if resp.Error != nil {
+		return tftypes.Value{}, resp.Error
+	}
+	result, err := resp.Result.Unmarshal(definition.Return.Type)
+	if err != nil {
+		t.Fatalf("unable to decode the result of %s: %s", name, err)
+	}
+	return result, nil
+}
@@ -0,0 +1,458 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+func GetAllowedBatchOutputActions() []string {
+	return []string{
+		"AppendRow",
+		"SummaryOnly",
+	}
+}
+
+func GetAllowedBatchLoggingLevels() []string {
+	return []string{
+		"Info",
+		"Warning",
+		"Debug",
+	}
+}
+
+func resourceBatchDeployment() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Deployment of a Batch Endpoint of an Azure ML Workspace.",
+
+		CreateContext: resourceBatchDeploymentCreate,
+		ReadContext:   resourceBatchDeploymentRead,
+		UpdateContext: resourceBatchDeploymentUpdate,
+		DeleteContext: resourceBatchDeploymentDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importEndpointDeployment("batchEndpoints"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the deployment belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the deployment belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"endpoint_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the batch endpoint to which the deployment belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The name of the deployment. It must be between 3 and 32 characters long, start with a " +
+					"letter and contain only letters, digits and hyphens.",
+				ForceNew:     true,
+				ValidateFunc: IsValidEndpointName,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the deployment.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the deployment.",
+			},
+			"model_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the model version to deploy.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The environment used for running the deployment, either as the ID of an environment " +
+					"version or as a reference in the `azureml:<name>:<version>` form.",
+				ValidateFunc:     validation.StringIsNotEmpty,
+				DiffSuppressFunc: suppressEquivalentAssetReferenceDiff("environments"),
+			},
+			"code_configuration": codeConfigurationSchema(),
+			"compute_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the compute cluster on which the scoring jobs run.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"instance_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "The number of nodes of the compute cluster used by each scoring job.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"mini_batch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      10,
+				Description:  "The number of files processed by the scoring script in a single call.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"max_concurrency_per_instance": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "The maximum number of parallel scoring processes on each node.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"output_action": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "AppendRow",
+				Description: fmt.Sprintf(
+					"How the outputs of the scoring script are collected. Possible values are: %+q.",
+					GetAllowedBatchOutputActions(),
+				),
+				ValidateFunc: validation.StringInSlice(GetAllowedBatchOutputActions(), false),
+			},
+			"output_file_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "predictions.csv",
+				Description:  "The name of the file collecting the outputs when `output_action` is `AppendRow`.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"retry_settings": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "The retry settings of the mini-batches.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_retries": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      3,
+							Description:  "The maximum number of retries of a failed or timed out mini-batch.",
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"timeout_seconds": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      30,
+							Description:  "The timeout, in seconds, of the scoring of a mini-batch.",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+					},
+				},
+			},
+			"error_threshold": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  -1,
+				Description: "The number of file failures that are tolerated before the scoring job is aborted. " +
+					"`-1` tolerates any number of failures.",
+				ValidateFunc: validation.IntAtLeast(-1),
+			},
+			"logging_level": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Info",
+				Description: fmt.Sprintf(
+					"The logging level of the scoring jobs. Possible values are: %+q.",
+					GetAllowedBatchLoggingLevels(),
+				),
+				ValidateFunc: validation.StringInSlice(GetAllowedBatchLoggingLevels(), false),
+			},
+			"environment_variables": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The environment variables set in the scoring jobs.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the deployment.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceBatchDeploymentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	endpointName := d.Get("endpoint_name").(string)
+	name := d.Get("name").(string)
+
+	// The deployment must be created in the same region of its endpoint
+	endpointPath := batchEndpointPath(client.arm, resourceGroupName, workspaceName, endpointName)
+	endpoint := new(batchEndpointResource)
+	if err := client.arm.get(ctx, endpointPath, endpoint); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to retrieve batch endpoint %s: %w", endpointName, err))
+	}
+
+	path := endpointDeploymentPath(endpointPath, name)
+	resp, err := client.arm.put(ctx, path, resourceBatchDeploymentGetResourceData(d, endpoint.Location), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(path)
+	if err := waitForProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for batch deployment %s to be created: %w", name, err))
+	}
+
+	return resourceBatchDeploymentRead(ctx, d, meta)
+}
+
+func resourceBatchDeploymentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	name := d.Get("name").(string)
+
+	deployment := new(batchDeploymentResource)
+	if err := getProvisioned(ctx, client.arm, resourceBatchDeploymentPath(client.arm, d), deployment); err != nil {
+		if diags, ok := failedProvisioningDiagnostics(d, fmt.Sprintf("batch deployment %s", name), err); ok {
+			return diags
+		}
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading batch deployment %s", name),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(deployment.Id)
+	return resourceBatchDeploymentSetResourceData(d, deployment)
+}
+
+func resourceBatchDeploymentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	name := d.Get("name").(string)
+	path := resourceBatchDeploymentPath(client.arm, d)
+
+	// The location is not exposed as an attribute, so keep the one of the existing deployment
+	existing := new(batchDeploymentResource)
+	if err := client.arm.get(ctx, path, existing); err != nil {
+		return diag.FromErr(err)
+	}
+
+	resp, err := client.arm.put(ctx, path, resourceBatchDeploymentGetResourceData(d, existing.Location), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := waitForProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for batch deployment %s to be updated: %w", name, err))
+	}
+
+	return resourceBatchDeploymentRead(ctx, d, meta)
+}
+
+func resourceBatchDeploymentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	name := d.Get("name").(string)
+	path := resourceBatchDeploymentPath(client.arm, d)
+
+	resp, err := client.arm.delete(ctx, path)
+	if err == nil {
+		err = waitForDeletion(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutDelete))
+	}
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting batch deployment %s.", name),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceBatchDeploymentPath(c *armClient, d *schema.ResourceData) string {
+	return endpointDeploymentPath(
+		batchEndpointPath(
+			c,
+			d.Get("resource_group_name").(string),
+			d.Get("workspace_name").(string),
+			d.Get("endpoint_name").(string),
+		),
+		d.Get("name").(string),
+	)
+}
+
+func resourceBatchDeploymentGetResourceData(d *schema.ResourceData, location string) *batchDeploymentResource {
+	deployment := &batchDeploymentResource{
+		Location: location,
+		Tags:     expandStringMap(d.Get("tags").(map[string]interface{})),
+		Properties: batchDeploymentProperties{
+			Description: d.Get("description").(string),
+			Model: &batchDeploymentModel{
+				ReferenceType: "Id",
+				AssetId:       d.Get("model_id").(string),
+			},
+			EnvironmentId:             d.Get("environment_id").(string),
+			CodeConfiguration:         expandCodeConfiguration(d.Get("code_configuration").([]interface{})),
+			Compute:                   d.Get("compute_id").(string),
+			Resources:                 &batchDeploymentResources{InstanceCount: d.Get("instance_count").(int)},
+			MiniBatchSize:             d.Get("mini_batch_size").(int),
+			MaxConcurrencyPerInstance: d.Get("max_concurrency_per_instance").(int),
+			OutputAction:              d.Get("output_action").(string),
+			OutputFileName:            d.Get("output_file_name").(string),
+			ErrorThreshold:            d.Get("error_threshold").(int),
+			LoggingLevel:              d.Get("logging_level").(string),
+			EnvironmentVariables:      expandStringMap(d.Get("environment_variables").(map[string]interface{})),
+		},
+	}
+
+	if l := d.Get("retry_settings").([]interface{}); len(l) > 0 && l[0] != nil {
+		data := l[0].(map[string]interface{})
+		deployment.Properties.RetrySettings = &batchRetrySettings{
+			MaxRetries: data["max_retries"].(int),
+			Timeout:    formatIsoDuration(time.Duration(data["timeout_seconds"].(int)) * time.Second),
+		}
+	}
+
+	return deployment
+}
+
+func resourceBatchDeploymentSetResourceData(d *schema.ResourceData, deployment *batchDeploymentResource) diag.Diagnostics {
+	props := deployment.Properties
+	if err := d.Set("tags", deployment.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if props.Model != nil {
+		if err := d.Set("model_id", props.Model.AssetId); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("environment_id", props.EnvironmentId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("code_configuration", flattenCodeConfiguration(props.CodeConfiguration)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("compute_id", props.Compute); err != nil {
+		return diag.FromErr(err)
+	}
+	if props.Resources != nil {
+		if err := d.Set("instance_count", props.Resources.InstanceCount); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("mini_batch_size", props.MiniBatchSize); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("max_concurrency_per_instance", props.MaxConcurrencyPerInstance); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("output_action", props.OutputAction); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("output_file_name", props.OutputFileName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("error_threshold", props.ErrorThreshold); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("logging_level", props.LoggingLevel); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("environment_variables", props.EnvironmentVariables); err != nil {
+		return diag.FromErr(err)
+	}
+
+	retrySettings := make([]interface{}, 0)
+	if r := props.RetrySettings; r != nil {
+		timeout, err := parseIsoDuration(r.Timeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		retrySettings = append(retrySettings, map[string]interface{}{
+			"max_retries":     r.MaxRetries,
+			"timeout_seconds": int(timeout / time.Second),
+		})
+	}
+	if err := d.Set("retry_settings", retrySettings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+type batchDeploymentResource struct {
+	Id         string                    `json:"id,omitempty"`
+	Name       string                    `json:"name,omitempty"`
+	Location   string                    `json:"location"`
+	Tags       map[string]string         `json:"tags,omitempty"`
+	Properties batchDeploymentProperties `json:"properties"`
+}
+
+type batchDeploymentProperties struct {
+	Description               string                    `json:"description,omitempty"`
+	Model                     *batchDeploymentModel     `json:"model,omitempty"`
+	EnvironmentId             string                    `json:"environmentId,omitempty"`
+	CodeConfiguration         *codeConfiguration        `json:"codeConfiguration,omitempty"`
+	Compute                   string                    `json:"compute"`
+	Resources                 *batchDeploymentResources `json:"resources,omitempty"`
+	MiniBatchSize             int                       `json:"miniBatchSize"`
+	MaxConcurrencyPerInstance int                       `json:"maxConcurrencyPerInstance"`
+	OutputAction              string                    `json:"outputAction"`
+	OutputFileName            string                    `json:"outputFileName,omitempty"`
+	RetrySettings             *batchRetrySettings       `json:"retrySettings,omitempty"`
+	ErrorThreshold            int                       `json:"errorThreshold"`
+	LoggingLevel              string                    `json:"loggingLevel"`
+	EnvironmentVariables      map[string]string         `json:"environmentVariables,omitempty"`
+	ProvisioningState         string                    `json:"provisioningState,omitempty"`
+}
+
+type batchDeploymentModel struct {
+	ReferenceType string `json:"referenceType"`
+	AssetId       string `json:"assetId"`
+}
+
+type batchDeploymentResources struct {
+	InstanceCount int `json:"instanceCount"`
+}
+
+type batchRetrySettings struct {
+	MaxRetries int    `json:"maxRetries"`
+	Timeout    string `json:"timeout"`
+}
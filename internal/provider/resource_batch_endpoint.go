@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+func resourceBatchEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Batch Endpoint of an Azure ML Workspace.",
+
+		CreateContext: resourceBatchEndpointCreate,
+		ReadContext:   resourceBatchEndpointRead,
+		UpdateContext: resourceBatchEndpointUpdate,
+		DeleteContext: resourceBatchEndpointDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceChild("batchEndpoints"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the endpoint belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the endpoint belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The name of the endpoint. It must be unique within the Azure region, between 3 and 32 " +
+					"characters long, start with a letter and contain only letters, digits and hyphens.",
+				ForceNew:     true,
+				ValidateFunc: IsValidEndpointName,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the endpoint.",
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The Azure region in which the endpoint is created. Defaults to the location of the Azure " +
+					"ML Workspace.",
+				ForceNew: true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the endpoint.",
+				ForceNew:    true,
+			},
+			"identity": managedIdentitySchema(true),
+			"default_deployment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The name of the deployment used when the endpoint is invoked without specifying a " +
+					"deployment. If not specified, the first deployment created for the endpoint becomes the default one.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the endpoint.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"scoring_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URI used for invoking the endpoint.",
+			},
+			"swagger_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URI of the Swagger definition of the endpoint.",
+			},
+		},
+	}
+}
+
+func resourceBatchEndpointCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	location := d.Get("location").(string)
+	if location == "" {
+		ws, err := client.arm.getWorkspace(ctx, resourceGroupName, workspaceName)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("unable to retrieve the location of workspace %s: %w", workspaceName, err))
+		}
+		location = ws.Location
+	}
+
+	path := batchEndpointPath(client.arm, resourceGroupName, workspaceName, name)
+	resp, err := client.arm.put(ctx, path, resourceBatchEndpointGetResourceData(d, location), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(path)
+	if err := waitForProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for batch endpoint %s to be created: %w", name, err))
+	}
+
+	return resourceBatchEndpointRead(ctx, d, meta)
+}
+
+func resourceBatchEndpointRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	endpoint := new(batchEndpointResource)
+	err := getProvisioned(ctx, client.arm, batchEndpointPath(client.arm, resourceGroupName, workspaceName, name), endpoint)
+	if err != nil {
+		if diags, ok := failedProvisioningDiagnostics(d, fmt.Sprintf("batch endpoint %s", name), err); ok {
+			return diags
+		}
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading batch endpoint %s", name),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(endpoint.Id)
+	return resourceBatchEndpointSetResourceData(d, endpoint)
+}
+
+func resourceBatchEndpointUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	path := batchEndpointPath(client.arm, resourceGroupName, workspaceName, name)
+	resp, err := client.arm.put(ctx, path, resourceBatchEndpointGetResourceData(d, d.Get("location").(string)), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := waitForProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for batch endpoint %s to be updated: %w", name, err))
+	}
+
+	return resourceBatchEndpointRead(ctx, d, meta)
+}
+
+func resourceBatchEndpointDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	path := batchEndpointPath(client.arm, resourceGroupName, workspaceName, name)
+
+	resp, err := client.arm.delete(ctx, path)
+	if err == nil {
+		err = waitForDeletion(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutDelete))
+	}
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting batch endpoint %s.", name),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceBatchEndpointGetResourceData(d *schema.ResourceData, location string) *batchEndpointResource {
+	endpoint := &batchEndpointResource{
+		Location: location,
+		Identity: expandManagedIdentity(d.Get("identity").([]interface{})),
+		Tags:     expandStringMap(d.Get("tags").(map[string]interface{})),
+		Properties: batchEndpointProperties{
+			// Batch endpoints support only Azure AD token authentication
+			AuthMode:    "AADToken",
+			Description: d.Get("description").(string),
+		},
+	}
+	if endpoint.Identity == nil {
+		endpoint.Identity = &armManagedIdentity{Type: "SystemAssigned"}
+	}
+	if defaultDeploymentName := d.Get("default_deployment_name").(string); defaultDeploymentName != "" {
+		endpoint.Properties.Defaults = &batchEndpointDefaults{DeploymentName: defaultDeploymentName}
+	}
+	return endpoint
+}
+
+func resourceBatchEndpointSetResourceData(d *schema.ResourceData, endpoint *batchEndpointResource) diag.Diagnostics {
+	if err := d.Set("location", endpoint.Location); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("identity", flattenManagedIdentity(endpoint.Identity)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", endpoint.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+
+	props := endpoint.Properties
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	defaultDeploymentName := ""
+	if props.Defaults != nil {
+		defaultDeploymentName = props.Defaults.DeploymentName
+	}
+	if err := d.Set("default_deployment_name", defaultDeploymentName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("scoring_uri", props.ScoringUri); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("swagger_uri", props.SwaggerUri); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func batchEndpointPath(c *armClient, resourceGroupName, workspaceName, name string) string {
+	return fmt.Sprintf("%s/batchEndpoints/%s", c.workspaceId(resourceGroupName, workspaceName), name)
+}
+
+type batchEndpointResource struct {
+	Id         string                  `json:"id,omitempty"`
+	Name       string                  `json:"name,omitempty"`
+	Location   string                  `json:"location"`
+	Identity   *armManagedIdentity     `json:"identity,omitempty"`
+	Tags       map[string]string       `json:"tags,omitempty"`
+	Properties batchEndpointProperties `json:"properties"`
+}
+
+type batchEndpointProperties struct {
+	AuthMode          string                 `json:"authMode"`
+	Description       string                 `json:"description,omitempty"`
+	Defaults          *batchEndpointDefaults `json:"defaults,omitempty"`
+	ScoringUri        string                 `json:"scoringUri,omitempty"`
+	SwaggerUri        string                 `json:"swaggerUri,omitempty"`
+	ProvisioningState string                 `json:"provisioningState,omitempty"`
+}
+
+type batchEndpointDefaults struct {
+	DeploymentName string `json:"deploymentName,omitempty"`
+}
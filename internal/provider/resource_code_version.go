@@ -0,0 +1,392 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+// codeContentHashProperty is the property of the code versions storing the hash of their content.
+const codeContentHashProperty = "contentHash"
+
+func resourceCodeVersion() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a version of a Code asset of an Azure ML Workspace, uploading the files of a local " +
+			"directory to the default datastore of the workspace. A new version is registered only when the content " +
+			"of the directory changes. The code container is created together with its first version, and it is " +
+			"deleted when its last version is deleted, while the uploaded files are kept in the datastore.",
+
+		CreateContext: resourceCodeVersionCreate,
+		ReadContext:   resourceCodeVersionRead,
+		UpdateContext: resourceCodeVersionUpdate,
+		DeleteContext: resourceCodeVersionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceAssetVersion("codes"),
+		},
+
+		CustomizeDiff: resourceCodeVersionCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the code belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the code belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the code.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The version of the code. Required unless `auto_increment` is `true`, in which case " +
+					"it is assigned by Azure ML. Since code versions are immutable, `auto_increment` should be used " +
+					"when the content of `source_dir` is expected to change.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"auto_increment": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Should the version be assigned automatically, incrementing the latest version of the " +
+					"code? When `true`, any change to the content of `source_dir` registers a new version.",
+				ForceNew: true,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the code version.",
+			},
+			"source_dir": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The path of the local directory containing the code. The files matching the patterns " +
+					"of the `.amlignore` file of the directory or, if missing, of its `.gitignore` file are not " +
+					"uploaded, as well as the `.git` directory.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"ignore_patterns": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Description: "Additional patterns of the files which are not uploaded, in the `.gitignore` syntax. " +
+					"They take precedence over the patterns of the ignore file of `source_dir`.",
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+			"content_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The SHA-256 hash of the paths and of the content of the uploaded files, computed at plan time.",
+			},
+			"code_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URI of the blob directory to which the files have been uploaded.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the code version.",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the code version.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"is_archived": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Is the code version archived?",
+			},
+			"asset_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reference to the code version in the `azureml:<name>:<version>` form used by jobs, components and deployments.",
+			},
+		},
+	}
+}
+
+// resourceCodeVersionCustomizeDiff computes the content hash of the source directory, replacing the code version
+// when it changes.
+func resourceCodeVersionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeDiffAssetVersion(ctx, d, meta); err != nil {
+		return err
+	}
+	if !d.NewValueKnown("source_dir") || !d.NewValueKnown("ignore_patterns") {
+		return d.SetNewComputed("content_hash")
+	}
+	hash, _, err := codeVersionContent(d.Get("source_dir").(string), d.Get("ignore_patterns").([]interface{}))
+	if err != nil {
+		return err
+	}
+	if hash != d.Get("content_hash").(string) {
+		return d.SetNew("content_hash", hash)
+	}
+	return nil
+}
+
+// codeVersionContent returns the content hash and the files of the source directory which are not ignored.
+func codeVersionContent(sourceDir string, ignorePatterns []interface{}) (string, []string, error) {
+	files, err := listCodeFiles(sourceDir, expandStringList(ignorePatterns))
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to list the files of %s: %w", sourceDir, err)
+	}
+	hash, err := codeContentHash(sourceDir, files)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to hash the files of %s: %w", sourceDir, err)
+	}
+	return hash, files, nil
+}
+
+func resourceCodeVersionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	sourceDir := d.Get("source_dir").(string)
+
+	hash, files, err := codeVersionContent(sourceDir, d.Get("ignore_patterns").([]interface{}))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if planned := d.Get("content_hash").(string); planned != "" && planned != hash {
+		return diag.Errorf("the content of %s has changed since the plan was created, please plan again", sourceDir)
+	}
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "codes", name)
+	if err := ensureAssetContainer(ctx, client.arm, containerPath, &assetContainerResource{}); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create code %s: %w", name, err))
+	}
+
+	version := d.Get("version").(string)
+	if d.Get("auto_increment").(bool) {
+		v, err := nextAssetVersion(ctx, client.arm, containerPath)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		version = v
+	}
+
+	path := assetVersionPath(containerPath, version)
+	codeUri, err := uploadCodeVersion(ctx, client.arm, path, sourceDir, files)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to upload code %s version %s: %w", name, version, err))
+	}
+
+	code := resourceCodeVersionGetResourceData(d)
+	code.Properties.CodeUri = codeUri
+	code.Properties.Properties = map[string]string{codeContentHashProperty: hash}
+	if err := putAssetVersion(ctx, client.arm, path, code); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(path)
+	if err := d.Set("version", version); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("content_hash", hash); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceCodeVersionRead(ctx, d, meta)
+}
+
+func resourceCodeVersionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "codes", name)
+	code := new(codeVersionResource)
+	err := client.arm.get(ctx, assetVersionPath(containerPath, version), code)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading code %s version %s", name, version),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(code.Id)
+	return resourceCodeVersionSetResourceData(d, code)
+}
+
+func resourceCodeVersionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	// The content is immutable, hence the URI and the properties of the registered version are submitted again
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "codes", name)
+	path := assetVersionPath(containerPath, version)
+	existing := new(codeVersionResource)
+	if err := client.arm.get(ctx, path, existing); err != nil {
+		return diag.FromErr(err)
+	}
+	code := resourceCodeVersionGetResourceData(d)
+	code.Properties.CodeUri = existing.Properties.CodeUri
+	code.Properties.Properties = existing.Properties.Properties
+	if err := putAssetVersion(ctx, client.arm, path, code); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceCodeVersionRead(ctx, d, meta)
+}
+
+func resourceCodeVersionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "codes", name)
+	err := deleteAssetVersion(ctx, client.arm, containerPath, version)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting code %s version %s.", name, version),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+// uploadCodeVersion uploads the files of the source directory to the blob directory of the default datastore
+// of the workspace reserved by Azure ML for the code version at the path provided as argument, and returns
+// the URI of the blob directory.
+func uploadCodeVersion(ctx context.Context, c *armClient, path, sourceDir string, files []string) (string, error) {
+	upload := new(pendingUploadResponse)
+	request := &pendingUploadRequest{PendingUploadType: "TemporaryBlobReference"}
+	if _, err := c.post(ctx, path+"/startPendingUpload", request, upload); err != nil {
+		return "", err
+	}
+	blobReference := upload.BlobReferenceForConsumption
+	if blobReference == nil || blobReference.Credential == nil || blobReference.Credential.SasUri == "" {
+		return "", fmt.Errorf("Azure ML did not return a SAS URI for uploading the code")
+	}
+	if err := uploadCodeFiles(ctx, c.httpClient, blobReference.Credential.SasUri, sourceDir, files); err != nil {
+		return "", err
+	}
+	if blobReference.BlobUri != "" {
+		return blobReference.BlobUri, nil
+	}
+	return blobDirectoryUri(blobReference.Credential.SasUri)
+}
+
+func resourceCodeVersionGetResourceData(d *schema.ResourceData) *codeVersionResource {
+	return &codeVersionResource{
+		Properties: codeVersionProperties{
+			Description: d.Get("description").(string),
+			Tags:        expandStringMap(d.Get("tags").(map[string]interface{})),
+			IsArchived:  d.Get("is_archived").(bool),
+		},
+	}
+}
+
+func resourceCodeVersionSetResourceData(d *schema.ResourceData, code *codeVersionResource) diag.Diagnostics {
+	props := code.Properties
+	if err := d.Set("code_uri", props.CodeUri); err != nil {
+		return diag.FromErr(err)
+	}
+	if hash := props.Properties[codeContentHashProperty]; hash != "" {
+		if err := d.Set("content_hash", hash); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", props.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_archived", props.IsArchived); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("asset_id", fmt.Sprintf("azureml:%s:%s", d.Get("name").(string), d.Get("version").(string))); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+type codeVersionResource struct {
+	Id         string                `json:"id,omitempty"`
+	Name       string                `json:"name,omitempty"`
+	Properties codeVersionProperties `json:"properties"`
+}
+
+type codeVersionProperties struct {
+	CodeUri     string            `json:"codeUri"`
+	Properties  map[string]string `json:"properties,omitempty"`
+	Description string            `json:"description,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	IsArchived  bool              `json:"isArchived"`
+}
+
+type pendingUploadRequest struct {
+	PendingUploadType string `json:"pendingUploadType"`
+}
+
+type pendingUploadResponse struct {
+	PendingUploadId             string         `json:"pendingUploadId,omitempty"`
+	PendingUploadType           string         `json:"pendingUploadType,omitempty"`
+	BlobReferenceForConsumption *blobReference `json:"blobReferenceForConsumption,omitempty"`
+}
+
+type blobReference struct {
+	BlobUri             string                   `json:"blobUri,omitempty"`
+	StorageAccountArmId string                   `json:"storageAccountArmId,omitempty"`
+	Credential          *blobReferenceCredential `json:"credential,omitempty"`
+}
+
+type blobReferenceCredential struct {
+	CredentialType string `json:"credentialType,omitempty"`
+	SasUri         string `json:"sasUri,omitempty"`
+}
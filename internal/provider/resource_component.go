@@ -0,0 +1,354 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+)
+
+func resourceComponent() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a version of a Component of an Azure ML Workspace. The component container is " +
+			"created together with its first version, and it is deleted when its last version is deleted. Component " +
+			"versions are immutable: changing the spec of an existing version is rejected, a new version must be " +
+			"created instead.",
+
+		CreateContext: resourceComponentCreate,
+		ReadContext:   resourceComponentRead,
+		UpdateContext: resourceComponentUpdate,
+		DeleteContext: resourceComponentDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceAssetVersion("components"),
+		},
+
+		CustomizeDiff: resourceComponentCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the component belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the component belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the component. It must match the `name` of the spec, if the spec sets one.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"version": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The version of the component. It must match the `version` of the spec, if the spec sets one.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the component version.",
+			},
+			"spec": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The YAML specification of the component, in the format used by the Azure ML CLI v2 " +
+					"(e.g. `type: command`).",
+				ValidateFunc:     validation.StringIsNotEmpty,
+				ExactlyOneOf:     []string{"spec", "spec_path"},
+				DiffSuppressFunc: suppressEquivalentComponentSpecDiff,
+			},
+			"spec_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The local path of the YAML specification of the component.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"spec_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The SHA-256 hash of the specification of the component. Formatting changes of the YAML " +
+					"do not change the hash.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the component version.",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the component version.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"component_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the component (e.g. `command` or `pipeline`).",
+			},
+			"asset_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reference to the component version in the `azureml:<name>:<version>` form used by jobs.",
+			},
+		},
+	}
+}
+
+func resourceComponentCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("spec") || !d.NewValueKnown("spec_path") {
+		return nil
+	}
+	spec, err := readComponentSpec(d.Get("spec").(string), d.Get("spec_path").(string))
+	if err != nil {
+		return err
+	}
+	if d.NewValueKnown("name") && d.NewValueKnown("version") {
+		if err := checkComponentSpecIdentity(spec, d.Get("name").(string), d.Get("version").(string)); err != nil {
+			return err
+		}
+	}
+	h, err := yamlSpecHash(spec)
+	if err != nil {
+		return err
+	}
+
+	// Imported versions have no hash: their spec is assumed to match the configuration
+	oldHash, _ := d.GetChange("spec_hash")
+	if d.Id() != "" && !d.HasChange("version") && oldHash.(string) != "" && oldHash.(string) != h {
+		return fmt.Errorf(
+			"the spec of version %s of component %s cannot be changed because component versions are immutable: "+
+				"set a new version to publish the changed spec",
+			d.Get("version").(string),
+			d.Get("name").(string),
+		)
+	}
+	return d.SetNew("spec_hash", h)
+}
+
+func resourceComponentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	spec, err := readComponentSpec(d.Get("spec").(string), d.Get("spec_path").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	spec["name"] = name
+	spec["version"] = version
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "components", name)
+	if err := ensureAssetContainer(ctx, client.arm, containerPath, &assetContainerResource{}); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create component %s: %w", name, err))
+	}
+
+	component := &componentVersionResource{
+		Properties: componentVersionProperties{
+			Description:   d.Get("description").(string),
+			Tags:          expandStringMap(d.Get("tags").(map[string]interface{})),
+			ComponentSpec: spec,
+		},
+	}
+	created := new(componentVersionResource)
+	if _, err := client.arm.put(ctx, assetVersionPath(containerPath, version), component, created); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(created.Id)
+	return resourceComponentRead(ctx, d, meta)
+}
+
+func resourceComponentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "components", name)
+	component := new(componentVersionResource)
+	if err := client.arm.get(ctx, assetVersionPath(containerPath, version), component); err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading component %s version %s", name, version),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	props := component.Properties
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", props.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	componentType, _ := props.ComponentSpec["type"].(string)
+	if err := d.Set("component_type", componentType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("asset_id", fmt.Sprintf("azureml:%s:%s", name, version)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(component.Id)
+	return diags
+}
+
+func resourceComponentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	if !d.HasChanges("description", "tags") {
+		return resourceComponentRead(ctx, d, meta)
+	}
+
+	// Only the description and the tags of a version can be updated, the spec must be submitted unchanged.
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "components", name)
+	path := assetVersionPath(containerPath, version)
+	component := new(componentVersionResource)
+	if err := client.arm.get(ctx, path, component); err != nil {
+		return diag.FromErr(err)
+	}
+	component.Properties.Description = d.Get("description").(string)
+	component.Properties.Tags = expandStringMap(d.Get("tags").(map[string]interface{}))
+	if _, err := client.arm.put(ctx, path, component, nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceComponentRead(ctx, d, meta)
+}
+
+func resourceComponentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "components", name)
+	err := deleteAssetVersion(ctx, client.arm, containerPath, version)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting component %s version %s.", name, version),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+// readComponentSpec parses the component spec provided inline or, if content is empty, read from the file
+// at the path provided as argument.
+func readComponentSpec(content, path string) (map[string]interface{}, error) {
+	if content == "" && path != "" {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read component spec: %w", err)
+		}
+		content = string(b)
+	}
+	return parseComponentSpec(content)
+}
+
+// parseComponentSpec parses a YAML component spec into the object submitted as componentSpec to ARM.
+func parseComponentSpec(content string) (map[string]interface{}, error) {
+	var spec map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &spec); err != nil {
+		return nil, fmt.Errorf("invalid component spec: %w", err)
+	}
+	if len(spec) == 0 {
+		return nil, fmt.Errorf("invalid component spec: the spec must be a non-empty YAML mapping")
+	}
+	if t, ok := spec["type"].(string); !ok || t == "" {
+		return nil, fmt.Errorf("invalid component spec: %q is required", "type")
+	}
+	return spec, nil
+}
+
+// checkComponentSpecIdentity checks that the name and the version set in the spec, if any, match the ones
+// of the resource.
+func checkComponentSpecIdentity(spec map[string]interface{}, name, version string) error {
+	if v, ok := spec["name"]; ok && fmt.Sprint(v) != name {
+		return fmt.Errorf("the name %q of the component spec does not match the name %q of the resource", v, name)
+	}
+	if v, ok := spec["version"]; ok && fmt.Sprint(v) != version {
+		return fmt.Errorf("the version %q of the component spec does not match the version %q of the resource", v, version)
+	}
+	return nil
+}
+
+// yamlSpecHash returns the hex-encoded SHA-256 hash of the JSON encoding of a spec parsed from YAML, whose
+// keys are sorted, so that the hash does not depend on the formatting of the YAML.
+func yamlSpecHash(spec map[string]interface{}) (string, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("invalid spec: %w", err)
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(b)), nil
+}
+
+// suppressEquivalentComponentSpecDiff suppresses the diff of component specs that differ only by formatting.
+func suppressEquivalentComponentSpecDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldSpec, err := parseComponentSpec(old)
+	if err != nil {
+		return false
+	}
+	newSpec, err := parseComponentSpec(new)
+	if err != nil {
+		return false
+	}
+	oldHash, err := yamlSpecHash(oldSpec)
+	if err != nil {
+		return false
+	}
+	newHash, err := yamlSpecHash(newSpec)
+	return err == nil && oldHash == newHash
+}
+
+type componentVersionResource struct {
+	Id         string                     `json:"id,omitempty"`
+	Name       string                     `json:"name,omitempty"`
+	Properties componentVersionProperties `json:"properties"`
+}
+
+type componentVersionProperties struct {
+	Description   string                 `json:"description,omitempty"`
+	Tags          map[string]string      `json:"tags,omitempty"`
+	IsArchived    bool                   `json:"isArchived,omitempty"`
+	ComponentSpec map[string]interface{} `json:"componentSpec"`
+}
@@ -0,0 +1,71 @@
+package provider
+
+import "testing"
+
+func TestParseComponentSpec(t *testing.T) {
+	invalidSpecs := []string{
+		"",
+		"- type: command",
+		"type: [command",
+		"name: train",
+		"type: \"\"",
+	}
+	for _, spec := range invalidSpecs {
+		if _, err := parseComponentSpec(spec); err == nil {
+			t.Errorf("expected error for spec %q", spec)
+		}
+	}
+
+	spec, err := parseComponentSpec("type: command\ninputs:\n  data:\n    type: uri_folder\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	inputs, ok := spec["inputs"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected nested mapping, got %T", spec["inputs"])
+	}
+	if _, ok := inputs["data"].(map[string]interface{}); !ok {
+		t.Fatalf("expected nested mapping, got %T", inputs["data"])
+	}
+}
+
+func TestComponentSpecHash(t *testing.T) {
+	hashOf := func(content string) string {
+		spec, err := parseComponentSpec(content)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		h, err := yamlSpecHash(spec)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return h
+	}
+
+	base := hashOf("type: command\ncommand: python train.py\ninputs:\n  epochs:\n    type: integer\n")
+	reformatted := hashOf("# Training component\ninputs: {epochs: {type: integer}}\ncommand: 'python train.py'\ntype: command\n")
+	changed := hashOf("type: command\ncommand: python train.py --fast\ninputs:\n  epochs:\n    type: integer\n")
+
+	if base != reformatted {
+		t.Errorf("expected equal hashes for equivalent specs, got %s and %s", base, reformatted)
+	}
+	if base == changed {
+		t.Errorf("expected different hashes for different specs")
+	}
+}
+
+func TestCheckComponentSpecIdentity(t *testing.T) {
+	spec, err := parseComponentSpec("name: train\nversion: 2\ntype: command\n")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := checkComponentSpecIdentity(spec, "train", "2"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := checkComponentSpecIdentity(spec, "train", "3"); err == nil {
+		t.Errorf("expected error for mismatching version")
+	}
+	if err := checkComponentSpecIdentity(spec, "score", "2"); err == nil {
+		t.Errorf("expected error for mismatching name")
+	}
+}
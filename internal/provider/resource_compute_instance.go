@@ -0,0 +1,803 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"time"
+)
+
+var computeInstanceErrorPaths = workspaceErrorPaths(armErrorPaths{armErrorQuota: cty.GetAttrPath("vm_size")})
+
+const (
+	computeInstanceStateRunning = "Running"
+	computeInstanceStateStopped = "Stopped"
+)
+
+func GetAllowedComputeInstanceDesiredStates() []string {
+	return []string{
+		computeInstanceStateRunning,
+		computeInstanceStateStopped,
+	}
+}
+
+func GetAllowedComputeScheduleActions() []string {
+	return []string{
+		"Start",
+		"Stop",
+	}
+}
+
+func GetAllowedScriptSources() []string {
+	return []string{
+		"inline",
+		"workspaceStorage",
+	}
+}
+
+func resourceComputeInstance() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Compute Instance of an Azure ML Workspace.",
+
+		CreateContext: resourceComputeInstanceCreate,
+		ReadContext:   resourceComputeInstanceRead,
+		UpdateContext: resourceComputeInstanceUpdate,
+		DeleteContext: resourceComputeInstanceDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceChild("computes"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the compute instance belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the compute instance belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The name of the compute instance. It must be between 3 and 24 characters long, start " +
+					"with a letter and contain only letters, digits and hyphens.",
+				ForceNew: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9-]{2,23}$"),
+					"must be between 3 and 24 characters long, start with a letter and contain only letters, digits and hyphens",
+				),
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the compute instance.",
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The Azure region in which the compute instance is created. Defaults to the location of " +
+					"the Azure ML Workspace.",
+				ForceNew: true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the compute instance.",
+				ForceNew:    true,
+			},
+			"vm_size": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The size of the virtual machine of the compute instance (e.g. `Standard_DS3_v2`).",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"subnet_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The ID of the subnet in which the compute instance is deployed.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"assigned_user": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				ForceNew:    true,
+				Description: "The user to which the compute instance is assigned. Only the assigned user can access the compute instance.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"object_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The object ID of the Azure Active Directory user.",
+							ForceNew:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+						"tenant_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The ID of the tenant to which the user belongs to.",
+							ForceNew:     true,
+							ValidateFunc: validation.IsUUID,
+						},
+					},
+				},
+			},
+			"ssh": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				ForceNew:    true,
+				Description: "Enables the public SSH access to the compute instance.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"public_key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The SSH public key of the administrator user account.",
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the administrator user account.",
+						},
+						"port": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The port on which the SSH server is listening.",
+						},
+					},
+				},
+			},
+			"creation_script": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				ForceNew:    true,
+				Description: "The script executed once, when the compute instance is created.",
+				Elem:        computeInstanceScriptSchema(),
+			},
+			"startup_script": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				ForceNew:    true,
+				Description: "The script executed every time the compute instance is started.",
+				Elem:        computeInstanceScriptSchema(),
+			},
+			"idle_time_before_shutdown_minutes": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Description: "The number of minutes of inactivity after which the compute instance is shut down. " +
+					"If not specified, the compute instance is never shut down for inactivity.",
+				ValidateFunc: validation.IntBetween(15, 3*24*60),
+			},
+			"schedule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The schedules used for automatically starting and stopping the compute instance.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"action": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: fmt.Sprintf(
+								"The action performed by the schedule. Possible values are: %+q.",
+								GetAllowedComputeScheduleActions(),
+							),
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice(GetAllowedComputeScheduleActions(), false),
+						},
+						"cron_expression": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The cron expression of the schedule (e.g. `0 18 * * 1-5`).",
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"time_zone": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "UTC",
+							Description:  "The time zone in which the cron expression is evaluated.",
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"start_time": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The time from which the schedule is active, in `yyyy-MM-ddTHH:mm:ss` format.",
+							ForceNew:     true,
+							ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}$`), "must be in yyyy-MM-ddTHH:mm:ss format"),
+						},
+						"enabled": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Is the schedule enabled?",
+							ForceNew:    true,
+						},
+					},
+				},
+			},
+			"desired_state": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  computeInstanceStateRunning,
+				Description: fmt.Sprintf(
+					"The state in which the compute instance should be. Possible values are: %+q. Changes to the "+
+						"state performed by the schedules are not reported as drift.",
+					GetAllowedComputeInstanceDesiredStates(),
+				),
+				ValidateFunc: validation.StringInSlice(GetAllowedComputeInstanceDesiredStates(), false),
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The current state of the compute instance.",
+			},
+		},
+	}
+}
+
+func computeInstanceScriptSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "inline",
+				Description: fmt.Sprintf(
+					"The source of the script. Possible values are: %+q.",
+					GetAllowedScriptSources(),
+				),
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(GetAllowedScriptSources(), false),
+			},
+			"data": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The content of the script, base64 encoded, if `source` is `inline`, or the path of the " +
+					"script relative to the workspace file share if `source` is `workspaceStorage`.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"arguments": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The command line arguments passed to the script.",
+				ForceNew:    true,
+			},
+			"timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The maximum execution time of the script (e.g. `5m` or `30s`).",
+				ForceNew:     true,
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^\d+[ms]$`), "must be a number followed by m or s"),
+			},
+		},
+	}
+}
+
+func resourceComputeInstanceCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	location := d.Get("location").(string)
+	if location == "" {
+		ws, err := client.arm.getWorkspace(ctx, resourceGroupName, workspaceName)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("unable to retrieve the location of workspace %s: %w", workspaceName, err))
+		}
+		location = ws.Location
+	}
+
+	compute := resourceComputeInstanceGetResourceData(d, location)
+	path := computePath(client.arm, resourceGroupName, workspaceName, name)
+	resp, err := client.arm.put(ctx, path, compute, nil)
+	if err != nil {
+		return armErrorDiagnostics(fmt.Sprintf("Error creating compute instance %s", name), err, computeInstanceErrorPaths)
+	}
+
+	d.SetId(path)
+	created, err := waitForComputeProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutCreate))
+	if err != nil {
+		return armErrorDiagnostics(fmt.Sprintf("Error waiting for compute instance %s to be created", name), err, computeInstanceErrorPaths)
+	}
+	d.SetId(created.Id)
+
+	if d.Get("desired_state").(string) == computeInstanceStateStopped {
+		if err := changeComputeInstanceState(ctx, client.arm, path, computeInstanceStateStopped, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceComputeInstanceRead(ctx, d, meta)
+}
+
+func resourceComputeInstanceRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	compute := new(computeInstanceResource)
+	err := getProvisioned(ctx, client.arm, computePath(client.arm, resourceGroupName, workspaceName, name), compute)
+	if err != nil {
+		if diags, ok := failedProvisioningDiagnostics(d, fmt.Sprintf("compute instance %s", name), err); ok {
+			return diags
+		}
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading compute instance %s", name),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(compute.Id)
+	return resourceComputeInstanceSetResourceData(d, compute)
+}
+
+func resourceComputeInstanceUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	path := computePath(client.arm, resourceGroupName, workspaceName, name)
+
+	if d.HasChange("idle_time_before_shutdown_minutes") {
+		setting := computeIdleShutdownSetting{}
+		if v := d.Get("idle_time_before_shutdown_minutes").(int); v > 0 {
+			setting.IdleTimeBeforeShutdown = formatIsoDurationMinutes(v)
+		}
+		if _, err := client.arm.post(ctx, path+"/updateIdleShutdownSetting", setting, nil); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("desired_state") {
+		desiredState := d.Get("desired_state").(string)
+		if err := changeComputeInstanceState(ctx, client.arm, path, desiredState, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceComputeInstanceRead(ctx, d, meta)
+}
+
+func resourceComputeInstanceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	path := computePath(client.arm, resourceGroupName, workspaceName, name)
+
+	resp, err := client.arm.delete(ctx, path+"?underlyingResourceAction=Delete")
+	if err == nil {
+		err = waitForDeletion(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutDelete))
+	}
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting compute instance %s.", name),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceComputeInstanceGetResourceData(d *schema.ResourceData, location string) *computeInstanceResource {
+	props := &computeInstanceProperties{
+		VmSize:                           d.Get("vm_size").(string),
+		ApplicationSharingPolicy:         "Shared",
+		ComputeInstanceAuthorizationType: "personal",
+		SshSettings:                      &computeInstanceSshSettings{SshPublicAccess: "Disabled"},
+	}
+
+	if v := d.Get("subnet_id").(string); v != "" {
+		props.Subnet = &armResourceId{Id: v}
+	}
+	if v := d.Get("assigned_user").([]interface{}); len(v) > 0 && v[0] != nil {
+		user := v[0].(map[string]interface{})
+		props.ApplicationSharingPolicy = "Personal"
+		props.PersonalComputeInstanceSettings = &personalComputeInstanceSettings{
+			AssignedUser: &computeInstanceAssignedUser{
+				ObjectId: user["object_id"].(string),
+				TenantId: user["tenant_id"].(string),
+			},
+		}
+	}
+	if v := d.Get("ssh").([]interface{}); len(v) > 0 && v[0] != nil {
+		ssh := v[0].(map[string]interface{})
+		props.SshSettings = &computeInstanceSshSettings{
+			SshPublicAccess: "Enabled",
+			AdminPublicKey:  ssh["public_key"].(string),
+		}
+	}
+
+	creationScript := schemaListToScriptReference(d.Get("creation_script").([]interface{}))
+	startupScript := schemaListToScriptReference(d.Get("startup_script").([]interface{}))
+	if creationScript != nil || startupScript != nil {
+		props.SetupScripts = &computeInstanceSetupScripts{
+			Scripts: &computeInstanceScripts{
+				CreationScript: creationScript,
+				StartupScript:  startupScript,
+			},
+		}
+	}
+
+	if v := d.Get("idle_time_before_shutdown_minutes").(int); v > 0 {
+		props.IdleTimeBeforeShutdown = formatIsoDurationMinutes(v)
+	}
+
+	schedules := d.Get("schedule").([]interface{})
+	if len(schedules) > 0 {
+		props.Schedules = &computeSchedules{ComputeStartStop: make([]computeStartStopSchedule, 0, len(schedules))}
+		for _, s := range schedules {
+			schedule := s.(map[string]interface{})
+			status := "Enabled"
+			if !schedule["enabled"].(bool) {
+				status = "Disabled"
+			}
+			props.Schedules.ComputeStartStop = append(props.Schedules.ComputeStartStop, computeStartStopSchedule{
+				Action:      schedule["action"].(string),
+				TriggerType: "Cron",
+				Status:      status,
+				Cron: &computeCronTrigger{
+					Expression: schedule["cron_expression"].(string),
+					TimeZone:   schedule["time_zone"].(string),
+					StartTime:  schedule["start_time"].(string),
+				},
+			})
+		}
+	}
+
+	return &computeInstanceResource{
+		Location: location,
+		Properties: computeInstanceCompute{
+			ComputeType: "ComputeInstance",
+			Description: d.Get("description").(string),
+			Properties:  props,
+		},
+	}
+}
+
+func resourceComputeInstanceSetResourceData(d *schema.ResourceData, compute *computeInstanceResource) diag.Diagnostics {
+	if err := d.Set("location", compute.Location); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", compute.Properties.Description); err != nil {
+		return diag.FromErr(err)
+	}
+
+	props := compute.Properties.Properties
+	if props == nil {
+		props = new(computeInstanceProperties)
+	}
+	if err := d.Set("vm_size", props.VmSize); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("state", props.State); err != nil {
+		return diag.FromErr(err)
+	}
+
+	subnetId := ""
+	if props.Subnet != nil {
+		subnetId = props.Subnet.Id
+	}
+	if err := d.Set("subnet_id", subnetId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	assignedUser := make([]interface{}, 0)
+	if props.PersonalComputeInstanceSettings != nil && props.PersonalComputeInstanceSettings.AssignedUser != nil {
+		assignedUser = append(assignedUser, map[string]interface{}{
+			"object_id": props.PersonalComputeInstanceSettings.AssignedUser.ObjectId,
+			"tenant_id": props.PersonalComputeInstanceSettings.AssignedUser.TenantId,
+		})
+	}
+	if err := d.Set("assigned_user", assignedUser); err != nil {
+		return diag.FromErr(err)
+	}
+
+	ssh := make([]interface{}, 0)
+	if props.SshSettings != nil && props.SshSettings.SshPublicAccess == "Enabled" {
+		ssh = append(ssh, map[string]interface{}{
+			"public_key": props.SshSettings.AdminPublicKey,
+			"username":   props.SshSettings.AdminUserName,
+			"port":       props.SshSettings.SshPort,
+		})
+	}
+	if err := d.Set("ssh", ssh); err != nil {
+		return diag.FromErr(err)
+	}
+
+	var creationScript, startupScript *computeInstanceScriptReference
+	if props.SetupScripts != nil && props.SetupScripts.Scripts != nil {
+		creationScript = props.SetupScripts.Scripts.CreationScript
+		startupScript = props.SetupScripts.Scripts.StartupScript
+	}
+	if err := d.Set("creation_script", scriptReferenceToSchemaList(creationScript)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("startup_script", scriptReferenceToSchemaList(startupScript)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	idleMinutes := 0
+	if props.IdleTimeBeforeShutdown != "" {
+		v, err := parseIsoDurationMinutes(props.IdleTimeBeforeShutdown)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		idleMinutes = v
+	}
+	if err := d.Set("idle_time_before_shutdown_minutes", idleMinutes); err != nil {
+		return diag.FromErr(err)
+	}
+
+	schedules := make([]interface{}, 0)
+	if props.Schedules != nil {
+		for _, s := range props.Schedules.ComputeStartStop {
+			if s.Cron == nil {
+				continue
+			}
+			schedules = append(schedules, map[string]interface{}{
+				"action":          s.Action,
+				"cron_expression": s.Cron.Expression,
+				"time_zone":       s.Cron.TimeZone,
+				"start_time":      s.Cron.StartTime,
+				"enabled":         s.Status != "Disabled",
+			})
+		}
+	}
+	if err := d.Set("schedule", schedules); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The desired state is not refreshed, otherwise the changes performed by the schedules would be
+	// reported as drift. It is initialized from the actual state only when missing (e.g. after an import).
+	if d.Get("desired_state").(string) == "" && contains(GetAllowedComputeInstanceDesiredStates(), props.State) {
+		if err := d.Set("desired_state", props.State); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func schemaListToScriptReference(l []interface{}) *computeInstanceScriptReference {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	data := l[0].(map[string]interface{})
+	return &computeInstanceScriptReference{
+		ScriptSource:    data["source"].(string),
+		ScriptData:      data["data"].(string),
+		ScriptArguments: data["arguments"].(string),
+		Timeout:         data["timeout"].(string),
+	}
+}
+
+func scriptReferenceToSchemaList(script *computeInstanceScriptReference) []interface{} {
+	if script == nil {
+		return make([]interface{}, 0)
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"source":    script.ScriptSource,
+			"data":      script.ScriptData,
+			"arguments": script.ScriptArguments,
+			"timeout":   script.Timeout,
+		},
+	}
+}
+
+func computePath(c *armClient, resourceGroupName, workspaceName, name string) string {
+	return fmt.Sprintf("%s/computes/%s", c.workspaceId(resourceGroupName, workspaceName), name)
+}
+
+// changeComputeInstanceState starts or stops the compute instance at the path provided as argument and
+// waits until it reaches the desired state.
+func changeComputeInstanceState(ctx context.Context, c *armClient, path, desiredState string, timeout time.Duration) error {
+	action := "start"
+	if desiredState == computeInstanceStateStopped {
+		action = "stop"
+	}
+
+	resp, err := c.post(ctx, fmt.Sprintf("%s/%s", path, action), nil, nil)
+	if err != nil {
+		return fmt.Errorf("unable to %s the compute instance: %w", action, err)
+	}
+
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	err = waitForOperation(ctx, c, resp, 0)
+	if err == nil {
+		err = pollUntilDone(ctx, "the compute instance to be "+desiredState, 0, func(ctx context.Context) (bool, time.Duration, error) {
+			compute := new(computeInstanceResource)
+			if err := c.get(ctx, path, compute); err != nil {
+				return false, 0, err
+			}
+			if compute.Properties.Properties == nil {
+				return false, 0, nil
+			}
+			return compute.Properties.Properties.State == desiredState, 0, nil
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("error waiting for the compute instance to be %s: %w", desiredState, err)
+	}
+	return nil
+}
+
+// waitForComputeProvisioning waits until the provisioning of the compute at the path provided as argument
+// is completed, following first the long-running operation started by the request whose response is provided
+// as argument, and returns the provisioned compute.
+func waitForComputeProvisioning(ctx context.Context, c *armClient, resp *armResponse, path string, timeout time.Duration) (*computeInstanceResource, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := waitForOperation(ctx, c, resp, 0); err != nil {
+		return nil, err
+	}
+	compute := new(computeInstanceResource)
+	err := pollUntilDone(ctx, "the provisioning of "+path, 0, func(ctx context.Context) (bool, time.Duration, error) {
+		if err := c.get(ctx, path, compute); err != nil {
+			return false, 0, err
+		}
+		if compute.Properties.ProvisioningState == "Failed" {
+			return false, 0, fmt.Errorf("provisioning failed: %s", compute.Properties.provisioningErrorsMessage())
+		}
+		return compute.Properties.ProvisioningState == "Succeeded", 0, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return compute, nil
+}
+
+type computeInstanceResource struct {
+	Id         string                 `json:"id,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Location   string                 `json:"location,omitempty"`
+	Properties computeInstanceCompute `json:"properties"`
+}
+
+type computeInstanceCompute struct {
+	ComputeType        string                     `json:"computeType"`
+	Description        string                     `json:"description,omitempty"`
+	ProvisioningState  string                     `json:"provisioningState,omitempty"`
+	ProvisioningErrors []computeProvisioningError `json:"provisioningErrors,omitempty"`
+	Properties         *computeInstanceProperties `json:"properties,omitempty"`
+}
+
+type computeProvisioningError struct {
+	Error struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func (c computeInstanceCompute) provisioningErrorsMessage() string {
+	if len(c.ProvisioningErrors) == 0 {
+		return "no error details available"
+	}
+	var message string
+	for i, e := range c.ProvisioningErrors {
+		if i > 0 {
+			message += "; "
+		}
+		message += fmt.Sprintf("%s: %s", e.Error.Code, e.Error.Message)
+	}
+	return message
+}
+
+type computeInstanceProperties struct {
+	VmSize                           string                           `json:"vmSize,omitempty"`
+	Subnet                           *armResourceId                   `json:"subnet,omitempty"`
+	ApplicationSharingPolicy         string                           `json:"applicationSharingPolicy,omitempty"`
+	SshSettings                      *computeInstanceSshSettings      `json:"sshSettings,omitempty"`
+	ComputeInstanceAuthorizationType string                           `json:"computeInstanceAuthorizationType,omitempty"`
+	PersonalComputeInstanceSettings  *personalComputeInstanceSettings `json:"personalComputeInstanceSettings,omitempty"`
+	SetupScripts                     *computeInstanceSetupScripts     `json:"setupScripts,omitempty"`
+	IdleTimeBeforeShutdown           string                           `json:"idleTimeBeforeShutdown,omitempty"`
+	Schedules                        *computeSchedules                `json:"schedules,omitempty"`
+	State                            string                           `json:"state,omitempty"`
+}
+
+type computeInstanceSshSettings struct {
+	SshPublicAccess string `json:"sshPublicAccess,omitempty"`
+	AdminPublicKey  string `json:"adminPublicKey,omitempty"`
+	AdminUserName   string `json:"adminUserName,omitempty"`
+	SshPort         int    `json:"sshPort,omitempty"`
+}
+
+type personalComputeInstanceSettings struct {
+	AssignedUser *computeInstanceAssignedUser `json:"assignedUser,omitempty"`
+}
+
+type computeInstanceAssignedUser struct {
+	ObjectId string `json:"objectId"`
+	TenantId string `json:"tenantId"`
+}
+
+type computeInstanceSetupScripts struct {
+	Scripts *computeInstanceScripts `json:"scripts,omitempty"`
+}
+
+type computeInstanceScripts struct {
+	CreationScript *computeInstanceScriptReference `json:"creationScript,omitempty"`
+	StartupScript  *computeInstanceScriptReference `json:"startupScript,omitempty"`
+}
+
+type computeInstanceScriptReference struct {
+	ScriptSource    string `json:"scriptSource,omitempty"`
+	ScriptData      string `json:"scriptData,omitempty"`
+	ScriptArguments string `json:"scriptArguments,omitempty"`
+	Timeout         string `json:"timeout,omitempty"`
+}
+
+type computeSchedules struct {
+	ComputeStartStop []computeStartStopSchedule `json:"computeStartStop"`
+}
+
+type computeStartStopSchedule struct {
+	Action      string              `json:"action"`
+	TriggerType string              `json:"triggerType"`
+	Status      string              `json:"status,omitempty"`
+	Cron        *computeCronTrigger `json:"cron,omitempty"`
+}
+
+type computeCronTrigger struct {
+	Expression string `json:"expression"`
+	TimeZone   string `json:"timeZone,omitempty"`
+	StartTime  string `json:"startTime,omitempty"`
+}
+
+type computeIdleShutdownSetting struct {
+	IdleTimeBeforeShutdown string `json:"idleTimeBeforeShutdown,omitempty"`
+}
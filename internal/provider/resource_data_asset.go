@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func GetAllowedDataTypes() []string {
+	return []string{
+		"uri_file",
+		"uri_folder",
+		"mltable",
+	}
+}
+
+func resourceDataAsset() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a version of a Data Asset of an Azure ML Workspace. Azure ML does not support the " +
+			"deletion of data versions: destroying this resource archives the version.",
+
+		CreateContext: resourceDataAssetCreate,
+		ReadContext:   resourceDataAssetRead,
+		UpdateContext: resourceDataAssetUpdate,
+		DeleteContext: resourceDataAssetDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceAssetVersion("data"),
+		},
+
+		CustomizeDiff: customizeDiffAssetVersion,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the data asset belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the data asset belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the data asset.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The version of the data asset. Required unless `auto_increment` is `true`, in which case " +
+					"it is assigned by Azure ML.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"auto_increment": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Should the version be assigned automatically, incrementing the latest version of the data " +
+					"asset? When `true`, any change to the immutable arguments registers a new version.",
+				ForceNew: true,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the data asset version.",
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"The type of the data asset. Possible values are: %+q.",
+					GetAllowedDataTypes(),
+				),
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(GetAllowedDataTypes(), false),
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The URI of the data. Paths of the datastores of the workspace can be expressed in the " +
+					"`azureml://datastores/<datastore>/paths/<path>` form.",
+				ForceNew:         true,
+				ValidateFunc:     IsValidDataPath,
+				DiffSuppressFunc: suppressEquivalentDatastoreUriDiff("workspace_name"),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the data asset version.",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the data asset version.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"is_archived": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Is the data asset version archived?",
+			},
+			"asset_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reference to the data asset version in the `azureml:<name>:<version>` form used by jobs.",
+			},
+		},
+	}
+}
+
+func resourceDataAssetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "data", name)
+	container := &assetContainerResource{Properties: assetContainerProperties{DataType: d.Get("type").(string)}}
+	if err := ensureAssetContainer(ctx, client.arm, containerPath, container); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create data asset %s: %w", name, err))
+	}
+
+	version := d.Get("version").(string)
+	if d.Get("auto_increment").(bool) {
+		v, err := nextAssetVersion(ctx, client.arm, containerPath)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		version = v
+	}
+
+	created := new(dataVersionResource)
+	if _, err := client.arm.put(ctx, assetVersionPath(containerPath, version), resourceDataAssetGetResourceData(d), created); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(created.Id)
+	if err := d.Set("version", version); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceDataAssetRead(ctx, d, meta)
+}
+
+func resourceDataAssetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "data", name)
+	data := new(dataVersionResource)
+	err := client.arm.get(ctx, assetVersionPath(containerPath, version), data)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading data asset %s version %s", name, version),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(data.Id)
+	return resourceDataAssetSetResourceData(d, data)
+}
+
+func resourceDataAssetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	// Only the mutable properties differ from the registered version, hence the whole version can be submitted.
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "data", name)
+	if _, err := client.arm.put(ctx, assetVersionPath(containerPath, version), resourceDataAssetGetResourceData(d), nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDataAssetRead(ctx, d, meta)
+}
+
+func resourceDataAssetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, workspaceName, "data", name)
+	err := archiveAssetVersion(ctx, client.arm, assetVersionPath(containerPath, version))
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error archiving data asset %s version %s.", name, version),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceDataAssetGetResourceData(d *schema.ResourceData) *dataVersionResource {
+	return &dataVersionResource{
+		Properties: dataVersionProperties{
+			DataType:    d.Get("type").(string),
+			DataUri:     d.Get("path").(string),
+			Description: d.Get("description").(string),
+			Tags:        expandStringMap(d.Get("tags").(map[string]interface{})),
+			IsArchived:  d.Get("is_archived").(bool),
+		},
+	}
+}
+
+func resourceDataAssetSetResourceData(d *schema.ResourceData, data *dataVersionResource) diag.Diagnostics {
+	props := data.Properties
+	if err := d.Set("type", props.DataType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("path", props.DataUri); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", props.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_archived", props.IsArchived); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("asset_id", fmt.Sprintf("azureml:%s:%s", d.Get("name").(string), d.Get("version").(string))); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+type dataVersionResource struct {
+	Id         string                `json:"id,omitempty"`
+	Name       string                `json:"name,omitempty"`
+	Properties dataVersionProperties `json:"properties"`
+}
+
+type dataVersionProperties struct {
+	DataType    string            `json:"dataType"`
+	DataUri     string            `json:"dataUri"`
+	Description string            `json:"description,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	IsArchived  bool              `json:"isArchived"`
+}
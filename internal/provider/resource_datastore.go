@@ -2,176 +2,275 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/orobix/azureml-go-sdk/workspace"
+	"strings"
 	"time"
 )
 
-func resourceDatastore() *schema.Resource {
-	return &schema.Resource{
-		Description: "Manages a Datastore.",
+// datastoreErrorPaths are the arguments of the datastores causing the ARM errors.
+var datastoreErrorPaths = workspaceErrorPaths(armErrorPaths{armErrorStorageAccess: cty.GetAttrPath("auth")})
 
-		CreateContext: resourceDatastoreCreate,
-		ReadContext:   resourceDatastoreRead,
-		UpdateContext: resourceDatastoreUpdate,
-		DeleteContext: resourceDatastoreDelete,
+var (
+	_ resource.ResourceWithConfigure      = &datastoreResource{}
+	_ resource.ResourceWithImportState    = &datastoreResource{}
+	_ resource.ResourceWithMoveState      = &datastoreResource{}
+	_ resource.ResourceWithUpgradeState   = &datastoreResource{}
+	_ resource.ResourceWithValidateConfig = &datastoreResource{}
+)
 
-		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
-		},
+// datastoreResource manages a datastore of an Azure ML Workspace. Version 0 of its schema is the one of the
+// SDKv2 implementation, in which auth was a set containing a single block whose hash changed with any of its
+// values.
+type datastoreResource struct {
+	client *apiClient
+}
+
+type datastoreResourceModel struct {
+	ResourceGroupName    types.String `tfsdk:"resource_group_name"`
+	WorkspaceName        types.String `tfsdk:"workspace_name"`
+	Name                 types.String `tfsdk:"name"`
+	Id                   types.String `tfsdk:"id"`
+	Description          types.String `tfsdk:"description"`
+	IsDefault            types.Bool   `tfsdk:"is_default"`
+	StorageType          types.String `tfsdk:"storage_type"`
+	StorageAccountName   types.String `tfsdk:"storage_account_name"`
+	StorageContainerName types.String `tfsdk:"storage_container_name"`
+	CreationDate         types.String `tfsdk:"creation_date"`
+	CreationUser         types.String `tfsdk:"creation_user"`
+	CreationUserType     types.String `tfsdk:"creation_user_type"`
+	LastModifiedDate     types.String `tfsdk:"last_modified_date"`
+	LastModifiedUser     types.String `tfsdk:"last_modified_user"`
+	LastModifiedUserType types.String `tfsdk:"last_modified_user_type"`
+	Auth                 types.Object `tfsdk:"auth"`
+}
+
+type datastoreAuthModel struct {
+	CredentialsType types.String `tfsdk:"credentials_type"`
+	TenantId        types.String `tfsdk:"tenant_id"`
+	ClientId        types.String `tfsdk:"client_id"`
+	ClientSecret    types.String `tfsdk:"client_secret"`
+	AccountKey      types.String `tfsdk:"account_key"`
+	SqlUserName     types.String `tfsdk:"sql_user_name"`
+	SqlUserPassword types.String `tfsdk:"sql_user_password"`
+
+	// The write-only arguments are null in the plan and in the state, and are read from the configuration
+	ClientSecretWo           types.String `tfsdk:"client_secret_wo"`
+	ClientSecretWoVersion    types.Int64  `tfsdk:"client_secret_wo_version"`
+	AccountKeyWo             types.String `tfsdk:"account_key_wo"`
+	AccountKeyWoVersion      types.Int64  `tfsdk:"account_key_wo_version"`
+	SqlUserPasswordWo        types.String `tfsdk:"sql_user_password_wo"`
+	SqlUserPasswordWoVersion types.Int64  `tfsdk:"sql_user_password_wo_version"`
+}
+
+var datastoreAuthAttributeTypes = map[string]attr.Type{
+	"credentials_type":  types.StringType,
+	"tenant_id":         types.StringType,
+	"client_id":         types.StringType,
+	"client_secret":     types.StringType,
+	"account_key":       types.StringType,
+	"sql_user_name":     types.StringType,
+	"sql_user_password": types.StringType,
+
+	"client_secret_wo":             types.StringType,
+	"client_secret_wo_version":     types.Int64Type,
+	"account_key_wo":               types.StringType,
+	"account_key_wo_version":       types.Int64Type,
+	"sql_user_password_wo":         types.StringType,
+	"sql_user_password_wo_version": types.Int64Type,
+}
+
+// datastoreAuthWriteOnlySecrets are the secrets of the credentials that can be set through a write-only argument.
+var datastoreAuthWriteOnlySecrets = []string{"client_secret", "account_key", "sql_user_password"}
+
+func newDatastoreResource() resource.Resource {
+	return &datastoreResource{}
+}
+
+func (r *datastoreResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_datastore"
+}
 
-		Schema: map[string]*schema.Schema{
-			"resource_group_name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The name of the resource group of the Azure ML Workspace to which the datastore belongs to.",
-				ForceNew:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
+func (r *datastoreResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a Datastore.",
+		Version:             1,
+		Attributes: map[string]schema.Attribute{
+			"resource_group_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the resource group of the Azure ML Workspace to which the datastore belongs to.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{StringNotEmptyValidator{}},
 			},
-			"workspace_name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The name of the Azure ML Workspace to which the datastore belongs to.",
-				ForceNew:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
+			"workspace_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the Azure ML Workspace to which the datastore belongs to.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{StringNotEmptyValidator{}},
 			},
-			"name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "The name of the datastore.",
-				ForceNew:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "The name of the datastore.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{StringNotEmptyValidator{}},
 			},
-			"id": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The ID of the datastore.",
-				ForceNew:    true,
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of the datastore.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"description": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "",
-				Description: "The description of the datastore.",
+			"description": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(""),
+				MarkdownDescription: "The description of the datastore.",
 			},
-			"is_default": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Is the datastore the default datastore of the Azure ML Workspace?",
+			"is_default": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+				MarkdownDescription: "Is the datastore the default datastore of the Azure ML Workspace?",
 			},
-			"storage_type": {
-				Type:     schema.TypeString,
+			"storage_type": schema.StringAttribute{
 				Required: true,
-				Description: fmt.Sprintf(
-					"The type of the storage to which the datstore is linked to. Possible values are: %+q",
+				MarkdownDescription: fmt.Sprintf(
+					"The type of the storage to which the datastore is linked to. Possible values are: %+q.",
 					GetAllowedStorageTypes(),
 				),
-				ForceNew:     true,
-				ValidateFunc: IsValidStorageType,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:    []validator.String{NewStorageTypeValidator()},
 			},
-			"storage_account_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the Storage Account to which the datastore is linked to.",
-				ForceNew:     true,
-				ValidateFunc: IsValidStorageAccountName,
+			"storage_account_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the Storage Account to which the datastore is linked to.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{StorageAccountNameValidator{}},
 			},
-			"storage_container_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "The name of the Storage Container to which the datastore is linked to.",
-				ForceNew:     true,
-				ValidateFunc: validation.StringIsNotEmpty,
+			"storage_container_name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "The name of the Storage Container to which the datastore is linked to.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.RequiresReplace()},
+				Validators:          []validator.String{StringNotEmptyValidator{}},
 			},
-			"creation_date": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The timestamp corresponding to the creation of the datastore.",
+			"creation_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp corresponding to the creation of the datastore.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"creation_user": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The user that created the datastore.",
+			"creation_user": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The user that created the datastore.",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"creation_user_type": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The kind of user that created the datastore (Service Principal or User).",
+			"creation_user_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The kind of user that created the datastore (Service Principal or User).",
+				PlanModifiers:       []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
 			},
-			"last_modified_date": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The timestamp corresponding to the last update of the datastore.",
+			"last_modified_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The timestamp corresponding to the last update of the datastore.",
 			},
-			"last_modified_user": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The user that last updated the datastore.",
+			"last_modified_user": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The user that last updated the datastore.",
 			},
-			"last_modified_user_type": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "The kind of user that last updated the datastore (Service Principal or User).",
+			"last_modified_user_type": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The kind of user that last updated the datastore (Service Principal or User).",
 			},
-			"auth": {
-				Type:     schema.TypeSet,
-				MaxItems: 1,
-				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"credentials_type": {
-							Type:     schema.TypeString,
-							Required: true,
-							Description: fmt.Sprintf(
-								"The type of credentials used for authenticating with the underlying storage. Possible values are: %+q.",
-								GetAllowedCredentialTypes(),
-							),
-							ForceNew:     true,
-							ValidateFunc: IsValidCredentialsType,
-						},
-						"tenant_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Description: "The ID of the tenant to which the Service Principal used for authenticating " +
-								"belongs to.",
-						},
-						"client_id": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Description: "The application ID of the service principal used for authenticating with the " +
-								"underlying storage of the datastore.",
-						},
-						"client_secret": {
-							Type:      schema.TypeString,
-							Optional:  true,
-							Sensitive: true,
-							Description: "The client secret of the service principal used for authenticating with the " +
-								"underlying storage of the datastore.",
-						},
-						"account_key": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Sensitive:   true,
-							Description: "The primary key of the Storage Account linked to the datastore.",
-						},
-						"sql_user_name": {
-							Type:     schema.TypeString,
-							Optional: true,
-							Description: "The username of the identity used for authenticating with the SQL database linked " +
-								"to the storage account.",
-						},
-						"sql_user_password": {
-							Type:      schema.TypeString,
-							Optional:  true,
-							Sensitive: true,
-							Description: "The password of the identity used for authenticating with the SQL database linked " +
-								"to the storage account.",
-						},
+			"auth": schema.SingleNestedAttribute{
+				Required:            true,
+				MarkdownDescription: "The credentials used by Azure ML for authenticating with the underlying storage.",
+				Attributes: map[string]schema.Attribute{
+					"credentials_type": schema.StringAttribute{
+						Required: true,
+						MarkdownDescription: fmt.Sprintf(
+							"The type of credentials used for authenticating with the underlying storage. Possible values are: %+q.",
+							GetAllowedCredentialTypes(),
+						),
+						PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()},
+						Validators:    []validator.String{NewDatastoreCredentialsTypeValidator()},
+					},
+					"tenant_id": schema.StringAttribute{
+						Optional: true,
+						MarkdownDescription: "The ID of the tenant to which the Service Principal used for authenticating " +
+							"belongs to.",
+					},
+					"client_id": schema.StringAttribute{
+						Optional: true,
+						MarkdownDescription: "The application ID of the service principal used for authenticating with the " +
+							"underlying storage of the datastore.",
+					},
+					"client_secret": schema.StringAttribute{
+						Optional:  true,
+						Sensitive: true,
+						MarkdownDescription: "The client secret of the service principal used for authenticating with the " +
+							"underlying storage of the datastore.",
+					},
+					"account_key": schema.StringAttribute{
+						Optional:            true,
+						Sensitive:           true,
+						MarkdownDescription: "The primary key of the Storage Account linked to the datastore.",
+					},
+					"sql_user_name": schema.StringAttribute{
+						Optional: true,
+						MarkdownDescription: "The username of the identity used for authenticating with the SQL database linked " +
+							"to the storage account.",
+					},
+					"sql_user_password": schema.StringAttribute{
+						Optional:  true,
+						Sensitive: true,
+						MarkdownDescription: "The password of the identity used for authenticating with the SQL database linked " +
+							"to the storage account.",
+					},
+					"client_secret_wo": schema.StringAttribute{
+						Optional:  true,
+						WriteOnly: true,
+						MarkdownDescription: "Write-only alternative to `client_secret`, never stored in the state. Requires " +
+							"Terraform 1.11 or later.",
+					},
+					"client_secret_wo_version": schema.Int64Attribute{
+						Optional: true,
+						MarkdownDescription: "The version of `client_secret_wo`. Since write-only arguments are not stored in " +
+							"the state, the secret is submitted again only when the version changes.",
+					},
+					"account_key_wo": schema.StringAttribute{
+						Optional:  true,
+						WriteOnly: true,
+						MarkdownDescription: "Write-only alternative to `account_key`, never stored in the state. Requires " +
+							"Terraform 1.11 or later.",
+					},
+					"account_key_wo_version": schema.Int64Attribute{
+						Optional: true,
+						MarkdownDescription: "The version of `account_key_wo`. Since write-only arguments are not stored in " +
+							"the state, the key is submitted again only when the version changes.",
+					},
+					"sql_user_password_wo": schema.StringAttribute{
+						Optional:  true,
+						WriteOnly: true,
+						MarkdownDescription: "Write-only alternative to `sql_user_password`, never stored in the state. " +
+							"Requires Terraform 1.11 or later.",
+					},
+					"sql_user_password_wo_version": schema.Int64Attribute{
+						Optional: true,
+						MarkdownDescription: "The version of `sql_user_password_wo`. Since write-only arguments are not " +
+							"stored in the state, the password is submitted again only when the version changes.",
 					},
 				},
 			},
@@ -179,198 +278,556 @@ func resourceDatastore() *schema.Resource {
 	}
 }
 
-func resourceDatastoreCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*apiClient)
-	resourceGroupName := d.Get("resource_group_name").(string)
-	workspaceName := d.Get("workspace_name").(string)
+func (r *datastoreResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	client, ok := req.ProviderData.(*apiClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected provider data",
+			fmt.Sprintf("Expected *apiClient, got %T.", req.ProviderData),
+		)
+		return
+	}
+	r.client = client
+}
+
+func (r *datastoreResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	resp.Diagnostics.Append(configuredClientDiagnostics(r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	datastore, err := resourceDatastoreGetResourceData(d)
-	if err != nil {
-		return diag.FromErr(err)
+	var data datastoreResourceModel
+	var config datastoreResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	createdDatastore, err := client.ws.CreateOrUpdateDatastore(resourceGroupName, workspaceName, datastore)
+	datastore, diags := data.toDatastore(ctx, config.Auth)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createdDatastore, err := createOrUpdateDatastore(
+		ctx,
+		r.client.arm,
+		data.ResourceGroupName.ValueString(),
+		data.WorkspaceName.ValueString(),
+		datastore,
+	)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(armErrorFrameworkDiagnostics(
+			fmt.Sprintf("Error creating datastore %s", datastore.Name),
+			err,
+			datastoreErrorPaths,
+		)...)
+		return
 	}
 
-	d.SetId(createdDatastore.Id)
-	return resourceDatastoreSetResourceData(d, createdDatastore)
+	resp.Diagnostics.Append(data.setDatastore(createdDatastore)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func resourceDatastoreRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := meta.(*apiClient)
-	resourceGroupName := d.Get("resource_group_name").(string)
-	workspaceName := d.Get("workspace_name").(string)
-	datastoreName := d.Get("name").(string)
+func (r *datastoreResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	resp.Diagnostics.Append(configuredClientDiagnostics(r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	ds, err := client.ws.GetDatastore(resourceGroupName, workspaceName, datastoreName)
+	var data datastoreResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	datastoreName := data.Name.ValueString()
+	ds, err := getDatastore(ctx, r.client.arm, data.ResourceGroupName.ValueString(), data.WorkspaceName.ValueString(), datastoreName)
 	if err != nil {
-		var notFoundErr *workspace.ResourceNotFoundError
+		var notFoundErr *resourceNotFoundError
 		if errors.As(err, &notFoundErr) {
-			d.SetId("")
-		} else {
-			diags = append(diags, diag.Diagnostic{
-				Severity: diag.Error,
-				Summary:  fmt.Sprintf("Error reading datastore %s", datastoreName),
-				Detail:   err.Error(),
-			})
+			resp.State.RemoveResource(ctx)
+			return
 		}
-		return diags
+		resp.Diagnostics.Append(armErrorFrameworkDiagnostics(
+			fmt.Sprintf("Error reading datastore %s", datastoreName),
+			err,
+			datastoreErrorPaths,
+		)...)
+		return
 	}
 
-	d.SetId(ds.Id)
-	return resourceDatastoreSetResourceData(d, ds)
+	resp.Diagnostics.Append(data.setDatastore(ds)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func resourceDatastoreUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	client := meta.(*apiClient)
-	resourceGroupName := d.Get("resource_group_name").(string)
-	workspaceName := d.Get("workspace_name").(string)
+func (r *datastoreResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.Append(configuredClientDiagnostics(r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data datastoreResourceModel
+	var config datastoreResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	datastore, err := resourceDatastoreGetResourceData(d)
-	if err != nil {
-		return diag.FromErr(err)
+	datastore, diags := data.toDatastore(ctx, config.Auth)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	createdDatastore, err := client.ws.CreateOrUpdateDatastore(resourceGroupName, workspaceName, datastore)
+	updatedDatastore, err := createOrUpdateDatastore(
+		ctx,
+		r.client.arm,
+		data.ResourceGroupName.ValueString(),
+		data.WorkspaceName.ValueString(),
+		datastore,
+	)
 	if err != nil {
-		return diag.FromErr(err)
+		resp.Diagnostics.Append(armErrorFrameworkDiagnostics(
+			fmt.Sprintf("Error updating datastore %s", datastore.Name),
+			err,
+			datastoreErrorPaths,
+		)...)
+		return
 	}
 
-	d.SetId(createdDatastore.Id)
-	return resourceDatastoreSetResourceData(d, createdDatastore)
+	resp.Diagnostics.Append(data.setDatastore(updatedDatastore)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func resourceDatastoreDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	var diags diag.Diagnostics
-	client := meta.(*apiClient)
-	resourceGroupName := d.Get("resource_group_name").(string)
-	workspaceName := d.Get("workspace_name").(string)
-	datastoreName := d.Get("name").(string)
+func (r *datastoreResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.Append(configuredClientDiagnostics(r.client)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var data datastoreResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	err := client.ws.DeleteDatastore(resourceGroupName, workspaceName, datastoreName)
+	datastoreName := data.Name.ValueString()
+	err := deleteDatastore(ctx, r.client.arm, data.ResourceGroupName.ValueString(), data.WorkspaceName.ValueString(), datastoreName)
 	if err != nil {
-		diags = append(diags, diag.Diagnostic{
-			Severity: diag.Error,
-			Summary:  fmt.Sprintf("Error deleting datastore %s.", datastoreName),
-			Detail:   err.Error(),
-		})
+		resp.Diagnostics.Append(armErrorFrameworkDiagnostics(
+			fmt.Sprintf("Error deleting datastore %s.", datastoreName),
+			err,
+			datastoreErrorPaths,
+		)...)
 	}
-	return diags
 }
 
-func resourceDatastoreGetResourceData(d *schema.ResourceData) (*workspace.Datastore, error) {
-	var creationDate time.Time
-	var lastModifiedDate time.Time
-	var err error
+func (r *datastoreResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resourceGroupName, workspaceName, name, err := parseWorkspaceChildId(req.ID, "datastores")
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid datastore ID", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("resource_group_name"), resourceGroupName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("workspace_name"), workspaceName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
 
-	if d.Get("creation_date") != "" {
-		creationDate, err = time.Parse(defaultDateFormat, d.Get("creation_date").(string))
-		if err != nil {
-			return nil, err
-		}
+// ValidateConfig checks that each secret of the credentials is set either through its argument or through its
+// write-only argument.
+func (r *datastoreResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var auth types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth"), &auth)...)
+	if resp.Diagnostics.HasError() || auth.IsNull() || auth.IsUnknown() {
+		return
 	}
-	if d.Get("last_modified_date") != "" {
-		lastModifiedDate, err = time.Parse(defaultDateFormat, d.Get("last_modified_date").(string))
-		if err != nil {
-			return nil, err
+	attributes := auth.Attributes()
+	for _, name := range datastoreAuthWriteOnlySecrets {
+		if !attributes[name].IsNull() && !attributes[name+"_wo"].IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("auth").AtName(name+"_wo"),
+				"Conflicting credentials",
+				fmt.Sprintf("Only one of auth.%s and auth.%s_wo can be set.", name, name),
+			)
 		}
 	}
+}
 
-	auth, err := schemaSetToDatastoreAuth(d.Get("auth").(*schema.Set))
-	if err != nil {
-		return nil, err
+// datastoreStateV0 is the state of the datastores written by the SDKv2 implementation of the resource, released
+// up to version 0.0.5. The auth set always contains a single element.
+type datastoreStateV0 struct {
+	ResourceGroupName    string `json:"resource_group_name"`
+	WorkspaceName        string `json:"workspace_name"`
+	Name                 string `json:"name"`
+	Id                   string `json:"id"`
+	Description          string `json:"description"`
+	IsDefault            bool   `json:"is_default"`
+	StorageType          string `json:"storage_type"`
+	StorageAccountName   string `json:"storage_account_name"`
+	StorageContainerName string `json:"storage_container_name"`
+	CreationDate         string `json:"creation_date"`
+	CreationUser         string `json:"creation_user"`
+	CreationUserType     string `json:"creation_user_type"`
+	LastModifiedDate     string `json:"last_modified_date"`
+	LastModifiedUser     string `json:"last_modified_user"`
+	LastModifiedUserType string `json:"last_modified_user_type"`
+	Auth                 []struct {
+		CredentialsType string `json:"credentials_type"`
+		TenantId        string `json:"tenant_id"`
+		ClientId        string `json:"client_id"`
+		ClientSecret    string `json:"client_secret"`
+		AccountKey      string `json:"account_key"`
+		SqlUserName     string `json:"sql_user_name"`
+		SqlUserPassword string `json:"sql_user_password"`
+	} `json:"auth"`
+}
+
+func (r *datastoreResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		// The raw state is decoded instead of being read through a prior schema, so that the state written by
+		// any SDKv2 release is upgraded regardless of the attributes it contains.
+		0: {StateUpgrader: upgradeDatastoreStateV0},
 	}
+}
 
-	return &workspace.Datastore{
-		Id:                   d.Get("id").(string),
-		Name:                 d.Get("name").(string),
-		IsDefault:            d.Get("is_default").(bool),
-		Description:          d.Get("description").(string),
-		StorageType:          d.Get("storage_type").(string),
-		StorageAccountName:   d.Get("storage_account_name").(string),
-		StorageContainerName: d.Get("storage_container_name").(string),
-		SystemData: &workspace.SystemData{
-			CreationDate:         creationDate,
-			CreationUser:         d.Get("creation_user").(string),
-			CreationUserType:     d.Get("creation_user_type").(string),
-			LastModifiedDate:     lastModifiedDate,
-			LastModifiedUser:     d.Get("last_modified_user").(string),
-			LastModifiedUserType: d.Get("last_modified_user_type").(string),
-		},
-		Auth: auth,
-	}, nil
+// upgradeDatastoreStateV0 converts the state written by the SDKv2 implementation of the resource, replacing the
+// auth set with a single object and keeping its secrets. SDKv2 stored the unset optional strings as empty strings,
+// which are converted to null values so that the configurations not setting them do not plan the replacement of
+// the datastore.
+func upgradeDatastoreStateV0(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError(
+			"Unable to upgrade the state of the datastore",
+			"The state does not contain the attributes of the datastore in JSON format.",
+		)
+		return
+	}
+	var prior datastoreStateV0
+	if err := json.Unmarshal(req.RawState.JSON, &prior); err != nil {
+		resp.Diagnostics.AddError("Unable to upgrade the state of the datastore", err.Error())
+		return
+	}
+
+	data := datastoreResourceModel{
+		ResourceGroupName:    types.StringValue(prior.ResourceGroupName),
+		WorkspaceName:        types.StringValue(prior.WorkspaceName),
+		Name:                 types.StringValue(prior.Name),
+		Id:                   types.StringValue(prior.Id),
+		Description:          types.StringValue(prior.Description),
+		IsDefault:            types.BoolValue(prior.IsDefault),
+		StorageType:          types.StringValue(prior.StorageType),
+		StorageAccountName:   stringValueOrNull(prior.StorageAccountName),
+		StorageContainerName: stringValueOrNull(prior.StorageContainerName),
+		CreationDate:         types.StringValue(prior.CreationDate),
+		CreationUser:         types.StringValue(prior.CreationUser),
+		CreationUserType:     types.StringValue(prior.CreationUserType),
+		LastModifiedDate:     types.StringValue(prior.LastModifiedDate),
+		LastModifiedUser:     types.StringValue(prior.LastModifiedUser),
+		LastModifiedUserType: types.StringValue(prior.LastModifiedUserType),
+		Auth:                 types.ObjectNull(datastoreAuthAttributeTypes),
+	}
+	if len(prior.Auth) > 0 {
+		auth := prior.Auth[0]
+		var diags diag.Diagnostics
+		data.Auth, diags = types.ObjectValueFrom(ctx, datastoreAuthAttributeTypes, datastoreAuthModel{
+			CredentialsType: types.StringValue(auth.CredentialsType),
+			TenantId:        stringValueOrNull(auth.TenantId),
+			ClientId:        stringValueOrNull(auth.ClientId),
+			ClientSecret:    stringValueOrNull(auth.ClientSecret),
+			AccountKey:      stringValueOrNull(auth.AccountKey),
+			SqlUserName:     stringValueOrNull(auth.SqlUserName),
+			SqlUserPassword: stringValueOrNull(auth.SqlUserPassword),
+		})
+		resp.Diagnostics.Append(diags...)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
-func resourceDatastoreSetResourceData(d *schema.ResourceData, datastore *workspace.Datastore) diag.Diagnostics {
-	if err := d.Set("description", datastore.Description); err != nil {
-		return diag.FromErr(err)
+// azurermDatastoreStorageTypes are the storage types of the datastores managed by the resources of the azurerm
+// provider whose state can be moved to azureml_datastore.
+var azurermDatastoreStorageTypes = map[string]string{
+	"azurerm_machine_learning_datastore_blobstorage":   "AzureBlob",
+	"azurerm_machine_learning_datastore_datalake_gen2": "AzureDataLakeGen2",
+	"azurerm_machine_learning_datastore_fileshare":     "AzureFile",
+}
+
+// azurermDatastoreState is the state of the datastore resources of the azurerm provider. The blob storage and
+// the Data Lake Gen2 datastores reference a container through storage_container_id, while the file share ones
+// reference a share through storage_fileshare_id.
+type azurermDatastoreState struct {
+	Id                      string            `json:"id"`
+	Name                    string            `json:"name"`
+	WorkspaceId             string            `json:"workspace_id"`
+	Description             string            `json:"description"`
+	IsDefault               bool              `json:"is_default"`
+	StorageContainerId      string            `json:"storage_container_id"`
+	StorageFileshareId      string            `json:"storage_fileshare_id"`
+	AccountKey              string            `json:"account_key"`
+	SharedAccessSignature   string            `json:"shared_access_signature"`
+	TenantId                string            `json:"tenant_id"`
+	ClientId                string            `json:"client_id"`
+	ClientSecret            string            `json:"client_secret"`
+	ServiceDataAuthIdentity string            `json:"service_data_auth_identity"`
+	ServiceDataIdentity     string            `json:"service_data_identity"`
+	Tags                    map[string]string `json:"tags"`
+}
+
+func (r *datastoreResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		// The raw state is decoded instead of being read through a source schema, so that the state written by
+		// any version of the azurerm provider is moved regardless of the attributes it contains.
+		{StateMover: moveAzurermDatastoreState},
 	}
-	if err := d.Set("is_default", datastore.IsDefault); err != nil {
-		return diag.FromErr(err)
+}
+
+// moveAzurermDatastoreState converts the state of the datastore resources of the azurerm provider, so that a
+// moved block can replace them with azureml_datastore without recreating the datastore. The credentials are kept
+// in auth, while the computed attributes are left null and read from Azure ML by the following refresh.
+func moveAzurermDatastoreState(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	storageType, ok := azurermDatastoreStorageTypes[req.SourceTypeName]
+	if !ok || !strings.HasSuffix(req.SourceProviderAddress, "/hashicorp/azurerm") {
+		return
 	}
-	if err := d.Set("storage_type", datastore.StorageType); err != nil {
-		return diag.FromErr(err)
+	if req.SourceRawState == nil || req.SourceRawState.JSON == nil {
+		resp.Diagnostics.AddError(
+			"Unable to move the state of the datastore",
+			fmt.Sprintf("The state of %s does not contain its attributes in JSON format.", req.SourceTypeName),
+		)
+		return
 	}
-	if err := d.Set("storage_account_name", datastore.StorageAccountName); err != nil {
-		return diag.FromErr(err)
+	var source azurermDatastoreState
+	if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+		resp.Diagnostics.AddError("Unable to move the state of the datastore", err.Error())
+		return
 	}
-	if err := d.Set("storage_container_name", datastore.StorageContainerName); err != nil {
-		return diag.FromErr(err)
+
+	resourceGroupName, workspaceName, segments, err := splitWorkspaceId(source.WorkspaceId)
+	if err == nil && len(segments) > 0 {
+		err = fmt.Errorf("invalid Azure ML Workspace ID %q", source.WorkspaceId)
 	}
-	if err := d.Set("creation_date", datastore.SystemData.CreationDate.Format(defaultDateFormat)); err != nil {
-		return diag.FromErr(err)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to move the state of the datastore", err.Error())
+		return
 	}
-	if err := d.Set("creation_user", datastore.SystemData.CreationUser); err != nil {
-		return diag.FromErr(err)
+	storageId := source.StorageContainerId
+	if storageType == "AzureFile" {
+		storageId = source.StorageFileshareId
 	}
-	if err := d.Set("creation_user_type", datastore.SystemData.CreationUserType); err != nil {
-		return diag.FromErr(err)
+	storageAccountName, storageContainerName, err := parseStorageChildId(storageId)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to move the state of the datastore", err.Error())
+		return
+	}
+
+	auth := datastoreAuthModel{
+		CredentialsType: types.StringValue("None"),
+		TenantId:        types.StringNull(),
+		ClientId:        types.StringNull(),
+		ClientSecret:    types.StringNull(),
+		AccountKey:      types.StringNull(),
+		SqlUserName:     types.StringNull(),
+		SqlUserPassword: types.StringNull(),
 	}
-	if err := d.Set("last_modified_date", datastore.SystemData.CreationDate.Format(defaultDateFormat)); err != nil {
-		return diag.FromErr(err)
+	switch {
+	case source.SharedAccessSignature != "":
+		// The shared access signature would be lost, as azureml_datastore cannot store it
+		resp.Diagnostics.AddError(
+			"Unable to move the state of the datastore",
+			fmt.Sprintf(
+				"The datastore %s authenticates with a shared access signature, which is not supported by "+
+					"azureml_datastore. Configure the datastore to authenticate with the account key or with "+
+					"an identity before moving it.",
+				source.Name,
+			),
+		)
+		return
+	case source.AccountKey != "":
+		auth.CredentialsType = types.StringValue("AccountKey")
+		auth.AccountKey = types.StringValue(source.AccountKey)
+	case source.ClientId != "":
+		auth.CredentialsType = types.StringValue("ServicePrincipal")
+		auth.TenantId = stringValueOrNull(source.TenantId)
+		auth.ClientId = types.StringValue(source.ClientId)
+		auth.ClientSecret = stringValueOrNull(source.ClientSecret)
 	}
-	if err := d.Set("last_modified_user", datastore.SystemData.LastModifiedUser); err != nil {
-		return diag.FromErr(err)
+
+	if identity := source.ServiceDataAuthIdentity + source.ServiceDataIdentity; identity != "" && identity != "None" {
+		resp.Diagnostics.AddWarning(
+			"Service data identity not managed",
+			fmt.Sprintf(
+				"The datastore %s uses the identity %s for accessing the data from Azure ML Studio, which is not "+
+					"managed by azureml_datastore and is not changed by the move.",
+				source.Name,
+				identity,
+			),
+		)
 	}
-	if err := d.Set("last_modified_user_type", datastore.SystemData.LastModifiedUserType); err != nil {
-		return diag.FromErr(err)
+	if len(source.Tags) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Tags not managed",
+			fmt.Sprintf(
+				"The tags of the datastore %s are not managed by azureml_datastore and are not changed by the move.",
+				source.Name,
+			),
+		)
 	}
 
-	currentAuthSet := d.Get("auth").(*schema.Set)
-	currentAuth := currentAuthSet.List()[0].(map[string]interface{})
-	currentAuth["credentials_type"] = datastore.Auth.CredentialsType
-	if err := d.Set("auth", currentAuthSet); err != nil {
-		return diag.FromErr(err)
+	data := datastoreResourceModel{
+		ResourceGroupName:    types.StringValue(resourceGroupName),
+		WorkspaceName:        types.StringValue(workspaceName),
+		Name:                 types.StringValue(source.Name),
+		Id:                   types.StringValue(source.Id),
+		Description:          types.StringValue(source.Description),
+		IsDefault:            types.BoolValue(source.IsDefault),
+		StorageType:          types.StringValue(storageType),
+		StorageAccountName:   types.StringValue(storageAccountName),
+		StorageContainerName: types.StringValue(storageContainerName),
+		CreationDate:         types.StringNull(),
+		CreationUser:         types.StringNull(),
+		CreationUserType:     types.StringNull(),
+		LastModifiedDate:     types.StringNull(),
+		LastModifiedUser:     types.StringNull(),
+		LastModifiedUserType: types.StringNull(),
+	}
+	var diags diag.Diagnostics
+	data.Auth, diags = types.ObjectValueFrom(ctx, datastoreAuthAttributeTypes, auth)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	return nil
+	resp.Diagnostics.Append(resp.TargetState.Set(ctx, &data)...)
 }
 
-func schemaSetToDatastoreAuth(set *schema.Set) (*workspace.DatastoreAuth, error) {
-	data := set.List()[0].(map[string]interface{})
-	auth := new(workspace.DatastoreAuth)
-	auth.CredentialsType = data["credentials_type"].(string)
-	if data["client_id"] != nil {
-		auth.ClientId = data["client_id"].(string)
+// toDatastore returns the datastore described by the model, whose secrets are taken from the write-only arguments
+// of the auth object of the configuration when set. The model must not contain unknown values.
+func (m *datastoreResourceModel) toDatastore(ctx context.Context, configAuth types.Object) (*workspace.Datastore, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var creationDate time.Time
+	var lastModifiedDate time.Time
+	var err error
+
+	if v := m.CreationDate.ValueString(); v != "" {
+		creationDate, err = time.Parse(defaultDateFormat, v)
+		if err != nil {
+			diags.AddAttributeError(path.Root("creation_date"), "Invalid creation date", err.Error())
+			return nil, diags
+		}
 	}
-	if data["tenant_id"] != nil {
-		auth.TenantId = data["tenant_id"].(string)
+	if v := m.LastModifiedDate.ValueString(); v != "" {
+		lastModifiedDate, err = time.Parse(defaultDateFormat, v)
+		if err != nil {
+			diags.AddAttributeError(path.Root("last_modified_date"), "Invalid last modified date", err.Error())
+			return nil, diags
+		}
 	}
-	if data["client_secret"] != nil {
-		auth.ClientSecret = data["client_secret"].(string)
+
+	var auth, config datastoreAuthModel
+	diags.Append(m.Auth.As(ctx, &auth, basetypes.ObjectAsOptions{})...)
+	diags.Append(configAuth.As(ctx, &config, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	auth.ClientSecretWo = config.ClientSecretWo
+	auth.AccountKeyWo = config.AccountKeyWo
+	auth.SqlUserPasswordWo = config.SqlUserPasswordWo
+
+	return &workspace.Datastore{
+		Id:                   m.Id.ValueString(),
+		Name:                 m.Name.ValueString(),
+		IsDefault:            m.IsDefault.ValueBool(),
+		Description:          m.Description.ValueString(),
+		StorageType:          m.StorageType.ValueString(),
+		StorageAccountName:   m.StorageAccountName.ValueString(),
+		StorageContainerName: m.StorageContainerName.ValueString(),
+		SystemData: &workspace.SystemData{
+			CreationDate:         creationDate,
+			CreationUser:         m.CreationUser.ValueString(),
+			CreationUserType:     m.CreationUserType.ValueString(),
+			LastModifiedDate:     lastModifiedDate,
+			LastModifiedUser:     m.LastModifiedUser.ValueString(),
+			LastModifiedUserType: m.LastModifiedUserType.ValueString(),
+		},
+		Auth: auth.toDatastoreAuth(),
+	}, diags
+}
+
+// setDatastore updates the model with the datastore returned by Azure ML. The secrets of the credentials are not
+// returned by Azure ML, hence they are left untouched while the other values of auth are updated.
+func (m *datastoreResourceModel) setDatastore(datastore *workspace.Datastore) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.Id = types.StringValue(datastore.Id)
+	m.Description = types.StringValue(datastore.Description)
+	m.IsDefault = types.BoolValue(datastore.IsDefault)
+	m.StorageType = types.StringValue(datastore.StorageType)
+	m.StorageAccountName = stringValueOrNull(datastore.StorageAccountName)
+	m.StorageContainerName = stringValueOrNull(datastore.StorageContainerName)
+	m.CreationDate = types.StringValue(datastore.SystemData.CreationDate.Format(defaultDateFormat))
+	m.CreationUser = types.StringValue(datastore.SystemData.CreationUser)
+	m.CreationUserType = types.StringValue(datastore.SystemData.CreationUserType)
+	m.LastModifiedDate = types.StringValue(datastore.SystemData.LastModifiedDate.Format(defaultDateFormat))
+	m.LastModifiedUser = types.StringValue(datastore.SystemData.LastModifiedUser)
+	m.LastModifiedUserType = types.StringValue(datastore.SystemData.LastModifiedUserType)
+
+	// The auth object is null after an import
+	attributes := map[string]attr.Value{}
+	for name, attributeType := range datastoreAuthAttributeTypes {
+		if attributeType.Equal(types.Int64Type) {
+			attributes[name] = types.Int64Null()
+		} else {
+			attributes[name] = types.StringNull()
+		}
 	}
-	if data["account_key"] != nil {
-		auth.AccountKey = data["account_key"].(string)
+	if !m.Auth.IsNull() && !m.Auth.IsUnknown() {
+		for name, value := range m.Auth.Attributes() {
+			attributes[name] = value
+		}
 	}
-	if data["sql_user_password"] != nil {
-		auth.SqlUserPassword = data["sql_user_password"].(string)
+	attributes["credentials_type"] = types.StringValue(datastore.Auth.CredentialsType)
+	attributes["client_id"] = stringValueOrNull(datastore.Auth.ClientId)
+	attributes["tenant_id"] = stringValueOrNull(datastore.Auth.TenantId)
+	attributes["sql_user_name"] = stringValueOrNull(datastore.Auth.SqlUserName)
+	var d diag.Diagnostics
+	m.Auth, d = types.ObjectValue(datastoreAuthAttributeTypes, attributes)
+	diags.Append(d...)
+	return diags
+}
+
+// toDatastoreAuth returns the credentials described by the model, in which the null values are empty strings.
+// The secrets set through the write-only arguments take the place of the corresponding arguments.
+func (m datastoreAuthModel) toDatastoreAuth() *workspace.DatastoreAuth {
+	return &workspace.DatastoreAuth{
+		CredentialsType: m.CredentialsType.ValueString(),
+		ClientId:        m.ClientId.ValueString(),
+		TenantId:        m.TenantId.ValueString(),
+		ClientSecret:    writeOnlyValueOr(m.ClientSecretWo, m.ClientSecret),
+		AccountKey:      writeOnlyValueOr(m.AccountKeyWo, m.AccountKey),
+		SqlUserName:     m.SqlUserName.ValueString(),
+		SqlUserPassword: writeOnlyValueOr(m.SqlUserPasswordWo, m.SqlUserPassword),
 	}
-	if data["sql_user_name"] != nil {
-		auth.SqlUserName = data["sql_user_name"].(string)
+}
+
+// writeOnlyValueOr returns the value of the write-only argument if set, and the value of the argument otherwise.
+func writeOnlyValueOr(writeOnly, value types.String) string {
+	if !writeOnly.IsNull() {
+		return writeOnly.ValueString()
 	}
+	return value.ValueString()
+}
 
-	return auth, nil
+// stringValueOrNull returns a null string if the value provided as argument is empty, so that the optional
+// arguments not returned by Azure ML do not differ from an unset configuration.
+func stringValueOrNull(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
 }
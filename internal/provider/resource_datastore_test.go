@@ -1 +1,648 @@
 package provider
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/orobix/azureml-go-sdk/workspace"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testDatastoreStateV0 is the state of a datastore written by the SDKv2 implementation of the resource.
+const testDatastoreStateV0 = `{
+  "auth": [
+    {
+      "account_key": "",
+      "client_id": "client-id",
+      "client_secret": "s3cret",
+      "credentials_type": "ServicePrincipal",
+      "sql_user_name": "",
+      "sql_user_password": "",
+      "tenant_id": "tenant-id"
+    }
+  ],
+  "creation_date": "2022-03-01T10:00:00Z",
+  "creation_user": "user",
+  "creation_user_type": "Application",
+  "description": "",
+  "id": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/ds",
+  "is_default": false,
+  "last_modified_date": "2022-03-01T10:00:00Z",
+  "last_modified_user": "user",
+  "last_modified_user_type": "Application",
+  "name": "ds",
+  "resource_group_name": "rg",
+  "storage_account_name": "",
+  "storage_container_name": "container",
+  "storage_type": "AzureBlob",
+  "workspace_name": "ws"
+}`
+
+// newTestDatastoreServer returns the provider server and the type of the state of azureml_datastore.
+func newTestDatastoreServer(t *testing.T) (tfprotov6.ProviderServer, tftypes.Type) {
+	ctx := context.Background()
+	server, err := NewMuxServer(ctx, "dev")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	schemas, err := server().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return server(), schemas.ResourceSchemas["azureml_datastore"].ValueType()
+}
+
+// upgradeTestDatastoreState upgrades the raw state provided as argument through the provider server and returns
+// the upgraded state.
+func upgradeTestDatastoreState(t *testing.T, server tfprotov6.ProviderServer, stateType tftypes.Type, version int64, rawState []byte) tftypes.Value {
+	resp, err := server.UpgradeResourceState(context.Background(), &tfprotov6.UpgradeResourceStateRequest{
+		TypeName: "azureml_datastore",
+		Version:  version,
+		RawState: &tfprotov6.RawState{JSON: rawState},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+
+	value, err := resp.UpgradedState.Unmarshal(stateType)
+	if err != nil {
+		t.Fatalf("unable to decode the upgraded state: %s", err)
+	}
+	return value
+}
+
+func testValueAttributes(t *testing.T, value tftypes.Value) map[string]tftypes.Value {
+	attributes := map[string]tftypes.Value{}
+	if err := value.As(&attributes); err != nil {
+		t.Fatalf("unable to decode %s: %s", value, err)
+	}
+	return attributes
+}
+
+// testConfigValue returns the value of an optional string argument in the configuration corresponding to a
+// state value, in which the unset arguments are null.
+func testConfigValue(value tftypes.Value) tftypes.Value {
+	var v string
+	if value.Type().Is(tftypes.String) && value.As(&v) == nil && v == "" {
+		return tftypes.NewValue(tftypes.String, nil)
+	}
+	return value
+}
+
+func TestDatastoreUpgradeStateV0(t *testing.T) {
+	server, stateType := newTestDatastoreServer(t)
+	attributes := testValueAttributes(t, upgradeTestDatastoreState(t, server, stateType, 0, []byte(testDatastoreStateV0)))
+
+	for name, expected := range map[string]string{
+		"name":                   "ds",
+		"storage_container_name": "container",
+		"description":            "",
+		"creation_date":          "2022-03-01T10:00:00Z",
+	} {
+		var v string
+		if err := attributes[name].As(&v); err != nil || v != expected {
+			t.Errorf("unexpected %s %q, expected %q", name, v, expected)
+		}
+	}
+	if !attributes["storage_account_name"].IsNull() {
+		t.Errorf("the empty storage_account_name has not been converted to null")
+	}
+
+	auth := testValueAttributes(t, attributes["auth"])
+	for name, expected := range map[string]string{
+		"credentials_type": "ServicePrincipal",
+		"client_id":        "client-id",
+		"client_secret":    "s3cret",
+		"tenant_id":        "tenant-id",
+	} {
+		var v string
+		if err := auth[name].As(&v); err != nil || v != expected {
+			t.Errorf("unexpected auth.%s %q, expected %q", name, v, expected)
+		}
+	}
+	for _, name := range []string{"account_key", "sql_user_name", "sql_user_password"} {
+		if !auth[name].IsNull() {
+			t.Errorf("the empty auth.%s has not been converted to null", name)
+		}
+	}
+}
+
+// TestDatastoreUpgradeStateReleases upgrades the state written by each released version of the provider, which
+// must keep the secrets of the credentials and must not plan any change for the configuration that created it.
+// readTestDatastoreRelease returns the schema version and the raw state of the datastore in the state written by
+// the release provided as argument.
+func readTestDatastoreRelease(t *testing.T, version string) (int64, json.RawMessage) {
+	b, err := os.ReadFile(filepath.Join("testdata", "datastore_state", version+".tfstate"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	var state struct {
+		Resources []struct {
+			Instances []struct {
+				SchemaVersion int64           `json:"schema_version"`
+				Attributes    json.RawMessage `json:"attributes"`
+			} `json:"instances"`
+		} `json:"resources"`
+	}
+	if err := json.Unmarshal(b, &state); err != nil {
+		t.Fatalf("unable to parse the state: %s", err)
+	}
+	instance := state.Resources[0].Instances[0]
+	return instance.SchemaVersion, instance.Attributes
+}
+
+func TestDatastoreUpgradeStateReleases(t *testing.T) {
+	ctx := context.Background()
+	server, stateType := newTestDatastoreServer(t)
+
+	for _, version := range []string{"0.0.1", "0.0.2", "0.0.3", "0.0.4", "0.0.5"} {
+		t.Run(version, func(t *testing.T) {
+			schemaVersion, rawState := readTestDatastoreRelease(t, version)
+			var prior datastoreStateV0
+			if err := json.Unmarshal(rawState, &prior); err != nil {
+				t.Fatalf("unable to parse the state: %s", err)
+			}
+
+			upgraded := upgradeTestDatastoreState(t, server, stateType, schemaVersion, rawState)
+			attributes := testValueAttributes(t, upgraded)
+			auth := testValueAttributes(t, attributes["auth"])
+			for name, expected := range map[string]string{
+				"credentials_type":  prior.Auth[0].CredentialsType,
+				"client_secret":     prior.Auth[0].ClientSecret,
+				"account_key":       prior.Auth[0].AccountKey,
+				"sql_user_password": prior.Auth[0].SqlUserPassword,
+			} {
+				var v string
+				if expected != "" && (auth[name].As(&v) != nil || v != expected) {
+					t.Errorf("auth.%s has not been kept: %q, expected %q", name, v, expected)
+				}
+			}
+
+			// The configuration which created the datastore sets neither the computed arguments nor the
+			// optional ones stored as empty strings by SDKv2
+			config := map[string]tftypes.Value{}
+			for name, value := range attributes {
+				config[name] = testConfigValue(value)
+			}
+			authConfig := map[string]tftypes.Value{}
+			for name, value := range auth {
+				authConfig[name] = testConfigValue(value)
+			}
+			config["auth"] = tftypes.NewValue(attributes["auth"].Type(), authConfig)
+			for _, name := range []string{
+				"id",
+				"creation_date",
+				"creation_user",
+				"creation_user_type",
+				"last_modified_date",
+				"last_modified_user",
+				"last_modified_user_type",
+			} {
+				config[name] = tftypes.NewValue(tftypes.String, nil)
+			}
+			if !prior.IsDefault {
+				config["is_default"] = tftypes.NewValue(tftypes.Bool, nil)
+			}
+
+			// As Terraform does, the proposed new state takes the configured values and the prior values of the
+			// computed attributes that are not configured
+			proposed := map[string]tftypes.Value{}
+			for name, value := range config {
+				proposed[name] = value
+				if value.IsNull() {
+					switch name {
+					case "storage_account_name", "storage_container_name":
+					default:
+						proposed[name] = attributes[name]
+					}
+				}
+			}
+
+			priorState, err := tfprotov6.NewDynamicValue(stateType, upgraded)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			configValue, err := tfprotov6.NewDynamicValue(stateType, tftypes.NewValue(stateType, config))
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			proposedValue, err := tfprotov6.NewDynamicValue(stateType, tftypes.NewValue(stateType, proposed))
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+				TypeName:         "azureml_datastore",
+				PriorState:       &priorState,
+				ProposedNewState: &proposedValue,
+				Config:           &configValue,
+			})
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			for _, d := range resp.Diagnostics {
+				t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+			}
+			if len(resp.RequiresReplace) > 0 {
+				t.Errorf("unexpected replacement caused by %v", resp.RequiresReplace)
+			}
+			planned, err := resp.PlannedState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatalf("err: %s", err)
+			}
+			if diffs, _ := upgraded.Diff(planned); len(diffs) > 0 {
+				t.Errorf("unexpected changes planned: %v", diffs)
+			}
+		})
+	}
+}
+
+// moveTestDatastoreState moves the raw state of the azurerm resource provided as argument through the provider
+// server and returns the response.
+func moveTestDatastoreState(t *testing.T, server tfprotov6.ProviderServer, providerAddress, typeName, rawState string) *tfprotov6.MoveResourceStateResponse {
+	resp, err := server.MoveResourceState(context.Background(), &tfprotov6.MoveResourceStateRequest{
+		SourceProviderAddress: providerAddress,
+		SourceTypeName:        typeName,
+		SourceState:           &tfprotov6.RawState{JSON: []byte(rawState)},
+		TargetTypeName:        "azureml_datastore",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return resp
+}
+
+func TestDatastoreMoveState(t *testing.T) {
+	server, stateType := newTestDatastoreServer(t)
+	workspaceId := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws"
+	accountId := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account"
+
+	cases := []struct {
+		typeName   string
+		rawState   string
+		attributes map[string]string
+		auth       map[string]string
+	}{
+		{
+			"azurerm_machine_learning_datastore_blobstorage",
+			`{"id": "` + workspaceId + `/dataStores/blob", "name": "blob", "workspace_id": "` + workspaceId + `",
+			  "storage_container_id": "` + accountId + `/blobServices/default/containers/c", "description": "",
+			  "is_default": true, "account_key": "k3y", "shared_access_signature": "",
+			  "service_data_auth_identity": "None", "tags": {}}`,
+			map[string]string{"storage_type": "AzureBlob", "storage_container_name": "c", "name": "blob", "description": ""},
+			map[string]string{"credentials_type": "AccountKey", "account_key": "k3y"},
+		},
+		{
+			"azurerm_machine_learning_datastore_datalake_gen2",
+			`{"id": "` + workspaceId + `/dataStores/lake", "name": "lake", "workspace_id": "` + workspaceId + `",
+			  "storage_container_id": "https://account.dfs.core.windows.net/fs", "description": "Data lake",
+			  "tenant_id": "tenant-id", "client_id": "client-id", "client_secret": "s3cret",
+			  "authority_url": "", "service_data_identity": "None"}`,
+			map[string]string{"storage_type": "AzureDataLakeGen2", "storage_container_name": "fs", "description": "Data lake"},
+			map[string]string{"credentials_type": "ServicePrincipal", "client_id": "client-id", "client_secret": "s3cret", "tenant_id": "tenant-id"},
+		},
+		{
+			"azurerm_machine_learning_datastore_fileshare",
+			`{"id": "` + workspaceId + `/dataStores/share", "name": "share", "workspace_id": "` + workspaceId + `",
+			  "storage_fileshare_id": "` + accountId + `/fileServices/default/shares/s", "description": "",
+			  "account_key": "", "shared_access_signature": "", "service_data_identity": "WorkspaceSystemAssignedIdentity"}`,
+			map[string]string{"storage_type": "AzureFile", "storage_container_name": "s"},
+			map[string]string{"credentials_type": "None"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.typeName, func(t *testing.T) {
+			resp := moveTestDatastoreState(t, server, "registry.terraform.io/hashicorp/azurerm", c.typeName, c.rawState)
+			for _, d := range resp.Diagnostics {
+				if d.Severity == tfprotov6.DiagnosticSeverityError {
+					t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+				}
+			}
+			value, err := resp.TargetState.Unmarshal(stateType)
+			if err != nil {
+				t.Fatalf("unable to decode the moved state: %s", err)
+			}
+			attributes := testValueAttributes(t, value)
+			c.attributes["resource_group_name"] = "rg"
+			c.attributes["workspace_name"] = "ws"
+			c.attributes["storage_account_name"] = "account"
+			for name, expected := range c.attributes {
+				var v string
+				if err := attributes[name].As(&v); err != nil || v != expected {
+					t.Errorf("unexpected %s %q, expected %q", name, v, expected)
+				}
+			}
+			auth := testValueAttributes(t, attributes["auth"])
+			for name := range datastoreAuthAttributeTypes {
+				var v string
+				if expected, ok := c.auth[name]; ok {
+					if err := auth[name].As(&v); err != nil || v != expected {
+						t.Errorf("unexpected auth.%s %q, expected %q", name, v, expected)
+					}
+				} else if !auth[name].IsNull() {
+					t.Errorf("auth.%s is not null", name)
+				}
+			}
+		})
+	}
+
+	// The shared access signature cannot be kept
+	resp := moveTestDatastoreState(t, server, "registry.terraform.io/hashicorp/azurerm",
+		"azurerm_machine_learning_datastore_blobstorage",
+		`{"name": "blob", "workspace_id": "`+workspaceId+`", "storage_container_id": "`+accountId+
+			`/blobServices/default/containers/c", "shared_access_signature": "sv=2022"}`)
+	if len(resp.Diagnostics) == 0 || resp.TargetState != nil {
+		t.Errorf("the datastore authenticating with a shared access signature has been moved")
+	}
+
+	// The resources of other providers are not moved
+	resp = moveTestDatastoreState(t, server, "registry.terraform.io/example/azurerm",
+		"azurerm_machine_learning_datastore_blobstorage", `{}`)
+	if len(resp.Diagnostics) == 0 || resp.TargetState != nil {
+		t.Errorf("the resource of another provider has been moved")
+	}
+}
+
+// testDatastoreConfig returns a configuration of azureml_datastore in which the arguments of auth are the ones
+// provided as argument and the other arguments are null.
+func testDatastoreConfig(stateType tftypes.Type, auth map[string]tftypes.Value) tftypes.Value {
+	objectType := stateType.(tftypes.Object)
+	authType := objectType.AttributeTypes["auth"].(tftypes.Object)
+	attributes := map[string]tftypes.Value{}
+	for name, t := range objectType.AttributeTypes {
+		attributes[name] = tftypes.NewValue(t, nil)
+	}
+	authAttributes := map[string]tftypes.Value{}
+	for name, t := range authType.AttributeTypes {
+		authAttributes[name] = tftypes.NewValue(t, nil)
+	}
+	for name, value := range auth {
+		authAttributes[name] = value
+	}
+	for name, value := range map[string]string{
+		"resource_group_name":    "rg",
+		"workspace_name":         "ws",
+		"name":                   "ds",
+		"storage_type":           "AzureBlob",
+		"storage_account_name":   "account",
+		"storage_container_name": "container",
+	} {
+		attributes[name] = tftypes.NewValue(tftypes.String, value)
+	}
+	attributes["auth"] = tftypes.NewValue(authType, authAttributes)
+	return tftypes.NewValue(stateType, attributes)
+}
+
+func TestDatastoreWriteOnlyCredentials(t *testing.T) {
+	ctx := context.Background()
+	server, stateType := newTestDatastoreServer(t)
+
+	config, err := tfprotov6.NewDynamicValue(stateType, testDatastoreConfig(stateType, map[string]tftypes.Value{
+		"credentials_type":       tftypes.NewValue(tftypes.String, "AccountKey"),
+		"account_key_wo":         tftypes.NewValue(tftypes.String, "k3y"),
+		"account_key_wo_version": tftypes.NewValue(tftypes.Number, 1),
+	}))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	priorState, err := tfprotov6.NewDynamicValue(stateType, tftypes.NewValue(stateType, nil))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp, err := server.PlanResourceChange(ctx, &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         "azureml_datastore",
+		PriorState:       &priorState,
+		ProposedNewState: &config,
+		Config:           &config,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	planned, err := resp.PlannedState.Unmarshal(stateType)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	auth := testValueAttributes(t, testValueAttributes(t, planned)["auth"])
+	if !auth["account_key_wo"].IsNull() {
+		t.Errorf("the write-only account key has been planned: %s", auth["account_key_wo"])
+	}
+	if auth["account_key_wo_version"].IsNull() {
+		t.Errorf("the version of the write-only account key has not been planned")
+	}
+
+	// The secret is submitted from the configuration
+	data := datastoreResourceModel{
+		Auth: types.ObjectValueMust(datastoreAuthAttributeTypes, map[string]attr.Value{
+			"credentials_type":             types.StringValue("AccountKey"),
+			"tenant_id":                    types.StringNull(),
+			"client_id":                    types.StringNull(),
+			"client_secret":                types.StringNull(),
+			"account_key":                  types.StringNull(),
+			"sql_user_name":                types.StringNull(),
+			"sql_user_password":            types.StringNull(),
+			"client_secret_wo":             types.StringNull(),
+			"client_secret_wo_version":     types.Int64Null(),
+			"account_key_wo":               types.StringNull(),
+			"account_key_wo_version":       types.Int64Value(1),
+			"sql_user_password_wo":         types.StringNull(),
+			"sql_user_password_wo_version": types.Int64Null(),
+		}),
+	}
+	configAuth := data.Auth.Attributes()
+	configAuth["account_key_wo"] = types.StringValue("k3y")
+	datastore, diags := data.toDatastore(ctx, types.ObjectValueMust(datastoreAuthAttributeTypes, configAuth))
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	if datastore.Auth.AccountKey != "k3y" {
+		t.Errorf("unexpected account key %q", datastore.Auth.AccountKey)
+	}
+
+	// A secret cannot be set through both its arguments
+	config, err = tfprotov6.NewDynamicValue(stateType, testDatastoreConfig(stateType, map[string]tftypes.Value{
+		"credentials_type": tftypes.NewValue(tftypes.String, "ServicePrincipal"),
+		"client_secret":    tftypes.NewValue(tftypes.String, "s3cret"),
+		"client_secret_wo": tftypes.NewValue(tftypes.String, "s3cret"),
+	}))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	validateResp, err := server.ValidateResourceConfig(ctx, &tfprotov6.ValidateResourceConfigRequest{
+		TypeName:           "azureml_datastore",
+		Config:             &config,
+		ClientCapabilities: &tfprotov6.ValidateResourceConfigClientCapabilities{WriteOnlyAttributesAllowed: true},
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(validateResp.Diagnostics) != 1 || validateResp.Diagnostics[0].Summary != "Conflicting credentials" {
+		t.Errorf("expected a single conflict diagnostic, got %d", len(validateResp.Diagnostics))
+		for _, d := range validateResp.Diagnostics {
+			t.Logf("diagnostic: %s: %s", d.Summary, d.Detail)
+		}
+	}
+}
+
+func TestDatastoreValidators(t *testing.T) {
+	ctx := context.Background()
+	cases := []struct {
+		validator validator.String
+		value     types.String
+		valid     bool
+	}{
+		{NewDatastoreCredentialsTypeValidator(), types.StringValue("ServicePrincipal"), true},
+		{NewDatastoreCredentialsTypeValidator(), types.StringValue("Password"), false},
+		{NewStorageTypeValidator(), types.StringValue("AzureBlob"), true},
+		{NewStorageTypeValidator(), types.StringValue("S3"), false},
+		{StorageAccountNameValidator{}, types.StringValue("account01"), true},
+		{StorageAccountNameValidator{}, types.StringValue("ab"), false},
+		{StorageAccountNameValidator{}, types.StringValue("account-01"), false},
+		{StringNotEmptyValidator{}, types.StringValue("value"), true},
+		{StringNotEmptyValidator{}, types.StringValue("  "), false},
+		{StringNotEmptyValidator{}, types.StringNull(), true},
+		{StorageAccountNameValidator{}, types.StringUnknown(), true},
+	}
+	for _, c := range cases {
+		resp := &validator.StringResponse{}
+		c.validator.ValidateString(ctx, validator.StringRequest{Path: path.Root("value"), ConfigValue: c.value}, resp)
+		if valid := !resp.Diagnostics.HasError(); valid != c.valid {
+			t.Errorf("%T validation of %s: valid = %v, expected %v", c.validator, c.value, valid, c.valid)
+		}
+	}
+}
+
+func TestDatastoreImport(t *testing.T) {
+	ctx := context.Background()
+	server, err := NewMuxServer(ctx, "dev")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	schemas, err := server().GetProviderSchema(ctx, &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	id := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/ds"
+	resp, err := server().ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: "azureml_datastore",
+		ID:       id,
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	for _, d := range resp.Diagnostics {
+		t.Fatalf("unexpected diagnostic: %s: %s", d.Summary, d.Detail)
+	}
+	if len(resp.ImportedResources) != 1 {
+		t.Fatalf("unexpected number of imported resources: %d", len(resp.ImportedResources))
+	}
+	value, err := resp.ImportedResources[0].State.Unmarshal(schemas.ResourceSchemas["azureml_datastore"].ValueType())
+	if err != nil {
+		t.Fatalf("unable to decode the imported state: %s", err)
+	}
+	attributes := map[string]tftypes.Value{}
+	if err := value.As(&attributes); err != nil {
+		t.Fatalf("unable to decode the imported state: %s", err)
+	}
+	for name, expected := range map[string]string{"id": id, "resource_group_name": "rg", "workspace_name": "ws", "name": "ds"} {
+		if !attributes[name].Equal(tftypes.NewValue(tftypes.String, expected)) {
+			t.Errorf("unexpected %s %s, expected %q", name, attributes[name], expected)
+		}
+	}
+
+	resp, err = server().ImportResourceState(ctx, &tfprotov6.ImportResourceStateRequest{
+		TypeName: "azureml_datastore",
+		ID:       "ds",
+	})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if len(resp.Diagnostics) != 1 || resp.Diagnostics[0].Summary != "Invalid datastore ID" {
+		t.Errorf("expected an invalid ID diagnostic, got %d", len(resp.Diagnostics))
+	}
+}
+
+func TestDatastoreSetDatastoreAfterImport(t *testing.T) {
+	// The auth object is null in the state written by ImportState
+	data := datastoreResourceModel{Auth: types.ObjectNull(datastoreAuthAttributeTypes)}
+	diags := data.setDatastore(&workspace.Datastore{
+		Id:          "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/ds",
+		Name:        "ds",
+		StorageType: "AzureBlob",
+		Auth:        &workspace.DatastoreAuth{CredentialsType: "AccountKey"},
+		SystemData:  &workspace.SystemData{},
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	auth := data.Auth.Attributes()
+	if auth["credentials_type"] != types.StringValue("AccountKey") {
+		t.Errorf("unexpected credentials type %s", auth["credentials_type"])
+	}
+	if !auth["account_key_wo_version"].Equal(types.Int64Null()) {
+		t.Errorf("unexpected version of the write-only account key %s", auth["account_key_wo_version"])
+	}
+}
+
+func TestDatastoreRefreshUpgradedSqlAdminState(t *testing.T) {
+	ctx := context.Background()
+	server, stateType := newTestDatastoreServer(t)
+	schemaVersion, rawState := readTestDatastoreRelease(t, "0.0.3")
+	upgraded := upgradeTestDatastoreState(t, server, stateType, schemaVersion, rawState)
+
+	schemaResp := &resource.SchemaResponse{}
+	(&datastoreResource{}).Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	state := tfsdk.State{Schema: schemaResp.Schema, Raw: upgraded}
+	var data datastoreResourceModel
+	if diags := state.Get(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+
+	// The datastore as returned by Azure ML, without the password of the SQL user
+	var ds armDatastore
+	if err := json.Unmarshal([]byte(`{
+  "id": "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/rg-ml/providers/Microsoft.MachineLearningServices/workspaces/ws-ml/datastores/sql_admin",
+  "name": "sql_admin",
+  "properties": {
+    "contents": {
+      "contentsType": "AzureSqlDatabase",
+      "credentials": {"credentialsType": "SqlAdmin", "userId": "mladmin"},
+      "databaseName": "features",
+      "endpoint": "database.windows.net",
+      "portNumber": 1433,
+      "serverName": "sql-ml"
+    },
+    "description": "Feature tables",
+    "isDefault": false
+  },
+  "systemData": {"createdAt": "2022-04-05T12:00:45Z", "createdByType": "Application"}
+}`), &ds); err != nil {
+		t.Fatalf("unable to parse the datastore: %v", err)
+	}
+	if diags := data.setDatastore(ds.toDatastore()); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	if diags := state.Set(ctx, &data); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+
+	// The refresh keeps the credentials of the upgraded state, which match the configuration
+	refreshed := testValueAttributes(t, state.Raw)["auth"]
+	if diffs, _ := testValueAttributes(t, upgraded)["auth"].Diff(refreshed); len(diffs) > 0 {
+		t.Errorf("unexpected changes of auth: %v", diffs)
+	}
+}
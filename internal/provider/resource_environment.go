@@ -0,0 +1,424 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"io/ioutil"
+	"strings"
+	"time"
+)
+
+func GetAllowedOsTypes() []string {
+	return []string{
+		"Linux",
+		"Windows",
+	}
+}
+
+func resourceEnvironment() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a version of an Environment of an Azure ML Workspace or Registry. The environment container is " +
+			"created together with its first version, and it is deleted when its last version is deleted.",
+
+		CreateContext: resourceEnvironmentCreate,
+		ReadContext:   resourceEnvironmentRead,
+		UpdateContext: resourceEnvironmentUpdate,
+		DeleteContext: resourceEnvironmentDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importAssetVersion("environments"),
+		},
+
+		CustomizeDiff: resourceEnvironmentCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace or Registry to which the environment belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The name of the Azure ML Workspace to which the environment belongs to. Conflicts with `registry_name`.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"workspace_name", "registry_name"},
+			},
+			"registry_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of the Azure ML Registry to which the environment belongs to, for sharing it across " +
+					"workspaces. Conflicts with `workspace_name`.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"workspace_name", "registry_name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the environment.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"version": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The version of the environment.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the environment version.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the environment version.",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the environment version.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"image": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The Docker image used as base image of the environment (e.g. " +
+					"`mcr.microsoft.com/azureml/openmpi4.1.0-ubuntu20.04`).",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"image", "build"},
+			},
+			"conda_file": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The content of the Conda specification file used for installing the packages of the " +
+					"environment on top of `image`.",
+				ForceNew:         true,
+				ValidateFunc:     validation.StringIsNotEmpty,
+				ConflictsWith:    []string{"conda_file_path", "build"},
+				DiffSuppressFunc: suppressWhitespaceDiff,
+			},
+			"conda_file_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The local path of the Conda specification file used for installing the packages of " +
+					"the environment on top of `image`. A new version is required whenever the content of the file changes.",
+				ForceNew:      true,
+				ValidateFunc:  validation.StringIsNotEmpty,
+				ConflictsWith: []string{"conda_file", "build"},
+			},
+			"conda_file_hash": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The SHA-256 hash of the content of the file at `conda_file_path`.",
+			},
+			"build": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				ForceNew:    true,
+				Description: "The Docker build context used for building the image of the environment.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"context_uri": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: "The URI of the Docker build context (e.g. the `azureml://` URI of a folder " +
+								"of a datastore, or the URL of a Git repository).",
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"dockerfile_path": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "Dockerfile",
+							Description:  "The path of the Dockerfile relative to the root of the build context.",
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+			"os_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Linux",
+				Description: fmt.Sprintf(
+					"The operating system of the environment. Possible values are: %+q.",
+					GetAllowedOsTypes(),
+				),
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(GetAllowedOsTypes(), false),
+			},
+			"wait_for_build": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Should the provider wait for the image of the environment to be built? If the build " +
+					"fails, its failure is reported as an error.",
+			},
+			"environment_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the environment (Curated or UserCreated).",
+			},
+		},
+	}
+}
+
+func resourceEnvironmentCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	path := d.Get("conda_file_path").(string)
+	if path == "" {
+		return d.SetNew("conda_file_hash", "")
+	}
+	h, err := fileSha256(path)
+	if err != nil {
+		return fmt.Errorf("unable to read conda file: %w", err)
+	}
+	return d.SetNew("conda_file_hash", h)
+}
+
+func resourceEnvironmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	environment, err := resourceEnvironmentGetResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	containerPath := resourceAssetContainerPath(client.arm, d, "environments")
+	if err := ensureAssetContainer(ctx, client.arm, containerPath, &assetContainerResource{}); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create environment %s: %w", name, err))
+	}
+
+	path := assetVersionPath(containerPath, version)
+	if err := putAssetVersion(ctx, client.arm, path, environment); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(path)
+
+	if d.Get("wait_for_build").(bool) {
+		if diags := waitForEnvironmentBuild(ctx, client.arm, path, d.Timeout(schema.TimeoutCreate)); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceEnvironmentRead(ctx, d, meta)
+}
+
+func resourceEnvironmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := resourceAssetContainerPath(client.arm, d, "environments")
+	environment := new(environmentVersionResource)
+	err := client.arm.get(ctx, assetVersionPath(containerPath, version), environment)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading environment %s version %s", name, version),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(environment.Id)
+	return resourceEnvironmentSetResourceData(d, environment)
+}
+
+func resourceEnvironmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	version := d.Get("version").(string)
+
+	if !d.HasChanges("description", "tags") {
+		return resourceEnvironmentRead(ctx, d, meta)
+	}
+
+	// Only the description and the tags of a version can be updated, the other properties must be
+	// submitted unchanged.
+	containerPath := resourceAssetContainerPath(client.arm, d, "environments")
+	path := assetVersionPath(containerPath, version)
+	environment := new(environmentVersionResource)
+	if err := client.arm.get(ctx, path, environment); err != nil {
+		return diag.FromErr(err)
+	}
+	environment.Properties.Description = d.Get("description").(string)
+	environment.Properties.Tags = expandStringMap(d.Get("tags").(map[string]interface{}))
+	if err := putAssetVersion(ctx, client.arm, path, environment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceEnvironmentRead(ctx, d, meta)
+}
+
+func resourceEnvironmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := resourceAssetContainerPath(client.arm, d, "environments")
+	err := deleteAssetVersion(ctx, client.arm, containerPath, version)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting environment %s version %s.", name, version),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceEnvironmentGetResourceData(d *schema.ResourceData) (*environmentVersionResource, error) {
+	props := environmentVersionProperties{
+		Description: d.Get("description").(string),
+		Tags:        expandStringMap(d.Get("tags").(map[string]interface{})),
+		Image:       d.Get("image").(string),
+		CondaFile:   d.Get("conda_file").(string),
+		OsType:      d.Get("os_type").(string),
+	}
+
+	if path := d.Get("conda_file_path").(string); path != "" {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read conda file: %w", err)
+		}
+		props.CondaFile = string(content)
+	}
+
+	if v := d.Get("build").([]interface{}); len(v) > 0 && v[0] != nil {
+		build := v[0].(map[string]interface{})
+		props.Build = &environmentBuildContext{
+			ContextUri:     build["context_uri"].(string),
+			DockerfilePath: build["dockerfile_path"].(string),
+		}
+	}
+
+	return &environmentVersionResource{Properties: props}, nil
+}
+
+func resourceEnvironmentSetResourceData(d *schema.ResourceData, environment *environmentVersionResource) diag.Diagnostics {
+	props := environment.Properties
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", props.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("image", props.Image); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("os_type", props.OsType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("environment_type", props.EnvironmentType); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The content of the conda file read from a local path is tracked through its hash.
+	if d.Get("conda_file_path").(string) == "" {
+		if err := d.Set("conda_file", props.CondaFile); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	build := make([]interface{}, 0)
+	if props.Build != nil {
+		build = append(build, map[string]interface{}{
+			"context_uri":     props.Build.ContextUri,
+			"dockerfile_path": props.Build.DockerfilePath,
+		})
+	}
+	if err := d.Set("build", build); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// waitForEnvironmentBuild waits until the image of the environment version at the path provided as argument
+// is built, and reports the build failure as an error diagnostic.
+func waitForEnvironmentBuild(ctx context.Context, c *armClient, path string, timeout time.Duration) diag.Diagnostics {
+	environment := new(environmentVersionResource)
+	err := pollUntilDone(ctx, "the environment image to be built", timeout, func(ctx context.Context) (bool, time.Duration, error) {
+		if err := c.get(ctx, path, environment); err != nil {
+			return false, 0, err
+		}
+		switch environment.Properties.ProvisioningState {
+		case "Succeeded", "Failed", "Canceled":
+			return true, 0, nil
+		}
+		return false, 0, nil
+	})
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for the environment image to be built: %w", err))
+	}
+
+	if environment.Properties.ProvisioningState != "Succeeded" {
+		return diag.Diagnostics{
+			diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Environment image build %s", strings.ToLower(environment.Properties.ProvisioningState)),
+				Detail: fmt.Sprintf(
+					"The build of the image of environment %s ended with state %q. The build logs are available "+
+						"in the Environments section of Azure ML Studio.",
+					environment.Id,
+					environment.Properties.ProvisioningState,
+				),
+			},
+		}
+	}
+	return nil
+}
+
+type environmentVersionResource struct {
+	Id         string                       `json:"id,omitempty"`
+	Name       string                       `json:"name,omitempty"`
+	Properties environmentVersionProperties `json:"properties"`
+}
+
+type environmentVersionProperties struct {
+	Description       string                   `json:"description,omitempty"`
+	Tags              map[string]string        `json:"tags,omitempty"`
+	Image             string                   `json:"image,omitempty"`
+	CondaFile         string                   `json:"condaFile,omitempty"`
+	Build             *environmentBuildContext `json:"build,omitempty"`
+	OsType            string                   `json:"osType,omitempty"`
+	EnvironmentType   string                   `json:"environmentType,omitempty"`
+	ProvisioningState string                   `json:"provisioningState,omitempty"`
+}
+
+type environmentBuildContext struct {
+	ContextUri     string `json:"contextUri"`
+	DockerfilePath string `json:"dockerfilePath,omitempty"`
+}
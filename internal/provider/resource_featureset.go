@@ -0,0 +1,507 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+func GetAllowedMaterializationStoreTypes() []string {
+	return []string{
+		"None",
+		"Online",
+		"Offline",
+		"OnlineAndOffline",
+	}
+}
+
+func GetAllowedMaterializationNotificationEvents() []string {
+	return []string{
+		"JobCompleted",
+		"JobFailed",
+		"JobCancelled",
+	}
+}
+
+func resourceFeatureSet() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a version of a Feature Set of an Azure ML Feature Store, i.e. a group of features " +
+			"computed by the transformation code of its specification. The feature set container is created together " +
+			"with its first version, and it is deleted when its last version is deleted.",
+
+		CreateContext: resourceFeatureSetCreate,
+		ReadContext:   resourceFeatureSetRead,
+		UpdateContext: resourceFeatureSetUpdate,
+		DeleteContext: resourceFeatureSetDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceAssetVersionAs("featuresets", "featurestore_name"),
+		},
+
+		CustomizeDiff: customizeDiffAssetVersion,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Feature Store to which the feature set belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"featurestore_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Feature Store to which the feature set belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the feature set.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The version of the feature set. Required unless `auto_increment` is `true`, in which case " +
+					"it is assigned by Azure ML.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"auto_increment": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Should the version be assigned automatically, incrementing the latest version of the " +
+					"feature set? When `true`, any change to the immutable arguments registers a new version.",
+				ForceNew: true,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the feature set version.",
+			},
+			"specification_path": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The URI of the folder containing the `FeatureSetSpec.yaml` specification of the feature set, " +
+					"e.g. `azureml://datastores/<datastore>/paths/<path>`.",
+				ForceNew:         true,
+				ValidateFunc:     IsValidDataPath,
+				DiffSuppressFunc: suppressEquivalentDatastoreUriDiff("featurestore_name"),
+			},
+			"entities": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				ForceNew: true,
+				Description: "The entities of the feature set, in the `azureml:<name>:<version>` form exported by the " +
+					"`asset_id` attribute of `azureml_featurestore_entity`.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+			"materialization": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The settings of the materialization of the feature set into the stores of the feature store.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"store_type": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: fmt.Sprintf(
+								"The stores into which the feature set is materialized. Possible values are: %+q.",
+								GetAllowedMaterializationStoreTypes(),
+							),
+							ValidateFunc: validation.StringInSlice(GetAllowedMaterializationStoreTypes(), false),
+						},
+						"instance_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The instance type of the serverless Spark compute running the materialization jobs (e.g. `standard_e8s_v3`).",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"spark_configuration": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "The Spark configuration of the materialization jobs.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+						"schedule": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "The recurrence of the materialization jobs. If not specified, the feature set is materialized only on demand.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"frequency": {
+										Type:     schema.TypeString,
+										Required: true,
+										Description: fmt.Sprintf(
+											"The unit of the interval between the runs. Possible values are: %+q.",
+											GetAllowedRecurrenceFrequencies(),
+										),
+										ValidateFunc: validation.StringInSlice(GetAllowedRecurrenceFrequencies(), false),
+									},
+									"interval": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										Description:  "The number of `frequency` units between the runs.",
+										ValidateFunc: validation.IntAtLeast(1),
+									},
+									"time_zone": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "UTC",
+										Description:  "The time zone of `start_time`, as a Windows time zone name (e.g. `W. Europe Standard Time`).",
+										ValidateFunc: validation.StringIsNotEmpty,
+									},
+									"start_time": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										Computed:         true,
+										Description:      "The time of the first run, in RFC 3339 format without offset (e.g. `2024-01-01T08:00:00`).",
+										ValidateFunc:     isValidScheduleTime,
+										DiffSuppressFunc: suppressEquivalentScheduleTimeDiff,
+									},
+								},
+							},
+						},
+						"notification_emails": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The email addresses notified of the events of the materialization jobs.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+						},
+						"notification_events": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Description: fmt.Sprintf(
+								"The events of the materialization jobs notified to `notification_emails`. Possible values are: %+q.",
+								GetAllowedMaterializationNotificationEvents(),
+							),
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(GetAllowedMaterializationNotificationEvents(), false),
+							},
+						},
+					},
+				},
+			},
+			"properties": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The properties of the feature set version. Properties cannot be changed once the version is registered.",
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the feature set version.",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the feature set version.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"stage": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The lifecycle stage of the feature set version (e.g. `Development` or `Production`).",
+			},
+			"is_archived": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Is the feature set version archived?",
+			},
+			"asset_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reference to the feature set version in the `azureml:<name>:<version>` form.",
+			},
+		},
+	}
+}
+
+func resourceFeatureSetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	featureStoreName := d.Get("featurestore_name").(string)
+	name := d.Get("name").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, featureStoreName, "featuresets", name)
+	if err := ensureAssetContainer(ctx, client.arm, containerPath, &assetContainerResource{}); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create feature set %s: %w", name, err))
+	}
+
+	version := d.Get("version").(string)
+	if d.Get("auto_increment").(bool) {
+		v, err := nextAssetVersion(ctx, client.arm, containerPath)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		version = v
+	}
+
+	path := assetVersionPath(containerPath, version)
+	if err := putProvisionedAssetVersion(ctx, client.arm, path, resourceFeatureSetGetResourceData(d), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(path)
+	if err := d.Set("version", version); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceFeatureSetRead(ctx, d, meta)
+}
+
+func resourceFeatureSetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	featureStoreName := d.Get("featurestore_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, featureStoreName, "featuresets", name)
+	featureSet := new(featureSetVersionResource)
+	err := client.arm.get(ctx, assetVersionPath(containerPath, version), featureSet)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading feature set %s version %s", name, version),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(featureSet.Id)
+	return resourceFeatureSetSetResourceData(d, featureSet)
+}
+
+func resourceFeatureSetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	featureStoreName := d.Get("featurestore_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	// Only the mutable properties differ from the registered version, hence the whole version can be submitted.
+	containerPath := assetContainerPath(client.arm, resourceGroupName, featureStoreName, "featuresets", name)
+	path := assetVersionPath(containerPath, version)
+	if err := putProvisionedAssetVersion(ctx, client.arm, path, resourceFeatureSetGetResourceData(d), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceFeatureSetRead(ctx, d, meta)
+}
+
+func resourceFeatureSetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	featureStoreName := d.Get("featurestore_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, featureStoreName, "featuresets", name)
+	err := deleteAssetVersion(ctx, client.arm, containerPath, version)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting feature set %s version %s.", name, version),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceFeatureSetGetResourceData(d *schema.ResourceData) *featureSetVersionResource {
+	return &featureSetVersionResource{
+		Properties: featureSetVersionProperties{
+			Specification:           &featureSetSpecification{Path: d.Get("specification_path").(string)},
+			Entities:                expandStringList(d.Get("entities").([]interface{})),
+			MaterializationSettings: expandMaterializationSettings(d.Get("materialization").([]interface{})),
+			Properties:              expandStringMap(d.Get("properties").(map[string]interface{})),
+			Description:             d.Get("description").(string),
+			Tags:                    expandStringMap(d.Get("tags").(map[string]interface{})),
+			Stage:                   d.Get("stage").(string),
+			IsArchived:              d.Get("is_archived").(bool),
+		},
+	}
+}
+
+func resourceFeatureSetSetResourceData(d *schema.ResourceData, featureSet *featureSetVersionResource) diag.Diagnostics {
+	props := featureSet.Properties
+	if props.Specification != nil {
+		if err := d.Set("specification_path", props.Specification.Path); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("entities", props.Entities); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("materialization", flattenMaterializationSettings(props.MaterializationSettings)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("properties", props.Properties); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", props.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("stage", props.Stage); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_archived", props.IsArchived); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("asset_id", fmt.Sprintf("azureml:%s:%s", d.Get("name").(string), d.Get("version").(string))); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func expandMaterializationSettings(l []interface{}) *materializationSettings {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	data := l[0].(map[string]interface{})
+	settings := &materializationSettings{
+		StoreType:          data["store_type"].(string),
+		SparkConfiguration: expandStringMap(data["spark_configuration"].(map[string]interface{})),
+	}
+	if instanceType := data["instance_type"].(string); instanceType != "" {
+		settings.Resource = &materializationComputeResource{InstanceType: instanceType}
+	}
+	if s := data["schedule"].([]interface{}); len(s) > 0 && s[0] != nil {
+		schedule := s[0].(map[string]interface{})
+		settings.Schedule = &scheduleTrigger{
+			TriggerType: "Recurrence",
+			Frequency:   schedule["frequency"].(string),
+			Interval:    schedule["interval"].(int),
+			TimeZone:    schedule["time_zone"].(string),
+			StartTime:   schedule["start_time"].(string),
+		}
+	}
+	emails := expandStringList(data["notification_emails"].([]interface{}))
+	events := expandStringList(data["notification_events"].(*schema.Set).List())
+	if len(emails) > 0 || len(events) > 0 {
+		settings.Notification = &materializationNotification{Emails: emails, EmailOn: events}
+	}
+	return settings
+}
+
+func flattenMaterializationSettings(settings *materializationSettings) []interface{} {
+	if settings == nil {
+		return make([]interface{}, 0)
+	}
+	data := map[string]interface{}{
+		"store_type":          settings.StoreType,
+		"instance_type":       "",
+		"spark_configuration": settings.SparkConfiguration,
+		"schedule":            make([]interface{}, 0, 1),
+		"notification_emails": make([]interface{}, 0),
+		"notification_events": make([]interface{}, 0),
+	}
+	if settings.Resource != nil {
+		data["instance_type"] = settings.Resource.InstanceType
+	}
+	if s := settings.Schedule; s != nil {
+		data["schedule"] = []interface{}{
+			map[string]interface{}{
+				"frequency":  s.Frequency,
+				"interval":   s.Interval,
+				"time_zone":  s.TimeZone,
+				"start_time": s.StartTime,
+			},
+		}
+	}
+	if n := settings.Notification; n != nil {
+		emails := make([]interface{}, len(n.Emails))
+		for i, email := range n.Emails {
+			emails[i] = email
+		}
+		events := make([]interface{}, len(n.EmailOn))
+		for i, event := range n.EmailOn {
+			events[i] = event
+		}
+		data["notification_emails"] = emails
+		data["notification_events"] = events
+	}
+	return []interface{}{data}
+}
+
+type featureSetVersionResource struct {
+	Id         string                      `json:"id,omitempty"`
+	Name       string                      `json:"name,omitempty"`
+	Properties featureSetVersionProperties `json:"properties"`
+}
+
+type featureSetVersionProperties struct {
+	Specification           *featureSetSpecification `json:"specification"`
+	Entities                []string                 `json:"entities"`
+	MaterializationSettings *materializationSettings `json:"materializationSettings,omitempty"`
+	Properties              map[string]string        `json:"properties,omitempty"`
+	Description             string                   `json:"description,omitempty"`
+	Tags                    map[string]string        `json:"tags,omitempty"`
+	Stage                   string                   `json:"stage,omitempty"`
+	IsArchived              bool                     `json:"isArchived"`
+	ProvisioningState       string                   `json:"provisioningState,omitempty"`
+}
+
+type featureSetSpecification struct {
+	Path string `json:"path"`
+}
+
+type materializationSettings struct {
+	StoreType          string                          `json:"storeType"`
+	Schedule           *scheduleTrigger                `json:"schedule,omitempty"`
+	Notification       *materializationNotification    `json:"notification,omitempty"`
+	Resource           *materializationComputeResource `json:"resource,omitempty"`
+	SparkConfiguration map[string]string               `json:"sparkConfiguration,omitempty"`
+}
+
+type materializationNotification struct {
+	Emails  []string `json:"emails,omitempty"`
+	EmailOn []string `json:"emailOn,omitempty"`
+}
+
+type materializationComputeResource struct {
+	InstanceType string `json:"instanceType"`
+}
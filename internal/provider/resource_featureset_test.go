@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"reflect"
+	"testing"
+)
+
+func TestExpandFlattenMaterializationSettings(t *testing.T) {
+	data := []interface{}{
+		map[string]interface{}{
+			"store_type":          "OnlineAndOffline",
+			"instance_type":       "standard_e8s_v3",
+			"spark_configuration": map[string]interface{}{"spark.driver.cores": "4"},
+			"schedule": []interface{}{
+				map[string]interface{}{
+					"frequency":  "Day",
+					"interval":   1,
+					"time_zone":  "UTC",
+					"start_time": "2024-01-01T08:00:00",
+				},
+			},
+			"notification_emails": []interface{}{"team@example.com"},
+			"notification_events": schema.NewSet(schema.HashString, []interface{}{"JobFailed"}),
+		},
+	}
+
+	settings := expandMaterializationSettings(data)
+	if settings.StoreType != "OnlineAndOffline" || settings.Resource.InstanceType != "standard_e8s_v3" {
+		t.Errorf("unexpected settings %+v", settings)
+	}
+	if settings.Schedule.TriggerType != "Recurrence" || settings.Schedule.Frequency != "Day" {
+		t.Errorf("unexpected schedule %+v", settings.Schedule)
+	}
+
+	flattened := flattenMaterializationSettings(settings)[0].(map[string]interface{})
+	expected := data[0].(map[string]interface{})
+	for _, k := range []string{"store_type", "instance_type", "schedule", "notification_emails"} {
+		if !reflect.DeepEqual(flattened[k], expected[k]) {
+			t.Errorf("unexpected %s %v, expected %v", k, flattened[k], expected[k])
+		}
+	}
+	if !reflect.DeepEqual(flattened["notification_events"], []interface{}{"JobFailed"}) {
+		t.Errorf("unexpected notification events %v", flattened["notification_events"])
+	}
+	if !reflect.DeepEqual(flattened["spark_configuration"], map[string]string{"spark.driver.cores": "4"}) {
+		t.Errorf("unexpected spark configuration %v", flattened["spark_configuration"])
+	}
+}
+
+func TestExpandMaterializationSettingsMinimal(t *testing.T) {
+	data := []interface{}{
+		map[string]interface{}{
+			"store_type":          "Offline",
+			"instance_type":       "",
+			"spark_configuration": map[string]interface{}{},
+			"schedule":            []interface{}{},
+			"notification_emails": []interface{}{},
+			"notification_events": schema.NewSet(schema.HashString, nil),
+		},
+	}
+	settings := expandMaterializationSettings(data)
+	if settings.Resource != nil || settings.Schedule != nil || settings.Notification != nil {
+		t.Errorf("unexpected optional settings %+v", settings)
+	}
+	if expandMaterializationSettings(nil) != nil {
+		t.Errorf("expected no settings without materialization block")
+	}
+}
@@ -0,0 +1,606 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	featureStoreOfflineConnectionName = "OfflineStoreConnectionName"
+	featureStoreOnlineConnectionName  = "OnlineStoreConnectionName"
+)
+
+func resourceFeatureStore() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages an Azure ML managed Feature Store, i.e. an Azure ML Workspace of kind `FeatureStore`, " +
+			"together with the connections to its offline and online stores.",
+
+		CreateContext: resourceFeatureStoreCreate,
+		ReadContext:   resourceFeatureStoreRead,
+		UpdateContext: resourceFeatureStoreUpdate,
+		DeleteContext: resourceFeatureStoreDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importFeatureStore,
+		},
+
+		CustomizeDiff: resourceFeatureStoreCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group in which the feature store is created.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The name of the feature store. It must be between 3 and 33 characters long, start with a " +
+					"letter or a digit and contain only letters, digits, hyphens and underscores.",
+				ForceNew: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_-]{2,32}$"),
+					"the name must be between 3 and 33 characters long, start with a letter or a digit and contain "+
+						"only letters, digits, hyphens and underscores",
+				),
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the feature store.",
+			},
+			"location": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The Azure region of the feature store.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The display name of the feature store.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the feature store.",
+			},
+			"identity": managedIdentitySchema(false),
+			"storage_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the storage account associated with the feature store.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"key_vault_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the key vault associated with the feature store.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"application_insights_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The ID of the Application Insights associated with the feature store.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"container_registry_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The ID of the container registry associated with the feature store.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"public_network_access": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Enabled",
+				Description: fmt.Sprintf(
+					"Is the feature store reachable from the public network? Possible values are: %+q.",
+					GetAllowedPublicNetworkAccessValues(),
+				),
+				ValidateFunc: validation.StringInSlice(GetAllowedPublicNetworkAccessValues(), false),
+			},
+			"spark_runtime_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "3.4",
+				Description:  "The version of the Spark runtime used for materializing the feature sets.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"materialization_identity": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "The user assigned managed identity used for materializing the feature sets into the " +
+					"offline and online stores. It must be one of the `identity_ids` of `identity`.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The ID of the user assigned managed identity.",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"client_id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The client ID of the user assigned managed identity.",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+			"offline_store": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Description:  "The offline store of the feature store, where the materialized features are kept for training.",
+				RequiredWith: []string{"materialization_identity"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"target": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: "The ID of the Azure Data Lake Storage Gen2 container used as offline store, " +
+								"e.g. `/subscriptions/<subscription-id>/resourceGroups/<resource-group>/providers/" +
+								"Microsoft.Storage/storageAccounts/<account>/blobServices/default/containers/<container>`.",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+			"online_store": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Description:  "The online store of the feature store, where the materialized features are kept for inference.",
+				RequiredWith: []string{"materialization_identity"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"target": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The ID of the Azure Cache for Redis used as online store.",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the feature store.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"workspace_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The immutable ID assigned by Azure ML to the feature store.",
+			},
+			"discovery_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The discovery URL of the feature store.",
+			},
+			"ml_flow_tracking_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The MLflow tracking URI of the feature store.",
+			},
+		},
+	}
+}
+
+// resourceFeatureStoreCustomizeDiff checks that the materialization identity is assigned to the feature store.
+func resourceFeatureStoreCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("identity") || !d.NewValueKnown("materialization_identity") {
+		return nil
+	}
+	return validateMaterializationIdentity(
+		d.Get("identity").([]interface{}),
+		d.Get("materialization_identity").([]interface{}),
+	)
+}
+
+// validateMaterializationIdentity checks that the materialization identity, if any, is one of the user assigned
+// identities of the feature store.
+func validateMaterializationIdentity(identity, materializationIdentity []interface{}) error {
+	if len(materializationIdentity) == 0 || materializationIdentity[0] == nil {
+		return nil
+	}
+	id := materializationIdentity[0].(map[string]interface{})["id"].(string)
+	if id == "" {
+		// Unknown until apply
+		return nil
+	}
+	if len(identity) > 0 && identity[0] != nil {
+		for _, v := range identity[0].(map[string]interface{})["identity_ids"].(*schema.Set).List() {
+			if strings.EqualFold(v.(string), id) {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("the materialization identity %q must be one of the %q of %q", id, "identity_ids", "identity")
+}
+
+func resourceFeatureStoreCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+	path := client.arm.workspaceId(resourceGroupName, name)
+
+	// The connections to the stores can only be created once the feature store exists, hence the feature
+	// store settings referencing them are set afterwards
+	featureStore := resourceFeatureStoreGetResourceData(d)
+	featureStore.Properties.FeatureStoreSettings.OfflineStoreConnectionName = ""
+	featureStore.Properties.FeatureStoreSettings.OnlineStoreConnectionName = ""
+	resp, err := client.arm.put(ctx, path, featureStore, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(path)
+	if err := waitForProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for feature store %s to be created: %w", name, err))
+	}
+
+	if diags := updateFeatureStoreStores(ctx, d, client.arm, path, d.Timeout(schema.TimeoutCreate)); diags.HasError() {
+		return diags
+	}
+	return resourceFeatureStoreRead(ctx, d, meta)
+}
+
+func resourceFeatureStoreRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+	path := client.arm.workspaceId(resourceGroupName, name)
+
+	featureStore := new(featureStoreResource)
+	err := getProvisioned(ctx, client.arm, path, featureStore)
+	if err != nil {
+		if diags, ok := failedProvisioningDiagnostics(d, fmt.Sprintf("feature store %s", name), err); ok {
+			return diags
+		}
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading feature store %s", name),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+	if !strings.EqualFold(featureStore.Kind, "FeatureStore") {
+		return diag.Errorf("workspace %s is not a feature store, its kind is %q", name, featureStore.Kind)
+	}
+
+	d.SetId(featureStore.Id)
+	if diags := resourceFeatureStoreSetResourceData(d, featureStore); diags.HasError() {
+		return diags
+	}
+
+	// The targets of the stores are the ones of the connections referenced by the feature store settings, while
+	// the materialization identity is not read since the credentials of the connections are not returned
+	settings := featureStore.Properties.FeatureStoreSettings
+	for attribute, connectionName := range map[string]string{
+		"offline_store": settings.OfflineStoreConnectionName,
+		"online_store":  settings.OnlineStoreConnectionName,
+	} {
+		store := make([]interface{}, 0, 1)
+		if connectionName != "" {
+			connection := new(workspaceConnectionResource)
+			err := client.arm.get(ctx, workspaceConnectionPath(client.arm, resourceGroupName, name, connectionName), connection)
+			if err != nil {
+				var notFoundErr *resourceNotFoundError
+				if !errors.As(err, &notFoundErr) {
+					return diag.FromErr(err)
+				}
+			} else {
+				store = append(store, map[string]interface{}{"target": connection.Properties.Target})
+			}
+		}
+		if err := d.Set(attribute, store); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func resourceFeatureStoreUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+	path := client.arm.workspaceId(resourceGroupName, name)
+
+	if d.HasChanges("display_name", "description", "identity", "public_network_access", "tags") {
+		featureStore := resourceFeatureStoreGetResourceData(d)
+		update := &featureStoreResource{
+			Identity: featureStore.Identity,
+			Tags:     featureStore.Tags,
+			Properties: featureStoreProperties{
+				FriendlyName:        featureStore.Properties.FriendlyName,
+				Description:         featureStore.Properties.Description,
+				PublicNetworkAccess: featureStore.Properties.PublicNetworkAccess,
+			},
+		}
+		if update.Tags == nil {
+			update.Tags = map[string]string{}
+		}
+		resp, err := client.arm.patch(ctx, path, update, nil)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err := waitForProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(fmt.Errorf("error waiting for feature store %s to be updated: %w", name, err))
+		}
+	}
+
+	if d.HasChanges("spark_runtime_version", "materialization_identity", "offline_store", "online_store") {
+		if diags := updateFeatureStoreStores(ctx, d, client.arm, path, d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+			return diags
+		}
+	}
+	return resourceFeatureStoreRead(ctx, d, meta)
+}
+
+func resourceFeatureStoreDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+	path := client.arm.workspaceId(resourceGroupName, name)
+
+	resp, err := client.arm.delete(ctx, path)
+	if err == nil {
+		err = waitForDeletion(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutDelete))
+	}
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting feature store %s.", name),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+// updateFeatureStoreStores creates or updates the connections to the offline and online stores of the feature
+// store at the path provided as argument, references them in its settings and finally deletes the connections
+// to the stores which have been removed.
+func updateFeatureStoreStores(ctx context.Context, d *schema.ResourceData, c *armClient, path string, timeout time.Duration) diag.Diagnostics {
+	resourceGroupName := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+
+	var clientId, identityId string
+	if l := d.Get("materialization_identity").([]interface{}); len(l) > 0 && l[0] != nil {
+		identity := l[0].(map[string]interface{})
+		clientId = identity["client_id"].(string)
+		identityId = identity["id"].(string)
+	}
+
+	settings := expandFeatureStoreSettings(d)
+	var removed []string
+	for _, store := range []struct {
+		attribute      string
+		description    string
+		connectionName string
+		category       string
+	}{
+		{"offline_store", "offline store", featureStoreOfflineConnectionName, "AzureDataLakeGen2"},
+		{"online_store", "online store", featureStoreOnlineConnectionName, "Redis"},
+	} {
+		connectionPath := workspaceConnectionPath(c, resourceGroupName, name, store.connectionName)
+		l := d.Get(store.attribute).([]interface{})
+		if len(l) == 0 || l[0] == nil {
+			removed = append(removed, connectionPath)
+			continue
+		}
+		connection := &workspaceConnectionResource{
+			Properties: workspaceConnectionProperties{
+				AuthType:      "ManagedIdentity",
+				Category:      store.category,
+				Target:        l[0].(map[string]interface{})["target"].(string),
+				IsSharedToAll: true,
+				Credentials:   map[string]string{"clientId": clientId, "resourceId": identityId},
+			},
+		}
+		if _, err := c.put(ctx, connectionPath, connection, nil); err != nil {
+			return diag.FromErr(fmt.Errorf(
+				"unable to create the connection to the %s of feature store %s: %w",
+				store.description,
+				name,
+				err,
+			))
+		}
+	}
+
+	update := &featureStoreResource{Properties: featureStoreProperties{FeatureStoreSettings: settings}}
+	resp, err := c.patch(ctx, path, update, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := waitForProvisioning(ctx, c, resp, path, timeout); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for feature store %s to be updated: %w", name, err))
+	}
+
+	for _, connectionPath := range removed {
+		if _, err := c.delete(ctx, connectionPath); err != nil {
+			var notFoundErr *resourceNotFoundError
+			if !errors.As(err, &notFoundErr) {
+				return diag.FromErr(err)
+			}
+		}
+	}
+	return nil
+}
+
+// importFeatureStore sets the resource group name and the name of the imported feature store from its ID.
+func importFeatureStore(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceGroupName, name, segments, err := splitWorkspaceId(d.Id())
+	if err != nil || len(segments) != 0 {
+		return nil, fmt.Errorf(
+			"invalid ID %q, expected format is "+
+				"/subscriptions/<subscription-id>/resourceGroups/<resource-group>/providers/"+
+				"Microsoft.MachineLearningServices/workspaces/<feature-store>",
+			d.Id(),
+		)
+	}
+	if err := d.Set("resource_group_name", resourceGroupName); err != nil {
+		return nil, err
+	}
+	if err := d.Set("name", name); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceFeatureStoreGetResourceData(d *schema.ResourceData) *featureStoreResource {
+	featureStore := &featureStoreResource{
+		Kind:     "FeatureStore",
+		Location: d.Get("location").(string),
+		Identity: expandManagedIdentity(d.Get("identity").([]interface{})),
+		Tags:     expandStringMap(d.Get("tags").(map[string]interface{})),
+		Properties: featureStoreProperties{
+			FriendlyName:         d.Get("display_name").(string),
+			Description:          d.Get("description").(string),
+			StorageAccount:       d.Get("storage_account_id").(string),
+			KeyVault:             d.Get("key_vault_id").(string),
+			ApplicationInsights:  d.Get("application_insights_id").(string),
+			ContainerRegistry:    d.Get("container_registry_id").(string),
+			PublicNetworkAccess:  d.Get("public_network_access").(string),
+			FeatureStoreSettings: expandFeatureStoreSettings(d),
+		},
+	}
+	if featureStore.Identity == nil {
+		featureStore.Identity = &armManagedIdentity{Type: "SystemAssigned"}
+	}
+	return featureStore
+}
+
+func expandFeatureStoreSettings(d *schema.ResourceData) *featureStoreSettings {
+	settings := &featureStoreSettings{
+		ComputeRuntime: &featureStoreComputeRuntime{SparkRuntimeVersion: d.Get("spark_runtime_version").(string)},
+	}
+	if l := d.Get("offline_store").([]interface{}); len(l) > 0 && l[0] != nil {
+		settings.OfflineStoreConnectionName = featureStoreOfflineConnectionName
+	}
+	if l := d.Get("online_store").([]interface{}); len(l) > 0 && l[0] != nil {
+		settings.OnlineStoreConnectionName = featureStoreOnlineConnectionName
+	}
+	return settings
+}
+
+func resourceFeatureStoreSetResourceData(d *schema.ResourceData, featureStore *featureStoreResource) diag.Diagnostics {
+	if err := d.Set("location", featureStore.Location); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("identity", flattenManagedIdentity(featureStore.Identity)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", featureStore.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+
+	props := featureStore.Properties
+	if err := d.Set("display_name", props.FriendlyName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("storage_account_id", props.StorageAccount); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("key_vault_id", props.KeyVault); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("application_insights_id", props.ApplicationInsights); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("container_registry_id", props.ContainerRegistry); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("public_network_access", props.PublicNetworkAccess); err != nil {
+		return diag.FromErr(err)
+	}
+	if settings := props.FeatureStoreSettings; settings != nil && settings.ComputeRuntime != nil {
+		if err := d.Set("spark_runtime_version", settings.ComputeRuntime.SparkRuntimeVersion); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("workspace_id", props.WorkspaceId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("discovery_url", props.DiscoveryUrl); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ml_flow_tracking_uri", props.MlFlowTrackingUri); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+type featureStoreResource struct {
+	Id         string                 `json:"id,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Kind       string                 `json:"kind,omitempty"`
+	Location   string                 `json:"location,omitempty"`
+	Identity   *armManagedIdentity    `json:"identity,omitempty"`
+	Tags       map[string]string      `json:"tags,omitempty"`
+	Properties featureStoreProperties `json:"properties"`
+}
+
+type featureStoreProperties struct {
+	FriendlyName         string                `json:"friendlyName,omitempty"`
+	Description          string                `json:"description,omitempty"`
+	StorageAccount       string                `json:"storageAccount,omitempty"`
+	KeyVault             string                `json:"keyVault,omitempty"`
+	ApplicationInsights  string                `json:"applicationInsights,omitempty"`
+	ContainerRegistry    string                `json:"containerRegistry,omitempty"`
+	PublicNetworkAccess  string                `json:"publicNetworkAccess,omitempty"`
+	FeatureStoreSettings *featureStoreSettings `json:"featureStoreSettings,omitempty"`
+	WorkspaceId          string                `json:"workspaceId,omitempty"`
+	DiscoveryUrl         string                `json:"discoveryUrl,omitempty"`
+	MlFlowTrackingUri    string                `json:"mlFlowTrackingUri,omitempty"`
+	ProvisioningState    string                `json:"provisioningState,omitempty"`
+}
+
+type featureStoreSettings struct {
+	ComputeRuntime             *featureStoreComputeRuntime `json:"computeRuntime,omitempty"`
+	OfflineStoreConnectionName string                      `json:"offlineStoreConnectionName"`
+	OnlineStoreConnectionName  string                      `json:"onlineStoreConnectionName"`
+}
+
+type featureStoreComputeRuntime struct {
+	SparkRuntimeVersion string `json:"sparkRuntimeVersion,omitempty"`
+}
@@ -0,0 +1,331 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+func GetAllowedFeatureDataTypes() []string {
+	return []string{
+		"String",
+		"Integer",
+		"Long",
+		"Float",
+		"Double",
+		"Binary",
+		"Datetime",
+		"Boolean",
+	}
+}
+
+func resourceFeatureStoreEntity() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a version of an Entity of an Azure ML Feature Store, i.e. the index columns shared by " +
+			"the feature sets describing the same logical object (e.g. a customer). The entity container is created " +
+			"together with its first version, and it is deleted when its last version is deleted.",
+
+		CreateContext: resourceFeatureStoreEntityCreate,
+		ReadContext:   resourceFeatureStoreEntityRead,
+		UpdateContext: resourceFeatureStoreEntityUpdate,
+		DeleteContext: resourceFeatureStoreEntityDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceAssetVersionAs("featurestoreEntities", "featurestore_name"),
+		},
+
+		CustomizeDiff: customizeDiffAssetVersion,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Feature Store to which the entity belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"featurestore_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Feature Store to which the entity belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the entity.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The version of the entity. Required unless `auto_increment` is `true`, in which case " +
+					"it is assigned by Azure ML.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"auto_increment": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Should the version be assigned automatically, incrementing the latest version of the " +
+					"entity? When `true`, any change to the immutable arguments registers a new version.",
+				ForceNew: true,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the entity version.",
+			},
+			"index_column": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				ForceNew:    true,
+				Description: "The index columns of the entity, which join the feature sets referencing it.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The name of the column.",
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: fmt.Sprintf(
+								"The data type of the column. Possible values are: %+q.",
+								GetAllowedFeatureDataTypes(),
+							),
+							ForceNew:     true,
+							ValidateFunc: validation.StringInSlice(GetAllowedFeatureDataTypes(), false),
+						},
+					},
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the entity version.",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the entity version.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"stage": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The lifecycle stage of the entity version (e.g. `Development` or `Production`).",
+			},
+			"is_archived": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Is the entity version archived?",
+			},
+			"asset_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reference to the entity version in the `azureml:<name>:<version>` form used by feature sets.",
+			},
+		},
+	}
+}
+
+func resourceFeatureStoreEntityCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	featureStoreName := d.Get("featurestore_name").(string)
+	name := d.Get("name").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, featureStoreName, "featurestoreEntities", name)
+	if err := ensureAssetContainer(ctx, client.arm, containerPath, &assetContainerResource{}); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create entity %s: %w", name, err))
+	}
+
+	version := d.Get("version").(string)
+	if d.Get("auto_increment").(bool) {
+		v, err := nextAssetVersion(ctx, client.arm, containerPath)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		version = v
+	}
+
+	path := assetVersionPath(containerPath, version)
+	if err := putProvisionedAssetVersion(ctx, client.arm, path, resourceFeatureStoreEntityGetResourceData(d), d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(path)
+	if err := d.Set("version", version); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceFeatureStoreEntityRead(ctx, d, meta)
+}
+
+func resourceFeatureStoreEntityRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	featureStoreName := d.Get("featurestore_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, featureStoreName, "featurestoreEntities", name)
+	entity := new(featureStoreEntityVersionResource)
+	err := client.arm.get(ctx, assetVersionPath(containerPath, version), entity)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading entity %s version %s", name, version),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(entity.Id)
+	return resourceFeatureStoreEntitySetResourceData(d, entity)
+}
+
+func resourceFeatureStoreEntityUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	featureStoreName := d.Get("featurestore_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	// Only the mutable properties differ from the registered version, hence the whole version can be submitted.
+	containerPath := assetContainerPath(client.arm, resourceGroupName, featureStoreName, "featurestoreEntities", name)
+	path := assetVersionPath(containerPath, version)
+	if err := putProvisionedAssetVersion(ctx, client.arm, path, resourceFeatureStoreEntityGetResourceData(d), d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceFeatureStoreEntityRead(ctx, d, meta)
+}
+
+func resourceFeatureStoreEntityDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	featureStoreName := d.Get("featurestore_name").(string)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := assetContainerPath(client.arm, resourceGroupName, featureStoreName, "featurestoreEntities", name)
+	err := deleteAssetVersion(ctx, client.arm, containerPath, version)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting entity %s version %s.", name, version),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceFeatureStoreEntityGetResourceData(d *schema.ResourceData) *featureStoreEntityVersionResource {
+	return &featureStoreEntityVersionResource{
+		Properties: featureStoreEntityVersionProperties{
+			IndexColumns: expandFeatureIndexColumns(d.Get("index_column").([]interface{})),
+			Description:  d.Get("description").(string),
+			Tags:         expandStringMap(d.Get("tags").(map[string]interface{})),
+			Stage:        d.Get("stage").(string),
+			IsArchived:   d.Get("is_archived").(bool),
+		},
+	}
+}
+
+func resourceFeatureStoreEntitySetResourceData(d *schema.ResourceData, entity *featureStoreEntityVersionResource) diag.Diagnostics {
+	props := entity.Properties
+	if err := d.Set("index_column", flattenFeatureIndexColumns(props.IndexColumns)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", props.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("stage", props.Stage); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_archived", props.IsArchived); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("asset_id", fmt.Sprintf("azureml:%s:%s", d.Get("name").(string), d.Get("version").(string))); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func expandFeatureIndexColumns(l []interface{}) []featureIndexColumn {
+	columns := make([]featureIndexColumn, 0, len(l))
+	for _, v := range l {
+		column := v.(map[string]interface{})
+		columns = append(columns, featureIndexColumn{
+			ColumnName: column["name"].(string),
+			DataType:   column["type"].(string),
+		})
+	}
+	return columns
+}
+
+func flattenFeatureIndexColumns(columns []featureIndexColumn) []interface{} {
+	result := make([]interface{}, len(columns))
+	for i, column := range columns {
+		result[i] = map[string]interface{}{
+			"name": column.ColumnName,
+			"type": column.DataType,
+		}
+	}
+	return result
+}
+
+type featureStoreEntityVersionResource struct {
+	Id         string                              `json:"id,omitempty"`
+	Name       string                              `json:"name,omitempty"`
+	Properties featureStoreEntityVersionProperties `json:"properties"`
+}
+
+type featureStoreEntityVersionProperties struct {
+	IndexColumns      []featureIndexColumn `json:"indexColumns"`
+	Description       string               `json:"description,omitempty"`
+	Tags              map[string]string    `json:"tags,omitempty"`
+	Stage             string               `json:"stage,omitempty"`
+	IsArchived        bool                 `json:"isArchived"`
+	ProvisioningState string               `json:"provisioningState,omitempty"`
+}
+
+type featureIndexColumn struct {
+	ColumnName string `json:"columnName"`
+	DataType   string `json:"dataType"`
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"testing"
+)
+
+func TestValidateMaterializationIdentity(t *testing.T) {
+	identityId := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.ManagedIdentity/userAssignedIdentities/mi"
+	identity := []interface{}{
+		map[string]interface{}{
+			"type":         "SystemAssigned,UserAssigned",
+			"identity_ids": schema.NewSet(schema.HashString, []interface{}{identityId}),
+		},
+	}
+	materializationIdentity := func(id string) []interface{} {
+		return []interface{}{map[string]interface{}{"id": id, "client_id": "client"}}
+	}
+
+	if err := validateMaterializationIdentity(identity, materializationIdentity(identityId)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateMaterializationIdentity(nil, nil); err != nil {
+		t.Errorf("unexpected error without materialization identity: %v", err)
+	}
+	if err := validateMaterializationIdentity(identity, materializationIdentity("")); err != nil {
+		t.Errorf("unexpected error for an unknown materialization identity: %v", err)
+	}
+	if err := validateMaterializationIdentity(identity, materializationIdentity(identityId+"-other")); err == nil {
+		t.Errorf("expected error for a materialization identity not assigned to the feature store")
+	}
+	if err := validateMaterializationIdentity(nil, materializationIdentity(identityId)); err == nil {
+		t.Errorf("expected error for a feature store without user assigned identities")
+	}
+}
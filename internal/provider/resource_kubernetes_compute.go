@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"sort"
+	"time"
+)
+
+func resourceKubernetesCompute() *schema.Resource {
+	return &schema.Resource{
+		Description: "Attaches an existing Azure Kubernetes Service or Azure Arc-enabled Kubernetes cluster to an " +
+			"Azure ML Workspace. The Azure ML extension must be installed on the cluster. Destroying the resource " +
+			"detaches the cluster from the workspace, without deleting it.",
+
+		CreateContext: resourceKubernetesComputeCreate,
+		ReadContext:   resourceKubernetesComputeRead,
+		DeleteContext: detachCompute,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceChild("computes"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: attachedComputeSchema(
+			"Kubernetes compute",
+			"The ID of the Azure Kubernetes Service cluster or of the Azure Arc-enabled Kubernetes cluster.",
+			true,
+			map[string]*schema.Schema{
+				"namespace": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "default",
+					Description:  "The Kubernetes namespace in which the workloads of the workspace are run.",
+					ForceNew:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				"default_instance_type": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Computed: true,
+					Description: "The name of the instance type used by the workloads which do not specify one. It " +
+						"must be one of the names of `instance_type`, if any.",
+					ForceNew:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				"instance_type": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					ForceNew:    true,
+					Description: "The instance types, which define the node selector and the resources of the workloads.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:         schema.TypeString,
+								Required:     true,
+								Description:  "The name of the instance type.",
+								ForceNew:     true,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+							"node_selector": {
+								Type:        schema.TypeMap,
+								Optional:    true,
+								Description: "The labels of the nodes on which the workloads are scheduled.",
+								ForceNew:    true,
+								Elem:        &schema.Schema{Type: schema.TypeString},
+							},
+							"cpu_request": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Default:     "",
+								Description: "The CPU requested by the workloads, in Kubernetes notation (e.g. `500m`).",
+								ForceNew:    true,
+							},
+							"memory_request": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Default:     "",
+								Description: "The memory requested by the workloads, in Kubernetes notation (e.g. `2Gi`).",
+								ForceNew:    true,
+							},
+							"cpu_limit": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Default:     "",
+								Description: "The maximum CPU used by the workloads, in Kubernetes notation.",
+								ForceNew:    true,
+							},
+							"memory_limit": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Default:     "",
+								Description: "The maximum memory used by the workloads, in Kubernetes notation.",
+								ForceNew:    true,
+							},
+							"gpu_limit": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								Default:      0,
+								Description:  "The number of NVIDIA GPUs used by the workloads.",
+								ForceNew:     true,
+								ValidateFunc: validation.IntAtLeast(0),
+							},
+						},
+					},
+				},
+			},
+		),
+	}
+}
+
+func resourceKubernetesComputeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	properties := kubernetesComputeProperties{
+		Namespace:           d.Get("namespace").(string),
+		DefaultInstanceType: d.Get("default_instance_type").(string),
+		InstanceTypes:       expandKubernetesInstanceTypes(d.Get("instance_type").(*schema.Set).List()),
+	}
+	if diags := attachCompute(ctx, d, meta, "Kubernetes", properties); diags.HasError() {
+		return diags
+	}
+	return resourceKubernetesComputeRead(ctx, d, meta)
+}
+
+func resourceKubernetesComputeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	compute, diags := readAttachedCompute(ctx, d, meta, true)
+	if compute == nil {
+		return diags
+	}
+
+	properties := new(kubernetesComputeProperties)
+	if len(compute.Properties.Properties) > 0 {
+		if err := json.Unmarshal(compute.Properties.Properties, properties); err != nil {
+			return diag.FromErr(fmt.Errorf("unable to parse the properties of the Kubernetes compute: %w", err))
+		}
+	}
+	if properties.Namespace != "" {
+		if err := d.Set("namespace", properties.Namespace); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("default_instance_type", properties.DefaultInstanceType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("instance_type", flattenKubernetesInstanceTypes(properties.InstanceTypes)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func expandKubernetesInstanceTypes(l []interface{}) map[string]kubernetesInstanceType {
+	if len(l) == 0 {
+		return nil
+	}
+	instanceTypes := make(map[string]kubernetesInstanceType, len(l))
+	for _, v := range l {
+		data := v.(map[string]interface{})
+		instanceType := kubernetesInstanceType{
+			NodeSelector: expandStringMap(data["node_selector"].(map[string]interface{})),
+			Resources: &kubernetesInstanceTypeResources{
+				Requests: expandKubernetesResourceQuantities(data["cpu_request"].(string), data["memory_request"].(string), 0),
+				Limits:   expandKubernetesResourceQuantities(data["cpu_limit"].(string), data["memory_limit"].(string), data["gpu_limit"].(int)),
+			},
+		}
+		instanceTypes[data["name"].(string)] = instanceType
+	}
+	return instanceTypes
+}
+
+func expandKubernetesResourceQuantities(cpu, memory string, gpu int) map[string]string {
+	quantities := make(map[string]string)
+	if cpu != "" {
+		quantities["cpu"] = cpu
+	}
+	if memory != "" {
+		quantities["memory"] = memory
+	}
+	if gpu > 0 {
+		quantities[kubernetesGpuResourceName] = fmt.Sprint(gpu)
+	}
+	if len(quantities) == 0 {
+		return nil
+	}
+	return quantities
+}
+
+func flattenKubernetesInstanceTypes(instanceTypes map[string]kubernetesInstanceType) []interface{} {
+	names := make([]string, 0, len(instanceTypes))
+	for name := range instanceTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]interface{}, len(names))
+	for i, name := range names {
+		instanceType := instanceTypes[name]
+		data := map[string]interface{}{
+			"name":           name,
+			"node_selector":  instanceType.NodeSelector,
+			"cpu_request":    "",
+			"memory_request": "",
+			"cpu_limit":      "",
+			"memory_limit":   "",
+			"gpu_limit":      0,
+		}
+		if r := instanceType.Resources; r != nil {
+			data["cpu_request"] = r.Requests["cpu"]
+			data["memory_request"] = r.Requests["memory"]
+			data["cpu_limit"] = r.Limits["cpu"]
+			data["memory_limit"] = r.Limits["memory"]
+			var gpu int
+			if _, err := fmt.Sscan(r.Limits[kubernetesGpuResourceName], &gpu); err == nil {
+				data["gpu_limit"] = gpu
+			}
+		}
+		result[i] = data
+	}
+	return result
+}
+
+const kubernetesGpuResourceName = "nvidia.com/gpu"
+
+type kubernetesComputeProperties struct {
+	Namespace           string                            `json:"namespace,omitempty"`
+	DefaultInstanceType string                            `json:"defaultInstanceType,omitempty"`
+	InstanceTypes       map[string]kubernetesInstanceType `json:"instanceTypes,omitempty"`
+}
+
+type kubernetesInstanceType struct {
+	NodeSelector map[string]string                `json:"nodeSelector,omitempty"`
+	Resources    *kubernetesInstanceTypeResources `json:"resources,omitempty"`
+}
+
+type kubernetesInstanceTypeResources struct {
+	Requests map[string]string `json:"requests,omitempty"`
+	Limits   map[string]string `json:"limits,omitempty"`
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKubernetesInstanceTypesRoundTrip(t *testing.T) {
+	instanceTypes := []interface{}{
+		map[string]interface{}{
+			"name":           "gpu",
+			"node_selector":  map[string]interface{}{"agentpool": "gpu"},
+			"cpu_request":    "4",
+			"memory_request": "16Gi",
+			"cpu_limit":      "8",
+			"memory_limit":   "32Gi",
+			"gpu_limit":      1,
+		},
+		map[string]interface{}{
+			"name":           "cpu",
+			"node_selector":  map[string]interface{}{},
+			"cpu_request":    "500m",
+			"memory_request": "",
+			"cpu_limit":      "",
+			"memory_limit":   "",
+			"gpu_limit":      0,
+		},
+	}
+
+	expanded := expandKubernetesInstanceTypes(instanceTypes)
+	gpu := expanded["gpu"]
+	if gpu.Resources.Limits[kubernetesGpuResourceName] != "1" || gpu.NodeSelector["agentpool"] != "gpu" {
+		t.Errorf("unexpected instance type %+v", gpu)
+	}
+	if cpu := expanded["cpu"]; cpu.Resources.Limits != nil || cpu.NodeSelector != nil {
+		t.Errorf("unexpected instance type %+v", cpu)
+	}
+
+	flattened := flattenKubernetesInstanceTypes(expanded)
+	if len(flattened) != 2 {
+		t.Fatalf("unexpected number of instance types %d", len(flattened))
+	}
+	cpu := flattened[0].(map[string]interface{})
+	if cpu["name"] != "cpu" || cpu["cpu_request"] != "500m" || cpu["gpu_limit"] != 0 {
+		t.Errorf("unexpected flattened instance type %v", cpu)
+	}
+	flattenedGpu := flattened[1].(map[string]interface{})
+	if !reflect.DeepEqual(flattenedGpu["node_selector"], map[string]string{"agentpool": "gpu"}) || flattenedGpu["gpu_limit"] != 1 {
+		t.Errorf("unexpected flattened instance type %v", flattenedGpu)
+	}
+}
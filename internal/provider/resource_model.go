@@ -0,0 +1,388 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"sort"
+)
+
+func GetAllowedModelTypes() []string {
+	return []string{
+		"custom_model",
+		"mlflow_model",
+		"triton_model",
+	}
+}
+
+func resourceModel() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a version of a Model of an Azure ML Workspace or Registry. The model container is created " +
+			"together with its first version, and it is deleted when its last version is deleted.",
+
+		CreateContext: resourceModelCreate,
+		ReadContext:   resourceModelRead,
+		UpdateContext: resourceModelUpdate,
+		DeleteContext: resourceModelDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importAssetVersion("models"),
+		},
+
+		CustomizeDiff: resourceModelCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace or Registry to which the model belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The name of the Azure ML Workspace to which the model belongs to. Conflicts with `registry_name`.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"workspace_name", "registry_name"},
+			},
+			"registry_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of the Azure ML Registry to which the model belongs to, for sharing it across " +
+					"workspaces. Conflicts with `workspace_name`.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"workspace_name", "registry_name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the model.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"version": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The version of the model. Required unless `auto_increment` is `true`, in which case " +
+					"it is assigned by Azure ML.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"auto_increment": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Should the version be assigned automatically, incrementing the latest version of the " +
+					"model? When `true`, any change to the immutable arguments registers a new version.",
+				ForceNew: true,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the model version.",
+			},
+			"model_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "custom_model",
+				Description: fmt.Sprintf(
+					"The type of the model. Possible values are: %+q.",
+					GetAllowedModelTypes(),
+				),
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(GetAllowedModelTypes(), false),
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The URI of the model artifacts, e.g. `azureml://datastores/<datastore>/paths/<path>` or " +
+					"`azureml://jobs/<job>/outputs/artifacts/paths/<path>`. Datastore URIs are not supported when " +
+					"`registry_name` is set.",
+				ForceNew:         true,
+				ValidateFunc:     IsValidDataPath,
+				DiffSuppressFunc: suppressEquivalentDatastoreUriDiff("workspace_name"),
+			},
+			"flavor": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The flavors of the model, describing how the model can be loaded.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The name of the flavor (e.g. `python_function`).",
+							ForceNew:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"data": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "The properties of the flavor.",
+							ForceNew:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"properties": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The properties of the model version. Properties cannot be changed once the version is registered.",
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the model version.",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the model version.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"stage": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The lifecycle stage of the model version (e.g. `Development` or `Production`).",
+			},
+			"is_archived": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Is the model version archived?",
+			},
+			"asset_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reference to the model version in the `azureml:<name>:<version>` form used by jobs.",
+			},
+		},
+	}
+}
+
+func resourceModelCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if err := customizeDiffAssetVersion(ctx, d, meta); err != nil {
+		return err
+	}
+	if d.Get("registry_name").(string) == "" || !d.NewValueKnown("path") {
+		return nil
+	}
+	return validateRegistryModelPath(d.Get("path").(string))
+}
+
+// validateRegistryModelPath checks that the path of a model of an Azure ML Registry is not a datastore URI, since
+// datastores belong to a workspace and cannot be resolved by a registry.
+func validateRegistryModelPath(path string) error {
+	if _, err := parseDatastoreUri(path); err == nil {
+		return fmt.Errorf(
+			"%q cannot be a datastore URI when %q is set: datastores belong to a workspace and cannot be resolved by a registry",
+			"path",
+			"registry_name",
+		)
+	}
+	return nil
+}
+
+func resourceModelCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	name := d.Get("name").(string)
+
+	containerPath := resourceAssetContainerPath(client.arm, d, "models")
+	if err := ensureAssetContainer(ctx, client.arm, containerPath, &assetContainerResource{}); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to create model %s: %w", name, err))
+	}
+
+	version := d.Get("version").(string)
+	if d.Get("auto_increment").(bool) {
+		v, err := nextAssetVersion(ctx, client.arm, containerPath)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		version = v
+	}
+
+	path := assetVersionPath(containerPath, version)
+	if err := putAssetVersion(ctx, client.arm, path, resourceModelGetResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(path)
+	if err := d.Set("version", version); err != nil {
+		return diag.FromErr(err)
+	}
+	return resourceModelRead(ctx, d, meta)
+}
+
+func resourceModelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := resourceAssetContainerPath(client.arm, d, "models")
+	model := new(modelVersionResource)
+	err := client.arm.get(ctx, assetVersionPath(containerPath, version), model)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading model %s version %s", name, version),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(model.Id)
+	return resourceModelSetResourceData(d, model)
+}
+
+func resourceModelUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	version := d.Get("version").(string)
+
+	// Only the mutable properties differ from the registered version, hence the whole version can be submitted.
+	containerPath := resourceAssetContainerPath(client.arm, d, "models")
+	if err := putAssetVersion(ctx, client.arm, assetVersionPath(containerPath, version), resourceModelGetResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceModelRead(ctx, d, meta)
+}
+
+func resourceModelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	name := d.Get("name").(string)
+	version := d.Get("version").(string)
+
+	containerPath := resourceAssetContainerPath(client.arm, d, "models")
+	err := deleteAssetVersion(ctx, client.arm, containerPath, version)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting model %s version %s.", name, version),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceModelGetResourceData(d *schema.ResourceData) *modelVersionResource {
+	return &modelVersionResource{
+		Properties: modelVersionProperties{
+			ModelType:   d.Get("model_type").(string),
+			ModelUri:    d.Get("path").(string),
+			Flavors:     schemaSetToModelFlavors(d.Get("flavor").(*schema.Set)),
+			Properties:  expandStringMap(d.Get("properties").(map[string]interface{})),
+			Description: d.Get("description").(string),
+			Tags:        expandStringMap(d.Get("tags").(map[string]interface{})),
+			Stage:       d.Get("stage").(string),
+			IsArchived:  d.Get("is_archived").(bool),
+		},
+	}
+}
+
+func resourceModelSetResourceData(d *schema.ResourceData, model *modelVersionResource) diag.Diagnostics {
+	props := model.Properties
+	if err := d.Set("model_type", props.ModelType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("path", props.ModelUri); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("flavor", modelFlavorsToList(props.Flavors)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("properties", props.Properties); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", props.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("stage", props.Stage); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_archived", props.IsArchived); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("asset_id", fmt.Sprintf("azureml:%s:%s", d.Get("name").(string), d.Get("version").(string))); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func schemaSetToModelFlavors(set *schema.Set) map[string]modelFlavor {
+	if set.Len() == 0 {
+		return nil
+	}
+	flavors := make(map[string]modelFlavor, set.Len())
+	for _, v := range set.List() {
+		flavor := v.(map[string]interface{})
+		flavors[flavor["name"].(string)] = modelFlavor{
+			Data: expandStringMap(flavor["data"].(map[string]interface{})),
+		}
+	}
+	return flavors
+}
+
+func modelFlavorsToList(flavors map[string]modelFlavor) []interface{} {
+	names := make([]string, 0, len(flavors))
+	for name := range flavors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]interface{}, len(names))
+	for i, name := range names {
+		result[i] = map[string]interface{}{
+			"name": name,
+			"data": flavors[name].Data,
+		}
+	}
+	return result
+}
+
+type modelVersionResource struct {
+	Id         string                 `json:"id,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Properties modelVersionProperties `json:"properties"`
+}
+
+type modelVersionProperties struct {
+	ModelType   string                 `json:"modelType,omitempty"`
+	ModelUri    string                 `json:"modelUri"`
+	Flavors     map[string]modelFlavor `json:"flavors,omitempty"`
+	Properties  map[string]string      `json:"properties,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Stage       string                 `json:"stage,omitempty"`
+	IsArchived  bool                   `json:"isArchived"`
+}
+
+type modelFlavor struct {
+	Data map[string]string `json:"data,omitempty"`
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestValidateRegistryModelPath(t *testing.T) {
+	for _, path := range []string{
+		"azureml://datastores/workspaceblobstore/paths/models/model.pkl",
+		"azureml://subscriptions/sub/resourcegroups/rg/workspaces/ws/datastores/workspaceblobstore/paths/models/",
+	} {
+		if err := validateRegistryModelPath(path); err == nil {
+			t.Errorf("expected error for %s", path)
+		}
+	}
+
+	for _, path := range []string{
+		"azureml://jobs/example-job/outputs/artifacts/paths/model/",
+		"https://account.blob.core.windows.net/models/model.pkl",
+	} {
+		if err := validateRegistryModelPath(path); err != nil {
+			t.Errorf("unexpected error for %s: %v", path, err)
+		}
+	}
+}
@@ -0,0 +1,620 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+var onlineDeploymentErrorPaths = workspaceErrorPaths(armErrorPaths{armErrorQuota: cty.GetAttrPath("instance_type")})
+
+func GetAllowedOnlineScaleTypes() []string {
+	return []string{
+		"Default",
+		"TargetUtilization",
+	}
+}
+
+func resourceOnlineDeployment() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a managed Deployment of an Online Endpoint of an Azure ML Workspace. Changes to the " +
+			"deployment are rolled out in place and never alter the traffic of the endpoint.",
+
+		CreateContext: resourceOnlineDeploymentCreate,
+		ReadContext:   resourceOnlineDeploymentRead,
+		UpdateContext: resourceOnlineDeploymentUpdate,
+		DeleteContext: resourceOnlineDeploymentDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importEndpointDeployment("onlineEndpoints"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the deployment belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the deployment belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"endpoint_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the online endpoint to which the deployment belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The name of the deployment. It must be between 3 and 32 characters long, start with a " +
+					"letter and contain only letters, digits and hyphens.",
+				ForceNew:     true,
+				ValidateFunc: IsValidEndpointName,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the deployment.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the deployment.",
+			},
+			"model": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The model to deploy, either as the ID of a model version or as a reference in the " +
+					"`azureml:<name>:<version>` form.",
+				ValidateFunc:     validation.StringIsNotEmpty,
+				DiffSuppressFunc: suppressEquivalentAssetReferenceDiff("models"),
+			},
+			"environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The environment used for running the deployment, either as the ID of an environment " +
+					"version or as a reference in the `azureml:<name>:<version>` form.",
+				ValidateFunc:     validation.StringIsNotEmpty,
+				DiffSuppressFunc: suppressEquivalentAssetReferenceDiff("environments"),
+			},
+			"code_configuration": codeConfigurationSchema(),
+			"instance_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The VM size of the instances running the deployment (e.g. `Standard_DS3_v2`).",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"instance_count": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				Description:  "The number of instances running the deployment.",
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"scale_settings": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "The scale settings of the deployment.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "Default",
+							Description: fmt.Sprintf(
+								"The scale type. Possible values are: %+q.",
+								GetAllowedOnlineScaleTypes(),
+							),
+							ValidateFunc: validation.StringInSlice(GetAllowedOnlineScaleTypes(), false),
+						},
+						"min_instances": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							Description:  "The minimum number of instances. Used only with the `TargetUtilization` scale type.",
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"max_instances": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							Description:  "The maximum number of instances. Used only with the `TargetUtilization` scale type.",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"polling_interval_seconds": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  1,
+							Description: "The interval, in seconds, at which the utilization is evaluated. Used only " +
+								"with the `TargetUtilization` scale type.",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"target_utilization_percentage": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Default:  70,
+							Description: "The target CPU utilization percentage. Used only with the `TargetUtilization` " +
+								"scale type.",
+							ValidateFunc: validation.IntBetween(1, 100),
+						},
+					},
+				},
+			},
+			"liveness_probe":  onlineDeploymentProbeSchema("The probe checking whether the container is alive."),
+			"readiness_probe": onlineDeploymentProbeSchema("The probe checking whether the container is ready to serve traffic."),
+			"request_settings": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "The settings of the scoring requests.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"max_concurrent_requests_per_instance": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							Description:  "The maximum number of concurrent requests served by each instance.",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"request_timeout_ms": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      5000,
+							Description:  "The scoring timeout, in milliseconds.",
+							ValidateFunc: validation.IntBetween(1, 180000),
+						},
+						"max_queue_wait_ms": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      500,
+							Description:  "The maximum time, in milliseconds, a request waits in the queue.",
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+					},
+				},
+			},
+			"environment_variables": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The environment variables set in the deployment container.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"app_insights_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Is logging to the Application Insights of the workspace enabled?",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the deployment.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func onlineDeploymentProbeSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Computed:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"initial_delay_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      10,
+					Description:  "The delay, in seconds, before the first probe.",
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"period_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      10,
+					Description:  "The interval, in seconds, between two probes.",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"timeout_seconds": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      2,
+					Description:  "The timeout, in seconds, of a probe.",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"failure_threshold": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      30,
+					Description:  "The number of consecutive failures after which the probe is considered failed.",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+				"success_threshold": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      1,
+					Description:  "The number of consecutive successes after which the probe is considered successful.",
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func resourceOnlineDeploymentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	endpointName := d.Get("endpoint_name").(string)
+	name := d.Get("name").(string)
+
+	// The deployment must be created in the same region of its endpoint
+	endpointPath := onlineEndpointPath(client.arm, resourceGroupName, workspaceName, endpointName)
+	endpoint := new(onlineEndpointResource)
+	if err := client.arm.get(ctx, endpointPath, endpoint); err != nil {
+		return diag.FromErr(fmt.Errorf("unable to retrieve online endpoint %s: %w", endpointName, err))
+	}
+
+	path := endpointDeploymentPath(endpointPath, name)
+	resp, err := client.arm.put(ctx, path, resourceOnlineDeploymentGetResourceData(d, endpoint.Location), nil)
+	if err != nil {
+		return armErrorDiagnostics(fmt.Sprintf("Error creating online deployment %s", name), err, onlineDeploymentErrorPaths)
+	}
+	d.SetId(path)
+	if err := waitForProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return armErrorDiagnostics(fmt.Sprintf("Error waiting for online deployment %s to be created", name), err, onlineDeploymentErrorPaths)
+	}
+
+	return resourceOnlineDeploymentRead(ctx, d, meta)
+}
+
+func resourceOnlineDeploymentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	name := d.Get("name").(string)
+
+	deployment := new(onlineDeploymentResource)
+	if err := getProvisioned(ctx, client.arm, resourceOnlineDeploymentPath(client.arm, d), deployment); err != nil {
+		if diags, ok := failedProvisioningDiagnostics(d, fmt.Sprintf("online deployment %s", name), err); ok {
+			return diags
+		}
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading online deployment %s", name),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(deployment.Id)
+	return resourceOnlineDeploymentSetResourceData(d, deployment)
+}
+
+func resourceOnlineDeploymentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	name := d.Get("name").(string)
+	path := resourceOnlineDeploymentPath(client.arm, d)
+
+	// The location is not exposed as an attribute, so keep the one of the existing deployment
+	existing := new(onlineDeploymentResource)
+	if err := client.arm.get(ctx, path, existing); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Updating a deployment triggers a rolling update of its instances: the traffic of the endpoint,
+	// which is a property of the endpoint itself, is left untouched.
+	resp, err := client.arm.put(ctx, path, resourceOnlineDeploymentGetResourceData(d, existing.Location), nil)
+	if err != nil {
+		return armErrorDiagnostics(fmt.Sprintf("Error updating online deployment %s", name), err, onlineDeploymentErrorPaths)
+	}
+	if err := waitForProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return armErrorDiagnostics(fmt.Sprintf("Error waiting for online deployment %s to be updated", name), err, onlineDeploymentErrorPaths)
+	}
+
+	return resourceOnlineDeploymentRead(ctx, d, meta)
+}
+
+func resourceOnlineDeploymentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	name := d.Get("name").(string)
+	path := resourceOnlineDeploymentPath(client.arm, d)
+
+	resp, err := client.arm.delete(ctx, path)
+	if err == nil {
+		err = waitForDeletion(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutDelete))
+	}
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting online deployment %s.", name),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceOnlineDeploymentPath(c *armClient, d *schema.ResourceData) string {
+	return endpointDeploymentPath(
+		onlineEndpointPath(
+			c,
+			d.Get("resource_group_name").(string),
+			d.Get("workspace_name").(string),
+			d.Get("endpoint_name").(string),
+		),
+		d.Get("name").(string),
+	)
+}
+
+func resourceOnlineDeploymentGetResourceData(d *schema.ResourceData, location string) *onlineDeploymentResource {
+	deployment := &onlineDeploymentResource{
+		Location: location,
+		Kind:     "Managed",
+		Tags:     expandStringMap(d.Get("tags").(map[string]interface{})),
+		Sku: &onlineDeploymentSku{
+			Name:     "Default",
+			Capacity: d.Get("instance_count").(int),
+		},
+		Properties: onlineDeploymentProperties{
+			EndpointComputeType:  "Managed",
+			Description:          d.Get("description").(string),
+			Model:                d.Get("model").(string),
+			EnvironmentId:        d.Get("environment_id").(string),
+			InstanceType:         d.Get("instance_type").(string),
+			EnvironmentVariables: expandStringMap(d.Get("environment_variables").(map[string]interface{})),
+			AppInsightsEnabled:   d.Get("app_insights_enabled").(bool),
+			CodeConfiguration:    expandCodeConfiguration(d.Get("code_configuration").([]interface{})),
+			LivenessProbe:        expandOnlineDeploymentProbe(d.Get("liveness_probe").([]interface{})),
+			ReadinessProbe:       expandOnlineDeploymentProbe(d.Get("readiness_probe").([]interface{})),
+		},
+	}
+
+	if l := d.Get("scale_settings").([]interface{}); len(l) > 0 && l[0] != nil {
+		data := l[0].(map[string]interface{})
+		scaleSettings := &onlineScaleSettings{ScaleType: data["type"].(string)}
+		if scaleSettings.ScaleType == "TargetUtilization" {
+			scaleSettings.MinInstances = data["min_instances"].(int)
+			scaleSettings.MaxInstances = data["max_instances"].(int)
+			scaleSettings.PollingInterval = formatIsoDuration(time.Duration(data["polling_interval_seconds"].(int)) * time.Second)
+			scaleSettings.TargetUtilizationPercentage = data["target_utilization_percentage"].(int)
+		}
+		deployment.Properties.ScaleSettings = scaleSettings
+	}
+
+	if l := d.Get("request_settings").([]interface{}); len(l) > 0 && l[0] != nil {
+		data := l[0].(map[string]interface{})
+		deployment.Properties.RequestSettings = &onlineRequestSettings{
+			MaxConcurrentRequestsPerInstance: data["max_concurrent_requests_per_instance"].(int),
+			RequestTimeout:                   formatIsoDuration(time.Duration(data["request_timeout_ms"].(int)) * time.Millisecond),
+			MaxQueueWait:                     formatIsoDuration(time.Duration(data["max_queue_wait_ms"].(int)) * time.Millisecond),
+		}
+	}
+
+	return deployment
+}
+
+func resourceOnlineDeploymentSetResourceData(d *schema.ResourceData, deployment *onlineDeploymentResource) diag.Diagnostics {
+	props := deployment.Properties
+	if err := d.Set("tags", deployment.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if deployment.Sku != nil {
+		if err := d.Set("instance_count", deployment.Sku.Capacity); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("model", props.Model); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("environment_id", props.EnvironmentId); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("instance_type", props.InstanceType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("environment_variables", props.EnvironmentVariables); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("app_insights_enabled", props.AppInsightsEnabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("code_configuration", flattenCodeConfiguration(props.CodeConfiguration)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	scaleSettings := make([]interface{}, 0)
+	if s := props.ScaleSettings; s != nil {
+		data := map[string]interface{}{
+			"type":                          s.ScaleType,
+			"min_instances":                 1,
+			"max_instances":                 1,
+			"polling_interval_seconds":      1,
+			"target_utilization_percentage": 70,
+		}
+		if s.ScaleType == "TargetUtilization" {
+			pollingInterval, err := parseIsoDuration(s.PollingInterval)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			data["min_instances"] = s.MinInstances
+			data["max_instances"] = s.MaxInstances
+			data["polling_interval_seconds"] = int(pollingInterval / time.Second)
+			data["target_utilization_percentage"] = s.TargetUtilizationPercentage
+		}
+		scaleSettings = append(scaleSettings, data)
+	}
+	if err := d.Set("scale_settings", scaleSettings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	livenessProbe, err := flattenOnlineDeploymentProbe(props.LivenessProbe)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("liveness_probe", livenessProbe); err != nil {
+		return diag.FromErr(err)
+	}
+	readinessProbe, err := flattenOnlineDeploymentProbe(props.ReadinessProbe)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("readiness_probe", readinessProbe); err != nil {
+		return diag.FromErr(err)
+	}
+
+	requestSettings := make([]interface{}, 0)
+	if r := props.RequestSettings; r != nil {
+		requestTimeout, err := parseIsoDuration(r.RequestTimeout)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		maxQueueWait, err := parseIsoDuration(r.MaxQueueWait)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		requestSettings = append(requestSettings, map[string]interface{}{
+			"max_concurrent_requests_per_instance": r.MaxConcurrentRequestsPerInstance,
+			"request_timeout_ms":                   int(requestTimeout / time.Millisecond),
+			"max_queue_wait_ms":                    int(maxQueueWait / time.Millisecond),
+		})
+	}
+	if err := d.Set("request_settings", requestSettings); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func expandOnlineDeploymentProbe(l []interface{}) *onlineProbeSettings {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	data := l[0].(map[string]interface{})
+	return &onlineProbeSettings{
+		InitialDelay:     formatIsoDuration(time.Duration(data["initial_delay_seconds"].(int)) * time.Second),
+		Period:           formatIsoDuration(time.Duration(data["period_seconds"].(int)) * time.Second),
+		Timeout:          formatIsoDuration(time.Duration(data["timeout_seconds"].(int)) * time.Second),
+		FailureThreshold: data["failure_threshold"].(int),
+		SuccessThreshold: data["success_threshold"].(int),
+	}
+}
+
+func flattenOnlineDeploymentProbe(p *onlineProbeSettings) ([]interface{}, error) {
+	if p == nil {
+		return make([]interface{}, 0), nil
+	}
+	durations := make(map[string]int, 3)
+	for k, v := range map[string]string{
+		"initial_delay_seconds": p.InitialDelay,
+		"period_seconds":        p.Period,
+		"timeout_seconds":       p.Timeout,
+	} {
+		duration, err := parseIsoDuration(v)
+		if err != nil {
+			return nil, err
+		}
+		durations[k] = int(duration / time.Second)
+	}
+	return []interface{}{
+		map[string]interface{}{
+			"initial_delay_seconds": durations["initial_delay_seconds"],
+			"period_seconds":        durations["period_seconds"],
+			"timeout_seconds":       durations["timeout_seconds"],
+			"failure_threshold":     p.FailureThreshold,
+			"success_threshold":     p.SuccessThreshold,
+		},
+	}, nil
+}
+
+type onlineDeploymentResource struct {
+	Id         string                     `json:"id,omitempty"`
+	Name       string                     `json:"name,omitempty"`
+	Location   string                     `json:"location"`
+	Kind       string                     `json:"kind,omitempty"`
+	Tags       map[string]string          `json:"tags,omitempty"`
+	Sku        *onlineDeploymentSku       `json:"sku,omitempty"`
+	Properties onlineDeploymentProperties `json:"properties"`
+}
+
+type onlineDeploymentSku struct {
+	Name     string `json:"name"`
+	Capacity int    `json:"capacity"`
+}
+
+type onlineDeploymentProperties struct {
+	EndpointComputeType  string                 `json:"endpointComputeType"`
+	Description          string                 `json:"description,omitempty"`
+	Model                string                 `json:"model,omitempty"`
+	EnvironmentId        string                 `json:"environmentId,omitempty"`
+	CodeConfiguration    *codeConfiguration     `json:"codeConfiguration,omitempty"`
+	InstanceType         string                 `json:"instanceType"`
+	ScaleSettings        *onlineScaleSettings   `json:"scaleSettings,omitempty"`
+	LivenessProbe        *onlineProbeSettings   `json:"livenessProbe,omitempty"`
+	ReadinessProbe       *onlineProbeSettings   `json:"readinessProbe,omitempty"`
+	RequestSettings      *onlineRequestSettings `json:"requestSettings,omitempty"`
+	EnvironmentVariables map[string]string      `json:"environmentVariables,omitempty"`
+	AppInsightsEnabled   bool                   `json:"appInsightsEnabled"`
+	ProvisioningState    string                 `json:"provisioningState,omitempty"`
+}
+
+type onlineScaleSettings struct {
+	ScaleType                   string `json:"scaleType"`
+	MinInstances                int    `json:"minInstances,omitempty"`
+	MaxInstances                int    `json:"maxInstances,omitempty"`
+	PollingInterval             string `json:"pollingInterval,omitempty"`
+	TargetUtilizationPercentage int    `json:"targetUtilizationPercentage,omitempty"`
+}
+
+type onlineProbeSettings struct {
+	InitialDelay     string `json:"initialDelay"`
+	Period           string `json:"period"`
+	Timeout          string `json:"timeout"`
+	FailureThreshold int    `json:"failureThreshold"`
+	SuccessThreshold int    `json:"successThreshold"`
+}
+
+type onlineRequestSettings struct {
+	MaxConcurrentRequestsPerInstance int    `json:"maxConcurrentRequestsPerInstance"`
+	RequestTimeout                   string `json:"requestTimeout"`
+	MaxQueueWait                     string `json:"maxQueueWait"`
+}
@@ -0,0 +1,368 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+func GetAllowedOnlineEndpointAuthModes() []string {
+	return []string{
+		"Key",
+		"AMLToken",
+		"AADToken",
+	}
+}
+
+func GetAllowedPublicNetworkAccessValues() []string {
+	return []string{
+		"Enabled",
+		"Disabled",
+	}
+}
+
+func resourceOnlineEndpoint() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages an Online Endpoint of an Azure ML Workspace.",
+
+		CreateContext: resourceOnlineEndpointCreate,
+		ReadContext:   resourceOnlineEndpointRead,
+		UpdateContext: resourceOnlineEndpointUpdate,
+		DeleteContext: resourceOnlineEndpointDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceChild("onlineEndpoints"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the endpoint belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the endpoint belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The name of the endpoint. It must be unique within the Azure region, between 3 and 32 " +
+					"characters long, start with a letter and contain only letters, digits and hyphens.",
+				ForceNew:     true,
+				ValidateFunc: IsValidEndpointName,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the endpoint.",
+			},
+			"location": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The Azure region in which the endpoint is created. Defaults to the location of the Azure " +
+					"ML Workspace.",
+				ForceNew: true,
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the endpoint.",
+				ForceNew:    true,
+			},
+			"auth_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Key",
+				Description: fmt.Sprintf(
+					"The authentication mode of the endpoint. Possible values are: %+q.",
+					GetAllowedOnlineEndpointAuthModes(),
+				),
+				ValidateFunc: validation.StringInSlice(GetAllowedOnlineEndpointAuthModes(), false),
+			},
+			"identity": managedIdentitySchema(true),
+			"public_network_access": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Enabled",
+				Description: fmt.Sprintf(
+					"Is the endpoint reachable from the public network? Possible values are: %+q.",
+					GetAllowedPublicNetworkAccessValues(),
+				),
+				ValidateFunc: validation.StringInSlice(GetAllowedPublicNetworkAccessValues(), false),
+			},
+			"traffic": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+				Description: "The percentage of the traffic routed to each deployment of the endpoint, keyed by " +
+					"deployment name. The percentages must add up to 100. If not specified, the traffic is not managed. " +
+					"Do not set it when the traffic is managed by an `azureml_online_endpoint_traffic` resource.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(0, 100),
+				},
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the endpoint.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"scoring_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URI used for invoking the endpoint.",
+			},
+			"swagger_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URI of the Swagger definition of the endpoint.",
+			},
+		},
+	}
+}
+
+func resourceOnlineEndpointCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	location := d.Get("location").(string)
+	if location == "" {
+		ws, err := client.arm.getWorkspace(ctx, resourceGroupName, workspaceName)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("unable to retrieve the location of workspace %s: %w", workspaceName, err))
+		}
+		location = ws.Location
+	}
+
+	path := onlineEndpointPath(client.arm, resourceGroupName, workspaceName, name)
+	resp, err := client.arm.put(ctx, path, resourceOnlineEndpointGetResourceData(d, location), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(path)
+	if err := waitForProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for online endpoint %s to be created: %w", name, err))
+	}
+
+	return resourceOnlineEndpointRead(ctx, d, meta)
+}
+
+func resourceOnlineEndpointRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	endpoint := new(onlineEndpointResource)
+	err := getProvisioned(ctx, client.arm, onlineEndpointPath(client.arm, resourceGroupName, workspaceName, name), endpoint)
+	if err != nil {
+		if diags, ok := failedProvisioningDiagnostics(d, fmt.Sprintf("online endpoint %s", name), err); ok {
+			return diags
+		}
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading online endpoint %s", name),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(endpoint.Id)
+	return resourceOnlineEndpointSetResourceData(d, endpoint)
+}
+
+func resourceOnlineEndpointUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	path := onlineEndpointPath(client.arm, resourceGroupName, workspaceName, name)
+	existing := new(onlineEndpointResource)
+	if err := client.arm.get(ctx, path, existing); err != nil {
+		return diag.FromErr(err)
+	}
+
+	endpoint := resourceOnlineEndpointGetResourceData(d, d.Get("location").(string))
+	keepUnmanagedOnlineEndpointTraffic(endpoint, existing, d.GetRawConfig().GetAttr("traffic"))
+	resp, err := client.arm.put(ctx, path, endpoint, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := waitForProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for online endpoint %s to be updated: %w", name, err))
+	}
+
+	return resourceOnlineEndpointRead(ctx, d, meta)
+}
+
+func resourceOnlineEndpointDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	path := onlineEndpointPath(client.arm, resourceGroupName, workspaceName, name)
+
+	resp, err := client.arm.delete(ctx, path)
+	if err == nil {
+		err = waitForDeletion(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutDelete))
+	}
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting online endpoint %s.", name),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceOnlineEndpointGetResourceData(d *schema.ResourceData, location string) *onlineEndpointResource {
+	endpoint := &onlineEndpointResource{
+		Location: location,
+		Identity: expandManagedIdentity(d.Get("identity").([]interface{})),
+		Tags:     expandStringMap(d.Get("tags").(map[string]interface{})),
+		Properties: onlineEndpointProperties{
+			AuthMode:            d.Get("auth_mode").(string),
+			Description:         d.Get("description").(string),
+			PublicNetworkAccess: d.Get("public_network_access").(string),
+			Traffic:             expandIntMap(d.Get("traffic").(map[string]interface{})),
+		},
+	}
+	if endpoint.Identity == nil {
+		endpoint.Identity = &armManagedIdentity{Type: "SystemAssigned"}
+	}
+	return endpoint
+}
+
+// keepUnmanagedOnlineEndpointTraffic copies to endpoint the traffic of the existing endpoint that is not managed by
+// the resource: the mirror traffic, which is managed by the azureml_online_endpoint_traffic resource, and the live
+// traffic when the traffic argument is not set in the configuration.
+func keepUnmanagedOnlineEndpointTraffic(endpoint, existing *onlineEndpointResource, rawTraffic cty.Value) {
+	endpoint.Properties.MirrorTraffic = existing.Properties.MirrorTraffic
+	if rawTraffic.IsNull() {
+		endpoint.Properties.Traffic = existing.Properties.Traffic
+	}
+}
+
+func resourceOnlineEndpointSetResourceData(d *schema.ResourceData, endpoint *onlineEndpointResource) diag.Diagnostics {
+	if err := d.Set("location", endpoint.Location); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("identity", flattenManagedIdentity(endpoint.Identity)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", endpoint.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+
+	props := endpoint.Properties
+	if err := d.Set("auth_mode", props.AuthMode); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("public_network_access", props.PublicNetworkAccess); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("traffic", props.Traffic); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("scoring_uri", props.ScoringUri); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("swagger_uri", props.SwaggerUri); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func onlineEndpointPath(c *armClient, resourceGroupName, workspaceName, name string) string {
+	return fmt.Sprintf("%s/onlineEndpoints/%s", c.workspaceId(resourceGroupName, workspaceName), name)
+}
+
+type onlineEndpointResource struct {
+	Id         string                   `json:"id,omitempty"`
+	Name       string                   `json:"name,omitempty"`
+	Location   string                   `json:"location"`
+	Identity   *armManagedIdentity      `json:"identity,omitempty"`
+	Tags       map[string]string        `json:"tags,omitempty"`
+	Properties onlineEndpointProperties `json:"properties"`
+}
+
+type onlineEndpointProperties struct {
+	AuthMode            string         `json:"authMode"`
+	Description         string         `json:"description,omitempty"`
+	PublicNetworkAccess string         `json:"publicNetworkAccess,omitempty"`
+	Traffic             map[string]int `json:"traffic,omitempty"`
+	MirrorTraffic       map[string]int `json:"mirrorTraffic,omitempty"`
+	ScoringUri          string         `json:"scoringUri,omitempty"`
+	SwaggerUri          string         `json:"swaggerUri,omitempty"`
+	ProvisioningState   string         `json:"provisioningState,omitempty"`
+}
+
+type onlineEndpointKeys struct {
+	PrimaryKey   string `json:"primaryKey"`
+	SecondaryKey string `json:"secondaryKey"`
+}
+
+// listOnlineEndpointKeys returns the keys of the online endpoint at the path provided as argument. The keys
+// are available only for the endpoints using the Key authentication mode.
+func listOnlineEndpointKeys(ctx context.Context, c *armClient, endpointPath string) (*onlineEndpointKeys, error) {
+	keys := new(onlineEndpointKeys)
+	if _, err := c.post(ctx, fmt.Sprintf("%s/listKeys", endpointPath), nil, keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+type onlineEndpointToken struct {
+	AccessToken         string `json:"accessToken"`
+	TokenType           string `json:"tokenType"`
+	ExpiryTimeUtc       int64  `json:"expiryTimeUtc"`
+	RefreshAfterTimeUtc int64  `json:"refreshAfterTimeUtc"`
+}
+
+// getOnlineEndpointToken returns a token for invoking the online endpoint at the path provided as argument.
+// The token is available only for the endpoints using the AMLToken authentication mode.
+func getOnlineEndpointToken(ctx context.Context, c *armClient, endpointPath string) (*onlineEndpointToken, error) {
+	token := new(onlineEndpointToken)
+	if _, err := c.post(ctx, fmt.Sprintf("%s/token", endpointPath), nil, token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+func GetAllowedOnlineEndpointKeyTypes() []string {
+	return []string{
+		"Primary",
+		"Secondary",
+	}
+}
+
+func resourceOnlineEndpointKeyRegeneration() *schema.Resource {
+	return &schema.Resource{
+		Description: "Regenerates a key of an Online Endpoint using the `Key` authentication mode. The key is " +
+			"regenerated when the resource is created and whenever `triggers` change. Destroying the resource does " +
+			"not affect the keys of the endpoint.",
+
+		CreateContext: resourceOnlineEndpointKeyRegenerationCreate,
+		ReadContext:   resourceOnlineEndpointKeyRegenerationRead,
+		DeleteContext: resourceOnlineEndpointKeyRegenerationDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the endpoint belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the endpoint belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"endpoint_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the online endpoint whose key is regenerated.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the key regeneration.",
+			},
+			"key_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"The key to regenerate. Possible values are: %+q.",
+					GetAllowedOnlineEndpointKeyTypes(),
+				),
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(GetAllowedOnlineEndpointKeyTypes(), false),
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Arbitrary values that, when changed, regenerate the key (e.g. a rotation date).",
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"regenerated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time at which the key was regenerated.",
+			},
+		},
+	}
+}
+
+func resourceOnlineEndpointKeyRegenerationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	endpointName := d.Get("endpoint_name").(string)
+	keyType := d.Get("key_type").(string)
+	path := onlineEndpointPath(
+		client.arm,
+		d.Get("resource_group_name").(string),
+		d.Get("workspace_name").(string),
+		endpointName,
+	)
+
+	body := map[string]string{"keyType": keyType}
+	resp, err := client.arm.post(ctx, fmt.Sprintf("%s/regenerateKeys", path), body, nil)
+	if err != nil {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error regenerating the %s key of online endpoint %s", keyType, endpointName),
+			Detail:   err.Error(),
+		})
+		return diags
+	}
+	if err := waitForProvisioning(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for the keys of online endpoint %s to be regenerated: %w", endpointName, err))
+	}
+
+	regeneratedAt := time.Now().UTC()
+	if err := d.Set("regenerated_at", regeneratedAt.Format(time.RFC3339)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(fmt.Sprintf("%s/keys/%s/%d", path, keyType, regeneratedAt.Unix()))
+
+	return resourceOnlineEndpointKeyRegenerationRead(ctx, d, meta)
+}
+
+func resourceOnlineEndpointKeyRegenerationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	endpointName := d.Get("endpoint_name").(string)
+	path := onlineEndpointPath(
+		client.arm,
+		d.Get("resource_group_name").(string),
+		d.Get("workspace_name").(string),
+		endpointName,
+	)
+
+	// The regeneration does not exist anymore once its endpoint has been deleted
+	if err := client.arm.get(ctx, path, new(onlineEndpointResource)); err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading online endpoint %s", endpointName),
+				Detail:   err.Error(),
+			})
+		}
+	}
+	return diags
+}
+
+func resourceOnlineEndpointKeyRegenerationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,333 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"sort"
+	"time"
+)
+
+func resourceOnlineEndpointTraffic() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages the traffic split of an existing Online Endpoint of an Azure ML Workspace. The resource " +
+			"owns only the traffic and the mirror traffic of the endpoint, so that the traffic can be shifted between " +
+			"deployments without touching the endpoint or its deployments.",
+
+		CreateContext: resourceOnlineEndpointTrafficCreateOrUpdate,
+		ReadContext:   resourceOnlineEndpointTrafficRead,
+		UpdateContext: resourceOnlineEndpointTrafficCreateOrUpdate,
+		DeleteContext: resourceOnlineEndpointTrafficDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceOnlineEndpointTrafficImport,
+		},
+
+		CustomizeDiff: resourceOnlineEndpointTrafficCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the endpoint belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the endpoint belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"endpoint_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the online endpoint whose traffic is managed.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the online endpoint.",
+			},
+			"traffic": {
+				Type:     schema.TypeMap,
+				Required: true,
+				Description: "The percentage of the traffic routed to each deployment of the endpoint, keyed by " +
+					"deployment name. The percentages must add up to 100. Once the resource is created, the deployments must " +
+					"exist when the plan is computed.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(0, 100),
+				},
+			},
+			"mirror_traffic": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				Description: "The percentage of the traffic copied to each deployment of the endpoint, keyed by " +
+					"deployment name. The responses of the mirrored requests are discarded.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntBetween(0, 50),
+				},
+			},
+		},
+	}
+}
+
+func resourceOnlineEndpointTrafficCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("traffic") || !d.NewValueKnown("mirror_traffic") {
+		return nil
+	}
+	traffic := expandIntMap(d.Get("traffic").(map[string]interface{}))
+	mirrorTraffic := expandIntMap(d.Get("mirror_traffic").(map[string]interface{}))
+	if err := validateTrafficSplit(traffic, mirrorTraffic); err != nil {
+		return err
+	}
+
+	// When the resource is created, the endpoint and its deployments are usually created in the same apply,
+	// so the deployments are checked only when the resource is applied
+	if d.Id() == "" {
+		return nil
+	}
+	client := meta.(*apiClient)
+	endpointPath := onlineEndpointPath(
+		client.arm,
+		d.Get("resource_group_name").(string),
+		d.Get("workspace_name").(string),
+		d.Get("endpoint_name").(string),
+	)
+	deployments, err := listOnlineDeploymentNames(ctx, client.arm, endpointPath)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return nil
+		}
+		return err
+	}
+	return validateTrafficDeployments(traffic, mirrorTraffic, deployments)
+}
+
+func resourceOnlineEndpointTrafficCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	endpointName := d.Get("endpoint_name").(string)
+	path := onlineEndpointPath(
+		client.arm,
+		d.Get("resource_group_name").(string),
+		d.Get("workspace_name").(string),
+		endpointName,
+	)
+
+	traffic := expandIntMap(d.Get("traffic").(map[string]interface{}))
+	mirrorTraffic := expandIntMap(d.Get("mirror_traffic").(map[string]interface{}))
+	deployments, err := listOnlineDeploymentNames(ctx, client.arm, path)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("unable to list the deployments of online endpoint %s: %w", endpointName, err))
+	}
+	if err := validateTrafficDeployments(traffic, mirrorTraffic, deployments); err != nil {
+		return diag.FromErr(err)
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+	if err := setOnlineEndpointTraffic(ctx, client.arm, path, traffic, mirrorTraffic, timeout); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating the traffic of online endpoint %s: %w", endpointName, err))
+	}
+
+	d.SetId(path)
+	return resourceOnlineEndpointTrafficRead(ctx, d, meta)
+}
+
+func resourceOnlineEndpointTrafficRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	endpointName := d.Get("endpoint_name").(string)
+	path := onlineEndpointPath(
+		client.arm,
+		d.Get("resource_group_name").(string),
+		d.Get("workspace_name").(string),
+		endpointName,
+	)
+
+	endpoint := new(onlineEndpointResource)
+	if err := client.arm.get(ctx, path, endpoint); err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading online endpoint %s", endpointName),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(endpoint.Id)
+	if err := d.Set("traffic", endpoint.Properties.Traffic); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("mirror_traffic", endpoint.Properties.MirrorTraffic); err != nil {
+		return diag.FromErr(err)
+	}
+	return diags
+}
+
+func resourceOnlineEndpointTrafficDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	endpointName := d.Get("endpoint_name").(string)
+	path := onlineEndpointPath(
+		client.arm,
+		d.Get("resource_group_name").(string),
+		d.Get("workspace_name").(string),
+		endpointName,
+	)
+
+	// Destroying the resource stops routing traffic to the deployments, the endpoint is left untouched
+	if err := setOnlineEndpointTraffic(ctx, client.arm, path, nil, nil, d.Timeout(schema.TimeoutDelete)); err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error removing the traffic of online endpoint %s.", endpointName),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceOnlineEndpointTrafficImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceGroupName, workspaceName, name, err := parseWorkspaceChildId(d.Id(), "onlineEndpoints")
+	if err != nil {
+		return nil, err
+	}
+	if err := d.Set("resource_group_name", resourceGroupName); err != nil {
+		return nil, err
+	}
+	if err := d.Set("workspace_name", workspaceName); err != nil {
+		return nil, err
+	}
+	if err := d.Set("endpoint_name", name); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// onlineEndpointTrafficResource is the online endpoint sent when replacing its traffic. Unlike in
+// onlineEndpointResource, the traffic maps are always sent, as a missing map leaves the traffic unchanged.
+type onlineEndpointTrafficResource struct {
+	onlineEndpointResource
+	Properties onlineEndpointTrafficProperties `json:"properties"`
+}
+
+type onlineEndpointTrafficProperties struct {
+	onlineEndpointProperties
+	Traffic       map[string]int `json:"traffic"`
+	MirrorTraffic map[string]int `json:"mirrorTraffic"`
+}
+
+// setOnlineEndpointTraffic replaces the traffic and the mirror traffic of the online endpoint at the path
+// provided as argument, leaving the other properties of the endpoint untouched.
+func setOnlineEndpointTraffic(ctx context.Context, c *armClient, path string, traffic, mirrorTraffic map[string]int, timeout time.Duration) error {
+	endpoint := new(onlineEndpointResource)
+	if err := c.get(ctx, path, endpoint); err != nil {
+		return err
+	}
+	// An empty map is required for removing the traffic, a null one leaves it unchanged
+	if traffic == nil {
+		traffic = make(map[string]int)
+	}
+	if mirrorTraffic == nil {
+		mirrorTraffic = make(map[string]int)
+	}
+	resp, err := c.put(ctx, path, onlineEndpointTrafficResource{
+		onlineEndpointResource: *endpoint,
+		Properties: onlineEndpointTrafficProperties{
+			onlineEndpointProperties: endpoint.Properties,
+			Traffic:                  traffic,
+			MirrorTraffic:            mirrorTraffic,
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+	return waitForProvisioning(ctx, c, resp, path, timeout)
+}
+
+// listOnlineDeploymentNames returns the names of the deployments of the online endpoint at the path provided
+// as argument.
+func listOnlineDeploymentNames(ctx context.Context, c *armClient, endpointPath string) ([]string, error) {
+	names := make([]string, 0)
+	next := fmt.Sprintf("%s/deployments", endpointPath)
+	for next != "" {
+		page := new(armResourceList)
+		if err := c.get(ctx, next, page); err != nil {
+			return nil, err
+		}
+		for _, r := range page.Value {
+			names = append(names, r.Name)
+		}
+		next = page.NextLink
+	}
+	return names, nil
+}
+
+// validateTrafficSplit checks that the traffic percentages add up to 100 and that the mirrored traffic
+// does not exceed 50 percent.
+func validateTrafficSplit(traffic, mirrorTraffic map[string]int) error {
+	total := 0
+	for _, v := range traffic {
+		total += v
+	}
+	if total != 100 {
+		return fmt.Errorf("the percentages of %q must add up to 100, got %d", "traffic", total)
+	}
+	mirrored := 0
+	for name, v := range mirrorTraffic {
+		if traffic[name] > 0 && v > 0 {
+			return fmt.Errorf("deployment %q cannot receive both live and mirrored traffic", name)
+		}
+		mirrored += v
+	}
+	if mirrored > 50 {
+		return fmt.Errorf("the percentages of %q cannot exceed 50, got %d", "mirror_traffic", mirrored)
+	}
+	return nil
+}
+
+// validateTrafficDeployments checks that the deployments referenced by the traffic maps exist.
+func validateTrafficDeployments(traffic, mirrorTraffic map[string]int, deployments []string) error {
+	missing := make([]string, 0)
+	for _, m := range []map[string]int{traffic, mirrorTraffic} {
+		for name := range m {
+			if !contains(deployments, name) && !contains(missing, name) {
+				missing = append(missing, name)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return fmt.Errorf(
+			"the endpoint has no deployments named %+q: the deployments must be created before routing traffic to them",
+			missing,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/go-cty/cty"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestValidateTrafficSplit(t *testing.T) {
+	testCases := []struct {
+		name          string
+		traffic       map[string]int
+		mirrorTraffic map[string]int
+		expectError   bool
+	}{
+		{
+			name:    "Single deployment",
+			traffic: map[string]int{"blue": 100},
+		},
+		{
+			name:          "Blue/green with mirroring",
+			traffic:       map[string]int{"blue": 90, "green": 10, "red": 0},
+			mirrorTraffic: map[string]int{"red": 20},
+		},
+		{
+			name:        "Sum lower than 100",
+			traffic:     map[string]int{"blue": 50, "green": 40},
+			expectError: true,
+		},
+		{
+			name:        "Sum greater than 100",
+			traffic:     map[string]int{"blue": 60, "green": 50},
+			expectError: true,
+		},
+		{
+			name:        "Empty traffic",
+			traffic:     map[string]int{},
+			expectError: true,
+		},
+		{
+			name:          "Mirroring more than 50 percent",
+			traffic:       map[string]int{"blue": 100},
+			mirrorTraffic: map[string]int{"green": 30, "red": 30},
+			expectError:   true,
+		},
+		{
+			name:          "Mirroring to a deployment receiving live traffic",
+			traffic:       map[string]int{"blue": 100},
+			mirrorTraffic: map[string]int{"blue": 10},
+			expectError:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateTrafficSplit(tc.traffic, tc.mirrorTraffic)
+			if tc.expectError && err == nil {
+				t.Fatalf("expected error, got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateTrafficDeployments(t *testing.T) {
+	deployments := []string{"blue", "green"}
+
+	if err := validateTrafficDeployments(map[string]int{"blue": 80, "green": 20}, nil, deployments); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err := validateTrafficDeployments(map[string]int{"blue": 100}, map[string]int{"red": 10}, deployments)
+	if err == nil {
+		t.Fatalf("expected error, got nil")
+	}
+	expected := `the endpoint has no deployments named ["red"]: the deployments must be created before routing traffic to them`
+	if err.Error() != expected {
+		t.Fatalf("expected error %q, got %q", expected, err.Error())
+	}
+}
+
+func TestSetOnlineEndpointTraffic(t *testing.T) {
+	testCases := []struct {
+		name          string
+		traffic       map[string]int
+		mirrorTraffic map[string]int
+		expected      string
+	}{
+		{
+			name:     "Without mirror traffic",
+			traffic:  map[string]int{"blue": 100},
+			expected: `{"blue": 100}`,
+		},
+		{
+			name:     "Removal",
+			expected: `{}`,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body map[string]interface{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPut {
+					content, _ := ioutil.ReadAll(r.Body)
+					if err := json.Unmarshal(content, &body); err != nil {
+						t.Errorf("unable to decode the request body: %v", err)
+					}
+				}
+				_, _ = w.Write([]byte(`{
+					"id": "endpoint-id",
+					"location": "westeurope",
+					"properties": {
+						"authMode": "Key",
+						"traffic": {"blue": 90, "green": 10},
+						"mirrorTraffic": {"red": 20},
+						"provisioningState": "Succeeded"
+					}
+				}`))
+			}))
+			defer server.Close()
+
+			err := setOnlineEndpointTraffic(context.Background(), newTestArmClient(server), "/endpoint", tc.traffic, tc.mirrorTraffic, 0)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			properties, ok := body["properties"].(map[string]interface{})
+			if !ok {
+				t.Fatalf("unexpected request body %v", body)
+			}
+			var expected map[string]interface{}
+			_ = json.Unmarshal([]byte(tc.expected), &expected)
+			if !reflect.DeepEqual(properties["traffic"], expected) {
+				t.Errorf("unexpected traffic %v, expected %v", properties["traffic"], expected)
+			}
+			if !reflect.DeepEqual(properties["mirrorTraffic"], map[string]interface{}{}) {
+				t.Errorf("unexpected mirror traffic %v, expected an empty map", properties["mirrorTraffic"])
+			}
+			if properties["authMode"] != "Key" || body["location"] != "westeurope" {
+				t.Errorf("the other properties of the endpoint have not been preserved: %v", body)
+			}
+		})
+	}
+}
+
+func TestKeepUnmanagedOnlineEndpointTraffic(t *testing.T) {
+	existing := &onlineEndpointResource{Properties: onlineEndpointProperties{
+		Traffic:       map[string]int{"blue": 90, "green": 10},
+		MirrorTraffic: map[string]int{"red": 20},
+	}}
+
+	// The traffic is managed outside of the resource, e.g. by an azureml_online_endpoint_traffic resource
+	endpoint := &onlineEndpointResource{Properties: onlineEndpointProperties{Traffic: map[string]int{"blue": 100}}}
+	keepUnmanagedOnlineEndpointTraffic(endpoint, existing, cty.NullVal(cty.Map(cty.Number)))
+	if !reflect.DeepEqual(endpoint.Properties.Traffic, existing.Properties.Traffic) {
+		t.Errorf("unexpected traffic %v, expected %v", endpoint.Properties.Traffic, existing.Properties.Traffic)
+	}
+	if !reflect.DeepEqual(endpoint.Properties.MirrorTraffic, existing.Properties.MirrorTraffic) {
+		t.Errorf("unexpected mirror traffic %v", endpoint.Properties.MirrorTraffic)
+	}
+
+	endpoint = &onlineEndpointResource{Properties: onlineEndpointProperties{Traffic: map[string]int{"blue": 100}}}
+	keepUnmanagedOnlineEndpointTraffic(endpoint, existing, cty.MapVal(map[string]cty.Value{"blue": cty.NumberIntVal(100)}))
+	if !reflect.DeepEqual(endpoint.Properties.Traffic, map[string]int{"blue": 100}) {
+		t.Errorf("unexpected traffic %v, expected the configured one", endpoint.Properties.Traffic)
+	}
+	if !reflect.DeepEqual(endpoint.Properties.MirrorTraffic, existing.Properties.MirrorTraffic) {
+		t.Errorf("unexpected mirror traffic %v", endpoint.Properties.MirrorTraffic)
+	}
+}
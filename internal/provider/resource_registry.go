@@ -0,0 +1,539 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"regexp"
+	"strings"
+	"time"
+)
+
+func GetAllowedRegistryStorageAccountTypes() []string {
+	return []string{
+		"Standard_LRS",
+		"Standard_GRS",
+		"Standard_RAGRS",
+		"Standard_ZRS",
+		"Standard_GZRS",
+		"Standard_RAGZRS",
+		"Premium_LRS",
+		"Premium_ZRS",
+	}
+}
+
+func GetAllowedRegistryAcrSkus() []string {
+	return []string{
+		"Basic",
+		"Standard",
+		"Premium",
+	}
+}
+
+func resourceRegistry() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages an Azure ML Registry, which shares models, environments and components across the " +
+			"Azure ML Workspaces of an organization, replicating them in multiple Azure regions.",
+
+		CreateContext: resourceRegistryCreate,
+		ReadContext:   resourceRegistryRead,
+		UpdateContext: resourceRegistryUpdate,
+		DeleteContext: resourceRegistryDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importRegistry,
+		},
+
+		CustomizeDiff: resourceRegistryCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group in which the registry is created.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The name of the registry. It must be between 3 and 33 characters long, start with a " +
+					"letter or a digit and contain only letters, digits, hyphens and underscores.",
+				ForceNew: true,
+				ValidateFunc: validation.StringMatch(
+					regexp.MustCompile("^[a-zA-Z0-9][a-zA-Z0-9_-]{2,32}$"),
+					"the name must be between 3 and 33 characters long, start with a letter or a digit and contain "+
+						"only letters, digits, hyphens and underscores",
+				),
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the registry.",
+			},
+			"location": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The primary Azure region of the registry. It must be one of the regions of `region`.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"identity": managedIdentitySchema(true),
+			"public_network_access": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Enabled",
+				Description: fmt.Sprintf(
+					"Is the registry reachable from the public network? Possible values are: %+q.",
+					GetAllowedPublicNetworkAccessValues(),
+				),
+				ValidateFunc: validation.StringInSlice(GetAllowedPublicNetworkAccessValues(), false),
+			},
+			"region": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Description: "The Azure regions in which the assets of the registry are replicated. Regions can be " +
+					"added to an existing registry, while changing the storage or the container registry of a region " +
+					"replaces the registry.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"location": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The Azure region.",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"storage_account_type": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "Standard_LRS",
+							Description: fmt.Sprintf(
+								"The type of the storage account created by Azure ML in the region. Ignored when "+
+									"`storage_account_id` is set. Possible values are: %+q.",
+								GetAllowedRegistryStorageAccountTypes(),
+							),
+							ValidateFunc: validation.StringInSlice(GetAllowedRegistryStorageAccountTypes(), false),
+						},
+						"storage_account_hns_enabled": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  false,
+							Description: "Is the hierarchical namespace enabled on the storage account created by " +
+								"Azure ML in the region? Ignored when `storage_account_id` is set.",
+						},
+						"storage_account_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "",
+							Description: "The ID of an existing storage account used by the registry in the region. " +
+								"If not specified, a storage account is created by Azure ML.",
+						},
+						"acr_sku": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "Premium",
+							Description: fmt.Sprintf(
+								"The SKU of the Azure Container Registry created by Azure ML in the region. Ignored "+
+									"when `acr_id` is set. Possible values are: %+q.",
+								GetAllowedRegistryAcrSkus(),
+							),
+							ValidateFunc: validation.StringInSlice(GetAllowedRegistryAcrSkus(), false),
+						},
+						"acr_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "",
+							Description: "The ID of an existing Azure Container Registry used by the registry in the " +
+								"region. If not specified, a container registry is created by Azure ML.",
+						},
+					},
+				},
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the registry.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"discovery_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The discovery URL of the registry.",
+			},
+			"ml_flow_registry_uri": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The MLflow tracking URI of the registry.",
+			},
+		},
+	}
+}
+
+// resourceRegistryCustomizeDiff checks that the primary location of the registry is one of its regions and that
+// the regions are unique, and replaces the registry when the settings of an existing region change.
+func resourceRegistryCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	location := d.Get("location").(string)
+	if d.NewValueKnown("region") {
+		if err := validateRegistryRegions(location, d.Get("region").(*schema.Set).List()); err != nil {
+			return err
+		}
+	}
+	if d.Id() != "" && d.HasChange("region") {
+		o, n := d.GetChange("region")
+		if registryRegionsChanged(o.(*schema.Set).List(), n.(*schema.Set).List()) {
+			return d.ForceNew("region")
+		}
+	}
+	return nil
+}
+
+// validateRegistryRegions checks that the regions of a registry are unique and include its primary location.
+func validateRegistryRegions(location string, regions []interface{}) error {
+	seen := make(map[string]bool, len(regions))
+	known := true
+	for _, v := range regions {
+		region := v.(map[string]interface{})
+		l := normalizeLocation(region["location"].(string))
+		if l == "" {
+			// Unknown until apply
+			known = false
+			continue
+		}
+		if seen[l] {
+			return fmt.Errorf("region %q is specified more than once", region["location"].(string))
+		}
+		seen[l] = true
+	}
+	if known && location != "" && !seen[normalizeLocation(location)] {
+		return fmt.Errorf("the primary location %q must be one of the regions of the registry", location)
+	}
+	return nil
+}
+
+// registryRegionsChanged returns true if the settings of any region present both in the old and in the new
+// regions of a registry differ, which cannot be changed without replacing the registry.
+func registryRegionsChanged(oldRegions, newRegions []interface{}) bool {
+	old := make(map[string]map[string]interface{}, len(oldRegions))
+	for _, v := range oldRegions {
+		region := v.(map[string]interface{})
+		old[normalizeLocation(region["location"].(string))] = region
+	}
+	for _, v := range newRegions {
+		region := v.(map[string]interface{})
+		o, ok := old[normalizeLocation(region["location"].(string))]
+		if !ok {
+			continue
+		}
+		for _, k := range []string{"storage_account_type", "storage_account_hns_enabled", "storage_account_id", "acr_sku", "acr_id"} {
+			if o[k] != region[k] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// normalizeLocation returns the canonical form of the name of an Azure region (e.g. "westeurope" for
+// "West Europe").
+func normalizeLocation(location string) string {
+	return strings.ToLower(strings.ReplaceAll(location, " ", ""))
+}
+
+func resourceRegistryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+
+	path := client.arm.registryId(resourceGroupName, name)
+	resp, err := client.arm.put(ctx, path, resourceRegistryGetResourceData(d, nil), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(path)
+	if err := waitForCreation(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for registry %s to be created: %w", name, err))
+	}
+
+	return resourceRegistryRead(ctx, d, meta)
+}
+
+func resourceRegistryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+
+	registry := new(registryResource)
+	err := getProvisioned(ctx, client.arm, client.arm.registryId(resourceGroupName, name), registry)
+	if err != nil {
+		if diags, ok := failedProvisioningDiagnostics(d, fmt.Sprintf("registry %s", name), err); ok {
+			return diags
+		}
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading registry %s", name),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(registry.Id)
+	return resourceRegistrySetResourceData(d, registry)
+}
+
+func resourceRegistryUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+
+	path := client.arm.registryId(resourceGroupName, name)
+	existing := new(registryResource)
+	if err := client.arm.get(ctx, path, existing); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The existing regions are submitted as returned by Azure ML, since they reference the storage accounts and
+	// the container registries created for them
+	resp, err := client.arm.put(ctx, path, resourceRegistryGetResourceData(d, existing), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := waitForCreation(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for registry %s to be updated: %w", name, err))
+	}
+
+	return resourceRegistryRead(ctx, d, meta)
+}
+
+func resourceRegistryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	name := d.Get("name").(string)
+	path := client.arm.registryId(resourceGroupName, name)
+
+	resp, err := client.arm.delete(ctx, path)
+	if err == nil {
+		err = waitForDeletion(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutDelete))
+	}
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting registry %s.", name),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+// importRegistry sets the resource group name and the name of the imported registry from its ID.
+func importRegistry(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceGroupName, name, segments, err := splitRegistryId(d.Id())
+	if err != nil || len(segments) != 0 {
+		return nil, fmt.Errorf(
+			"invalid ID %q, expected format is "+
+				"/subscriptions/<subscription-id>/resourceGroups/<resource-group>/providers/"+
+				"Microsoft.MachineLearningServices/registries/<registry>",
+			d.Id(),
+		)
+	}
+	if err := d.Set("resource_group_name", resourceGroupName); err != nil {
+		return nil, err
+	}
+	if err := d.Set("name", name); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceRegistryGetResourceData(d *schema.ResourceData, existing *registryResource) *registryResource {
+	existingRegions := make(map[string]registryRegionDetails)
+	if existing != nil {
+		for _, region := range existing.Properties.RegionDetails {
+			existingRegions[normalizeLocation(region.Location)] = region
+		}
+	}
+
+	regions := d.Get("region").(*schema.Set).List()
+	regionDetails := make([]registryRegionDetails, len(regions))
+	for i, v := range regions {
+		region := v.(map[string]interface{})
+		if details, ok := existingRegions[normalizeLocation(region["location"].(string))]; ok {
+			regionDetails[i] = details
+		} else {
+			regionDetails[i] = expandRegistryRegion(region)
+		}
+	}
+
+	registry := &registryResource{
+		Location: d.Get("location").(string),
+		Identity: expandManagedIdentity(d.Get("identity").([]interface{})),
+		Tags:     expandStringMap(d.Get("tags").(map[string]interface{})),
+		Properties: registryProperties{
+			PublicNetworkAccess: d.Get("public_network_access").(string),
+			RegionDetails:       regionDetails,
+		},
+	}
+	if registry.Identity == nil {
+		registry.Identity = &armManagedIdentity{Type: "SystemAssigned"}
+	}
+	return registry
+}
+
+func expandRegistryRegion(region map[string]interface{}) registryRegionDetails {
+	details := registryRegionDetails{Location: region["location"].(string)}
+
+	storage := registryStorageAccountDetails{}
+	if id := region["storage_account_id"].(string); id != "" {
+		storage.UserCreatedStorageAccount = &registryUserCreatedAccount{ArmResourceId: registryResourceReference{ResourceId: id}}
+	} else {
+		storage.SystemCreatedStorageAccount = &registrySystemCreatedStorageAccount{
+			StorageAccountType:       region["storage_account_type"].(string),
+			StorageAccountHnsEnabled: region["storage_account_hns_enabled"].(bool),
+		}
+	}
+	details.StorageAccountDetails = []registryStorageAccountDetails{storage}
+
+	acr := registryAcrDetails{}
+	if id := region["acr_id"].(string); id != "" {
+		acr.UserCreatedAcrAccount = &registryUserCreatedAccount{ArmResourceId: registryResourceReference{ResourceId: id}}
+	} else {
+		acr.SystemCreatedAcrAccount = &registrySystemCreatedAcrAccount{AcrAccountSku: region["acr_sku"].(string)}
+	}
+	details.AcrDetails = []registryAcrDetails{acr}
+
+	return details
+}
+
+func flattenRegistryRegion(details registryRegionDetails) map[string]interface{} {
+	region := map[string]interface{}{
+		"location":                    details.Location,
+		"storage_account_type":        "Standard_LRS",
+		"storage_account_hns_enabled": false,
+		"storage_account_id":          "",
+		"acr_sku":                     "Premium",
+		"acr_id":                      "",
+	}
+	if len(details.StorageAccountDetails) > 0 {
+		storage := details.StorageAccountDetails[0]
+		if storage.UserCreatedStorageAccount != nil {
+			region["storage_account_id"] = storage.UserCreatedStorageAccount.ArmResourceId.ResourceId
+		} else if storage.SystemCreatedStorageAccount != nil {
+			region["storage_account_type"] = storage.SystemCreatedStorageAccount.StorageAccountType
+			region["storage_account_hns_enabled"] = storage.SystemCreatedStorageAccount.StorageAccountHnsEnabled
+		}
+	}
+	if len(details.AcrDetails) > 0 {
+		acr := details.AcrDetails[0]
+		if acr.UserCreatedAcrAccount != nil {
+			region["acr_id"] = acr.UserCreatedAcrAccount.ArmResourceId.ResourceId
+		} else if acr.SystemCreatedAcrAccount != nil {
+			region["acr_sku"] = acr.SystemCreatedAcrAccount.AcrAccountSku
+		}
+	}
+	return region
+}
+
+func resourceRegistrySetResourceData(d *schema.ResourceData, registry *registryResource) diag.Diagnostics {
+	if err := d.Set("location", registry.Location); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("identity", flattenManagedIdentity(registry.Identity)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", registry.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+
+	props := registry.Properties
+	if err := d.Set("public_network_access", props.PublicNetworkAccess); err != nil {
+		return diag.FromErr(err)
+	}
+	regions := make([]interface{}, len(props.RegionDetails))
+	for i, details := range props.RegionDetails {
+		regions[i] = flattenRegistryRegion(details)
+	}
+	if err := d.Set("region", regions); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("discovery_url", props.DiscoveryUrl); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("ml_flow_registry_uri", props.MlFlowRegistryUri); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+type registryResource struct {
+	Id         string              `json:"id,omitempty"`
+	Name       string              `json:"name,omitempty"`
+	Location   string              `json:"location"`
+	Identity   *armManagedIdentity `json:"identity,omitempty"`
+	Tags       map[string]string   `json:"tags,omitempty"`
+	Properties registryProperties  `json:"properties"`
+}
+
+type registryProperties struct {
+	DiscoveryUrl        string                  `json:"discoveryUrl,omitempty"`
+	MlFlowRegistryUri   string                  `json:"mlFlowRegistryUri,omitempty"`
+	PublicNetworkAccess string                  `json:"publicNetworkAccess,omitempty"`
+	RegionDetails       []registryRegionDetails `json:"regionDetails"`
+}
+
+type registryRegionDetails struct {
+	Location              string                          `json:"location"`
+	AcrDetails            []registryAcrDetails            `json:"acrDetails,omitempty"`
+	StorageAccountDetails []registryStorageAccountDetails `json:"storageAccountDetails,omitempty"`
+}
+
+type registryAcrDetails struct {
+	SystemCreatedAcrAccount *registrySystemCreatedAcrAccount `json:"systemCreatedAcrAccount,omitempty"`
+	UserCreatedAcrAccount   *registryUserCreatedAccount      `json:"userCreatedAcrAccount,omitempty"`
+}
+
+type registryStorageAccountDetails struct {
+	SystemCreatedStorageAccount *registrySystemCreatedStorageAccount `json:"systemCreatedStorageAccount,omitempty"`
+	UserCreatedStorageAccount   *registryUserCreatedAccount          `json:"userCreatedStorageAccount,omitempty"`
+}
+
+type registrySystemCreatedAcrAccount struct {
+	AcrAccountName string                     `json:"acrAccountName,omitempty"`
+	AcrAccountSku  string                     `json:"acrAccountSku,omitempty"`
+	ArmResourceId  *registryResourceReference `json:"armResourceId,omitempty"`
+}
+
+type registrySystemCreatedStorageAccount struct {
+	StorageAccountName       string                     `json:"storageAccountName,omitempty"`
+	StorageAccountType       string                     `json:"storageAccountType,omitempty"`
+	StorageAccountHnsEnabled bool                       `json:"storageAccountHnsEnabled"`
+	AllowBlobPublicAccess    bool                       `json:"allowBlobPublicAccess"`
+	ArmResourceId            *registryResourceReference `json:"armResourceId,omitempty"`
+}
+
+type registryUserCreatedAccount struct {
+	ArmResourceId registryResourceReference `json:"armResourceId"`
+}
+
+type registryResourceReference struct {
+	ResourceId string `json:"resourceId"`
+}
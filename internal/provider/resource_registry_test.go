@@ -0,0 +1,59 @@
+package provider
+
+import "testing"
+
+func testRegistryRegion(location, storageAccountType, acrId string) map[string]interface{} {
+	return map[string]interface{}{
+		"location":                    location,
+		"storage_account_type":        storageAccountType,
+		"storage_account_hns_enabled": false,
+		"storage_account_id":          "",
+		"acr_sku":                     "Premium",
+		"acr_id":                      acrId,
+	}
+}
+
+func TestValidateRegistryRegions(t *testing.T) {
+	regions := []interface{}{
+		testRegistryRegion("westeurope", "Standard_LRS", ""),
+		testRegistryRegion("northeurope", "Standard_LRS", ""),
+	}
+	if err := validateRegistryRegions("West Europe", regions); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateRegistryRegions("eastus", regions); err == nil {
+		t.Errorf("expected error for a primary location which is not a region")
+	}
+
+	duplicated := append(regions, testRegistryRegion("North Europe", "Standard_GRS", ""))
+	if err := validateRegistryRegions("westeurope", duplicated); err == nil {
+		t.Errorf("expected error for duplicated regions")
+	}
+
+	unknown := []interface{}{testRegistryRegion("", "Standard_LRS", "")}
+	if err := validateRegistryRegions("westeurope", unknown); err != nil {
+		t.Errorf("unexpected error for unknown regions: %v", err)
+	}
+}
+
+func TestRegistryRegionsChanged(t *testing.T) {
+	old := []interface{}{testRegistryRegion("westeurope", "Standard_LRS", "")}
+
+	added := []interface{}{
+		testRegistryRegion("westeurope", "Standard_LRS", ""),
+		testRegistryRegion("northeurope", "Standard_GRS", ""),
+	}
+	if registryRegionsChanged(old, added) {
+		t.Errorf("adding a region must not change the existing regions")
+	}
+
+	changedStorage := []interface{}{testRegistryRegion("westeurope", "Standard_GRS", "")}
+	if !registryRegionsChanged(old, changedStorage) {
+		t.Errorf("expected change of the storage account type")
+	}
+
+	changedAcr := []interface{}{testRegistryRegion("West Europe", "Standard_LRS", "/subscriptions/sub/acr")}
+	if !registryRegionsChanged(old, changedAcr) {
+		t.Errorf("expected change of the container registry")
+	}
+}
@@ -0,0 +1,588 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
+	"io/ioutil"
+	"time"
+)
+
+func GetAllowedRecurrenceFrequencies() []string {
+	return []string{
+		"Minute",
+		"Hour",
+		"Day",
+		"Week",
+		"Month",
+	}
+}
+
+func GetAllowedWeekDays() []string {
+	return []string{
+		"Monday",
+		"Tuesday",
+		"Wednesday",
+		"Thursday",
+		"Friday",
+		"Saturday",
+		"Sunday",
+	}
+}
+
+func resourceSchedule() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Schedule of an Azure ML Workspace, which creates a job, such as a pipeline job, " +
+			"whenever its cron or recurrence trigger fires.",
+
+		CreateContext: resourceScheduleCreate,
+		ReadContext:   resourceScheduleRead,
+		UpdateContext: resourceScheduleUpdate,
+		DeleteContext: resourceScheduleDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceChild("schedules"),
+		},
+
+		CustomizeDiff: resourceScheduleCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the schedule belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the schedule belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the schedule.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the schedule.",
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The display name of the schedule.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "",
+				Description: "The description of the schedule.",
+			},
+			"is_enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Is the schedule enabled? Disabled schedules do not create jobs.",
+			},
+			"cron": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Description:  "The cron trigger of the schedule.",
+				ExactlyOneOf: []string{"cron", "recurrence"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"expression": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The cron expression, in the `<minutes> <hours> <days> <months> <week days>` form.",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+					},
+				},
+			},
+			"recurrence": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Description:  "The recurrence trigger of the schedule.",
+				ExactlyOneOf: []string{"cron", "recurrence"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"frequency": {
+							Type:     schema.TypeString,
+							Required: true,
+							Description: fmt.Sprintf(
+								"The unit of the interval between the runs. Possible values are: %+q.",
+								GetAllowedRecurrenceFrequencies(),
+							),
+							ValidateFunc: validation.StringInSlice(GetAllowedRecurrenceFrequencies(), false),
+						},
+						"interval": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							Description:  "The number of `frequency` units between the runs.",
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"hours": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The hours of the day at which the runs start, for the `Day`, `Week` and `Month` frequencies.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(0, 23),
+							},
+						},
+						"minutes": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The minutes of the hour at which the runs start, for the `Day`, `Week` and `Month` frequencies.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(0, 59),
+							},
+						},
+						"week_days": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							Description: fmt.Sprintf(
+								"The days of the week on which the runs start, for the `Week` frequency. Possible values are: %+q.",
+								GetAllowedWeekDays(),
+							),
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(GetAllowedWeekDays(), false),
+							},
+						},
+						"month_days": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "The days of the month on which the runs start, for the `Month` frequency.",
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IntBetween(1, 31),
+							},
+						},
+					},
+				},
+			},
+			"time_zone": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "UTC",
+				Description: "The time zone of the trigger, as a Windows time zone name (e.g. `UTC` or " +
+					"`W. Europe Standard Time`).",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"start_time": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				Description: "The time from which the trigger is active, in the `YYYY-MM-DDThh:mm:ss` form relative " +
+					"to `time_zone`. Defaults to the creation time of the schedule.",
+				ValidateFunc:     isValidScheduleTime,
+				DiffSuppressFunc: suppressEquivalentScheduleTimeDiff,
+			},
+			"end_time": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The time after which the trigger is not active anymore, in the `YYYY-MM-DDThh:mm:ss` " +
+					"form relative to `time_zone`.",
+				ValidateFunc:     isValidScheduleTime,
+				DiffSuppressFunc: suppressEquivalentScheduleTimeDiff,
+			},
+			"create_job": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The YAML definition of the job created by the schedule, in the form of the properties " +
+					"of an Azure ML job of the REST API (e.g. `jobType: Pipeline`).",
+				ValidateFunc:     validation.StringIsNotEmpty,
+				ExactlyOneOf:     []string{"create_job", "create_job_path"},
+				DiffSuppressFunc: suppressEquivalentJobDefinitionDiff,
+			},
+			"create_job_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The local path of the YAML definition of the job created by the schedule.",
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"create_job", "create_job_path"},
+			},
+			"create_job_hash": {
+				Type:     schema.TypeString,
+				Computed: true,
+				Description: "The SHA-256 hash of the definition of the job created by the schedule. Formatting " +
+					"changes of the YAML do not change the hash.",
+			},
+			"tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The tags of the schedule.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// resourceScheduleCustomizeDiff computes the hash of the job definition, so that changes to the file at
+// create_job_path are detected as well.
+func resourceScheduleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("create_job") || !d.NewValueKnown("create_job_path") {
+		return d.SetNewComputed("create_job_hash")
+	}
+	job, err := readJobDefinition(d.Get("create_job").(string), d.Get("create_job_path").(string))
+	if err != nil {
+		return err
+	}
+	h, err := yamlSpecHash(job)
+	if err != nil {
+		return err
+	}
+	if o, _ := d.GetChange("create_job_hash"); o.(string) == h {
+		return nil
+	}
+	return d.SetNew("create_job_hash", h)
+}
+
+func resourceScheduleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	schedule, err := resourceScheduleGetResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	path := schedulePath(client.arm, resourceGroupName, workspaceName, name)
+	resp, err := client.arm.put(ctx, path, schedule, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := waitForCreation(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutCreate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for schedule %s to be created: %w", name, err))
+	}
+
+	d.SetId(path)
+	return resourceScheduleRead(ctx, d, meta)
+}
+
+func resourceScheduleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	schedule := new(scheduleResource)
+	err := client.arm.get(ctx, schedulePath(client.arm, resourceGroupName, workspaceName, name), schedule)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading schedule %s", name),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(schedule.Id)
+	return resourceScheduleSetResourceData(d, schedule)
+}
+
+func resourceScheduleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	schedule, err := resourceScheduleGetResourceData(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	path := schedulePath(client.arm, resourceGroupName, workspaceName, name)
+	resp, err := client.arm.put(ctx, path, schedule, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := waitForCreation(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for schedule %s to be updated: %w", name, err))
+	}
+
+	return resourceScheduleRead(ctx, d, meta)
+}
+
+func resourceScheduleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	path := schedulePath(client.arm, resourceGroupName, workspaceName, name)
+
+	resp, err := client.arm.delete(ctx, path)
+	if err == nil {
+		err = waitForDeletion(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutDelete))
+	}
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting schedule %s.", name),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceScheduleGetResourceData(d *schema.ResourceData) (*scheduleResource, error) {
+	job, err := readJobDefinition(d.Get("create_job").(string), d.Get("create_job_path").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	trigger := &scheduleTrigger{
+		TimeZone:  d.Get("time_zone").(string),
+		StartTime: d.Get("start_time").(string),
+		EndTime:   d.Get("end_time").(string),
+	}
+	if l := d.Get("cron").([]interface{}); len(l) > 0 && l[0] != nil {
+		trigger.TriggerType = "Cron"
+		trigger.Expression = l[0].(map[string]interface{})["expression"].(string)
+	}
+	if l := d.Get("recurrence").([]interface{}); len(l) > 0 && l[0] != nil {
+		recurrence := l[0].(map[string]interface{})
+		trigger.TriggerType = "Recurrence"
+		trigger.Frequency = recurrence["frequency"].(string)
+		trigger.Interval = recurrence["interval"].(int)
+		schedule := &recurrenceSchedule{
+			Hours:     expandIntList(recurrence["hours"].([]interface{})),
+			Minutes:   expandIntList(recurrence["minutes"].([]interface{})),
+			WeekDays:  expandStringList(recurrence["week_days"].(*schema.Set).List()),
+			MonthDays: expandIntList(recurrence["month_days"].([]interface{})),
+		}
+		// The runs of the Minute and Hour frequencies are not bound to specific times
+		if len(schedule.Hours)+len(schedule.Minutes)+len(schedule.WeekDays)+len(schedule.MonthDays) > 0 {
+			trigger.Schedule = schedule
+		}
+	}
+
+	return &scheduleResource{
+		Properties: scheduleProperties{
+			DisplayName: d.Get("display_name").(string),
+			Description: d.Get("description").(string),
+			Tags:        expandStringMap(d.Get("tags").(map[string]interface{})),
+			IsEnabled:   d.Get("is_enabled").(bool),
+			Trigger:     trigger,
+			Action: &scheduleAction{
+				ActionType:    "CreateJob",
+				JobDefinition: job,
+			},
+		},
+	}, nil
+}
+
+func resourceScheduleSetResourceData(d *schema.ResourceData, schedule *scheduleResource) diag.Diagnostics {
+	props := schedule.Properties
+	if err := d.Set("display_name", props.DisplayName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("description", props.Description); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("tags", props.Tags); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_enabled", props.IsEnabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The job definition is not set, since Azure ML fills in the properties not specified by the definition
+	if props.Trigger == nil {
+		return nil
+	}
+	trigger := props.Trigger
+	if err := d.Set("time_zone", trigger.TimeZone); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("start_time", trigger.StartTime); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("end_time", trigger.EndTime); err != nil {
+		return diag.FromErr(err)
+	}
+	cron := make([]interface{}, 0, 1)
+	recurrence := make([]interface{}, 0, 1)
+	switch trigger.TriggerType {
+	case "Cron":
+		cron = append(cron, map[string]interface{}{"expression": trigger.Expression})
+	case "Recurrence":
+		r := map[string]interface{}{
+			"frequency": trigger.Frequency,
+			"interval":  trigger.Interval,
+		}
+		if trigger.Schedule != nil {
+			r["hours"] = trigger.Schedule.Hours
+			r["minutes"] = trigger.Schedule.Minutes
+			r["week_days"] = trigger.Schedule.WeekDays
+			r["month_days"] = trigger.Schedule.MonthDays
+		}
+		recurrence = append(recurrence, r)
+	}
+	if err := d.Set("cron", cron); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("recurrence", recurrence); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// readJobDefinition parses the job definition provided inline or, if content is empty, read from the file
+// at the path provided as argument.
+func readJobDefinition(content, path string) (map[string]interface{}, error) {
+	if content == "" && path != "" {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read job definition: %w", err)
+		}
+		content = string(b)
+	}
+	return parseJobDefinition(content)
+}
+
+// parseJobDefinition parses a YAML job definition into the object submitted as jobDefinition to ARM.
+func parseJobDefinition(content string) (map[string]interface{}, error) {
+	var job map[string]interface{}
+	if err := yaml.Unmarshal([]byte(content), &job); err != nil {
+		return nil, fmt.Errorf("invalid job definition: %w", err)
+	}
+	if len(job) == 0 {
+		return nil, fmt.Errorf("invalid job definition: the definition must be a non-empty YAML mapping")
+	}
+	if t, ok := job["jobType"].(string); !ok || t == "" {
+		return nil, fmt.Errorf("invalid job definition: %q is required", "jobType")
+	}
+	return job, nil
+}
+
+// suppressEquivalentJobDefinitionDiff suppresses the diff of job definitions that differ only by formatting.
+func suppressEquivalentJobDefinitionDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldJob, err := parseJobDefinition(old)
+	if err != nil {
+		return false
+	}
+	newJob, err := parseJobDefinition(new)
+	if err != nil {
+		return false
+	}
+	oldHash, err := yamlSpecHash(oldJob)
+	if err != nil {
+		return false
+	}
+	newHash, err := yamlSpecHash(newJob)
+	return err == nil && oldHash == newHash
+}
+
+// scheduleTimeLayouts are the layouts accepted for the start and the end times of schedules. Azure ML may
+// return the times with a different precision than the configured ones.
+var scheduleTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04:05.9999999",
+	time.RFC3339,
+	time.RFC3339Nano,
+}
+
+func parseScheduleTime(s string) (time.Time, error) {
+	var err error
+	for _, layout := range scheduleTimeLayouts {
+		var t time.Time
+		if t, err = time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+func isValidScheduleTime(val interface{}, key string) (warns []string, errs []error) {
+	if _, err := parseScheduleTime(val.(string)); err != nil {
+		errs = append(errs, fmt.Errorf("%q must be in the YYYY-MM-DDThh:mm:ss form, got %q", key, val))
+	}
+	return
+}
+
+// suppressEquivalentScheduleTimeDiff suppresses the diff of schedule times that differ only by format.
+func suppressEquivalentScheduleTimeDiff(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := parseScheduleTime(old)
+	if err != nil {
+		return false
+	}
+	newTime, err := parseScheduleTime(new)
+	return err == nil && oldTime.Equal(newTime)
+}
+
+func schedulePath(c *armClient, resourceGroupName, workspaceName, name string) string {
+	return fmt.Sprintf("%s/schedules/%s", c.workspaceId(resourceGroupName, workspaceName), name)
+}
+
+type scheduleResource struct {
+	Id         string             `json:"id,omitempty"`
+	Name       string             `json:"name,omitempty"`
+	Properties scheduleProperties `json:"properties"`
+}
+
+type scheduleProperties struct {
+	DisplayName       string            `json:"displayName,omitempty"`
+	Description       string            `json:"description,omitempty"`
+	Tags              map[string]string `json:"tags,omitempty"`
+	IsEnabled         bool              `json:"isEnabled"`
+	Trigger           *scheduleTrigger  `json:"trigger"`
+	Action            *scheduleAction   `json:"action"`
+	ProvisioningState string            `json:"provisioningState,omitempty"`
+}
+
+type scheduleTrigger struct {
+	TriggerType string              `json:"triggerType"`
+	TimeZone    string              `json:"timeZone,omitempty"`
+	StartTime   string              `json:"startTime,omitempty"`
+	EndTime     string              `json:"endTime,omitempty"`
+	Expression  string              `json:"expression,omitempty"`
+	Frequency   string              `json:"frequency,omitempty"`
+	Interval    int                 `json:"interval,omitempty"`
+	Schedule    *recurrenceSchedule `json:"schedule,omitempty"`
+}
+
+type recurrenceSchedule struct {
+	Hours     []int    `json:"hours"`
+	Minutes   []int    `json:"minutes"`
+	WeekDays  []string `json:"weekDays,omitempty"`
+	MonthDays []int    `json:"monthDays,omitempty"`
+}
+
+type scheduleAction struct {
+	ActionType    string                 `json:"actionType"`
+	JobDefinition map[string]interface{} `json:"jobDefinition"`
+}
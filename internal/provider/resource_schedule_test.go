@@ -0,0 +1,56 @@
+package provider
+
+import "testing"
+
+func TestParseJobDefinition(t *testing.T) {
+	job, err := parseJobDefinition(`
+jobType: Pipeline
+displayName: retrain
+jobs:
+  train:
+    type: command
+    componentId: azureml:train:1
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job["jobType"] != "Pipeline" {
+		t.Errorf("unexpected job type %v", job["jobType"])
+	}
+
+	invalid := []string{
+		"",
+		"- jobType: Pipeline",
+		"displayName: retrain",
+		"jobType: [",
+	}
+	for _, content := range invalid {
+		if _, err := parseJobDefinition(content); err == nil {
+			t.Errorf("expected error for job definition %q", content)
+		}
+	}
+}
+
+func TestSuppressEquivalentJobDefinitionDiff(t *testing.T) {
+	old := "jobType: Pipeline\njobs: {train: {type: command}}\n"
+	reformatted := "jobs:\n  train:\n    type: command\njobType: Pipeline\n"
+	if !suppressEquivalentJobDefinitionDiff("create_job", old, reformatted, nil) {
+		t.Errorf("expected diff of reformatted job definitions to be suppressed")
+	}
+	changed := "jobType: Pipeline\njobs: {train: {type: spark}}\n"
+	if suppressEquivalentJobDefinitionDiff("create_job", old, changed, nil) {
+		t.Errorf("expected diff of changed job definitions not to be suppressed")
+	}
+}
+
+func TestSuppressEquivalentScheduleTimeDiff(t *testing.T) {
+	if !suppressEquivalentScheduleTimeDiff("start_time", "2024-01-01T08:00:00.0000000", "2024-01-01T08:00:00", nil) {
+		t.Errorf("expected diff of equivalent times to be suppressed")
+	}
+	if suppressEquivalentScheduleTimeDiff("start_time", "2024-01-01T08:00:00", "2024-01-01T09:00:00", nil) {
+		t.Errorf("expected diff of different times not to be suppressed")
+	}
+	if _, errs := isValidScheduleTime("01/01/2024", "start_time"); len(errs) == 0 {
+		t.Errorf("expected error for invalid time")
+	}
+}
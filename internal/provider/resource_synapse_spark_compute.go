@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"time"
+)
+
+func resourceSynapseSparkCompute() *schema.Resource {
+	return &schema.Resource{
+		Description: "Attaches an existing Apache Spark pool of an Azure Synapse Analytics workspace to an Azure ML " +
+			"Workspace. Destroying the resource detaches the pool from the workspace, without deleting it.",
+
+		CreateContext: resourceSynapseSparkComputeCreate,
+		ReadContext:   resourceSynapseSparkComputeRead,
+		DeleteContext: detachCompute,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceChild("computes"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: attachedComputeSchema(
+			"Synapse Spark compute",
+			"The ID of the Apache Spark pool of the Azure Synapse Analytics workspace.",
+			true,
+			map[string]*schema.Schema{
+				"node_size": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The size of the nodes of the Spark pool.",
+				},
+				"spark_version": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The Apache Spark version of the Spark pool.",
+				},
+			},
+		),
+	}
+}
+
+func resourceSynapseSparkComputeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The properties of the pool are read from the pool itself
+	if diags := attachCompute(ctx, d, meta, "SynapseSpark", nil); diags.HasError() {
+		return diags
+	}
+	return resourceSynapseSparkComputeRead(ctx, d, meta)
+}
+
+func resourceSynapseSparkComputeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	compute, diags := readAttachedCompute(ctx, d, meta, true)
+	if compute == nil {
+		return diags
+	}
+
+	properties := new(synapseSparkComputeProperties)
+	if len(compute.Properties.Properties) > 0 {
+		if err := json.Unmarshal(compute.Properties.Properties, properties); err != nil {
+			return diag.FromErr(fmt.Errorf("unable to parse the properties of the Synapse Spark compute: %w", err))
+		}
+	}
+	if err := d.Set("node_size", properties.NodeSize); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("spark_version", properties.SparkVersion); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+type synapseSparkComputeProperties struct {
+	NodeSize     string `json:"nodeSize,omitempty"`
+	SparkVersion string `json:"sparkVersion,omitempty"`
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"testing"
+)
+
+func TestSynapseSparkComputeCreate(t *testing.T) {
+	server := newTestComputeServer(t, `{
+  "id": "`+testComputeId+`",
+  "name": "compute",
+  "location": "westeurope",
+  "identity": {"type": "SystemAssigned", "principalId": "principal-id", "tenantId": "tenant-id"},
+  "properties": {
+    "computeType": "SynapseSpark",
+    "resourceId": "pool-id",
+    "provisioningState": "Succeeded",
+    "properties": {"nodeSize": "Medium", "sparkVersion": "3.3"}
+  }
+}`)
+	r := resourceSynapseSparkCompute()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"resource_group_name": "rg",
+		"workspace_name":      "ws",
+		"name":                "compute",
+		"location":            "westeurope",
+		"resource_id":         "pool-id",
+		"identity":            []interface{}{map[string]interface{}{"type": "SystemAssigned"}},
+	})
+
+	if diags := r.CreateContext(context.Background(), d, server.client()); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+
+	// The properties of the pool are not sent, as Azure ML reads them from the pool
+	properties := server.putBody["properties"].(map[string]interface{})
+	if properties["computeType"] != "SynapseSpark" || properties["resourceId"] != "pool-id" {
+		t.Errorf("unexpected compute %v", properties)
+	}
+	if _, ok := properties["properties"]; ok {
+		t.Errorf("unexpected properties %v", properties["properties"])
+	}
+	if identity, ok := server.putBody["identity"].(map[string]interface{}); !ok || identity["type"] != "SystemAssigned" {
+		t.Errorf("unexpected identity %v", server.putBody["identity"])
+	}
+
+	if d.Get("node_size") != "Medium" || d.Get("spark_version") != "3.3" {
+		t.Errorf("unexpected node size %q and Spark version %q", d.Get("node_size"), d.Get("spark_version"))
+	}
+	if d.Get("identity.0.principal_id") != "principal-id" {
+		t.Errorf("unexpected identity %v", d.Get("identity"))
+	}
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+func resourceVirtualMachineCompute() *schema.Resource {
+	return &schema.Resource{
+		Description: "Attaches an existing Linux virtual machine to an Azure ML Workspace, which connects to it " +
+			"through SSH. Destroying the resource detaches the virtual machine from the workspace, without deleting it.",
+
+		CreateContext: resourceVirtualMachineComputeCreate,
+		ReadContext:   resourceVirtualMachineComputeRead,
+		DeleteContext: detachCompute,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceChild("computes"),
+		},
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: attachedComputeSchema(
+			"virtual machine compute",
+			"The ID of the virtual machine.",
+			false,
+			map[string]*schema.Schema{
+				"ssh_port": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      22,
+					Description:  "The port on which the SSH server of the virtual machine listens.",
+					ForceNew:     true,
+					ValidateFunc: validation.IsPortNumber,
+				},
+				"admin_username": {
+					Type:         schema.TypeString,
+					Required:     true,
+					Description:  "The name of the administrator account used for connecting to the virtual machine.",
+					ForceNew:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+				"admin_password": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Sensitive:    true,
+					Description:  "The password of the administrator account. Conflicts with `ssh_private_key`.",
+					ForceNew:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					ExactlyOneOf: []string{"admin_password", "ssh_private_key"},
+				},
+				"ssh_private_key": {
+					Type:      schema.TypeString,
+					Optional:  true,
+					Sensitive: true,
+					Description: "The private SSH key of the administrator account. Conflicts with `admin_password`. " +
+						"Passphrase-protected keys are not supported.",
+					ForceNew:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					ExactlyOneOf: []string{"admin_password", "ssh_private_key"},
+				},
+				"ssh_public_key": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Description:  "The public SSH key of the administrator account, paired with `ssh_private_key`.",
+					ForceNew:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					RequiredWith: []string{"ssh_private_key"},
+				},
+				"vm_size": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The size of the virtual machine.",
+				},
+			},
+		),
+	}
+}
+
+func resourceVirtualMachineComputeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	properties := virtualMachineComputeProperties{
+		SshPort: d.Get("ssh_port").(int),
+		AdministratorAccount: &virtualMachineAdministratorAccount{
+			Username:       d.Get("admin_username").(string),
+			Password:       d.Get("admin_password").(string),
+			PrivateKeyData: d.Get("ssh_private_key").(string),
+			PublicKeyData:  d.Get("ssh_public_key").(string),
+		},
+	}
+	if diags := attachCompute(ctx, d, meta, "VirtualMachine", properties); diags.HasError() {
+		return diags
+	}
+	return resourceVirtualMachineComputeRead(ctx, d, meta)
+}
+
+func resourceVirtualMachineComputeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	compute, diags := readAttachedCompute(ctx, d, meta, false)
+	if compute == nil {
+		return diags
+	}
+
+	// The credentials of the administrator account are not returned by Azure ML
+	properties := new(virtualMachineComputeProperties)
+	if len(compute.Properties.Properties) > 0 {
+		if err := json.Unmarshal(compute.Properties.Properties, properties); err != nil {
+			return diag.FromErr(fmt.Errorf("unable to parse the properties of the virtual machine compute: %w", err))
+		}
+	}
+	if properties.SshPort != 0 {
+		if err := d.Set("ssh_port", properties.SshPort); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if properties.AdministratorAccount != nil && properties.AdministratorAccount.Username != "" {
+		if err := d.Set("admin_username", properties.AdministratorAccount.Username); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err := d.Set("vm_size", properties.VirtualMachineSize); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+type virtualMachineComputeProperties struct {
+	VirtualMachineSize   string                              `json:"virtualMachineSize,omitempty"`
+	SshPort              int                                 `json:"sshPort,omitempty"`
+	AdministratorAccount *virtualMachineAdministratorAccount `json:"administratorAccount,omitempty"`
+}
+
+type virtualMachineAdministratorAccount struct {
+	Username       string `json:"username"`
+	Password       string `json:"password,omitempty"`
+	PrivateKeyData string `json:"privateKeyData,omitempty"`
+	PublicKeyData  string `json:"publicKeyData,omitempty"`
+}
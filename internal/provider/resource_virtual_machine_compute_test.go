@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"reflect"
+	"testing"
+)
+
+const testVirtualMachineCompute = `{
+  "id": "` + testComputeId + `",
+  "name": "compute",
+  "location": "westeurope",
+  "properties": {
+    "computeType": "VirtualMachine",
+    "resourceId": "vm-id",
+    "provisioningState": "Succeeded",
+    "properties": {
+      "virtualMachineSize": "Standard_D2s_v3",
+      "sshPort": 2222,
+      "administratorAccount": {"username": "azureuser"}
+    }
+  }
+}`
+
+func testVirtualMachineComputeConfig() map[string]interface{} {
+	return map[string]interface{}{
+		"resource_group_name": "rg",
+		"workspace_name":      "ws",
+		"name":                "compute",
+		"location":            "westeurope",
+		"resource_id":         "vm-id",
+		"ssh_port":            2222,
+		"admin_username":      "azureuser",
+		"ssh_private_key":     "private-key",
+		"ssh_public_key":      "public-key",
+	}
+}
+
+func TestVirtualMachineComputeCreate(t *testing.T) {
+	server := newTestComputeServer(t, testVirtualMachineCompute)
+	r := resourceVirtualMachineCompute()
+	d := schema.TestResourceDataRaw(t, r.Schema, testVirtualMachineComputeConfig())
+
+	if diags := r.CreateContext(context.Background(), d, server.client()); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+
+	properties := server.putBody["properties"].(map[string]interface{})
+	if properties["computeType"] != "VirtualMachine" || properties["resourceId"] != "vm-id" {
+		t.Errorf("unexpected compute %v", properties)
+	}
+	expected := map[string]interface{}{
+		"sshPort": float64(2222),
+		"administratorAccount": map[string]interface{}{
+			"username":       "azureuser",
+			"privateKeyData": "private-key",
+			"publicKeyData":  "public-key",
+		},
+	}
+	if !reflect.DeepEqual(properties["properties"], expected) {
+		t.Errorf("unexpected properties %v, expected %v", properties["properties"], expected)
+	}
+
+	if d.Id() != testComputeId || d.Get("vm_size") != "Standard_D2s_v3" {
+		t.Errorf("unexpected compute %s of size %q", d.Id(), d.Get("vm_size"))
+	}
+}
+
+func TestVirtualMachineComputeRead(t *testing.T) {
+	server := newTestComputeServer(t, testVirtualMachineCompute)
+	r := resourceVirtualMachineCompute()
+	config := testVirtualMachineComputeConfig()
+	config["ssh_port"] = 22
+	config["admin_username"] = "admin"
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId(testComputeId)
+
+	if diags := r.ReadContext(context.Background(), d, server.client()); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	if d.Get("ssh_port") != 2222 || d.Get("admin_username") != "azureuser" {
+		t.Errorf("unexpected SSH port %v and administrator %q", d.Get("ssh_port"), d.Get("admin_username"))
+	}
+	// The credentials are not returned by Azure ML
+	if d.Get("ssh_private_key") != "private-key" {
+		t.Errorf("unexpected private key %q", d.Get("ssh_private_key"))
+	}
+
+	// The values not returned by Azure ML are left untouched
+	server.setCompute(`{"id": "` + testComputeId + `", "properties": {"provisioningState": "Succeeded", "properties": {}}}`)
+	if diags := r.ReadContext(context.Background(), d, server.client()); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+	if d.Get("ssh_port") != 2222 || d.Get("admin_username") != "azureuser" {
+		t.Errorf("unexpected SSH port %v and administrator %q", d.Get("ssh_port"), d.Get("admin_username"))
+	}
+}
+
+func TestVirtualMachineComputeCredentials(t *testing.T) {
+	testCases := []struct {
+		name        string
+		credentials map[string]interface{}
+		expectError bool
+	}{
+		{
+			name:        "Password",
+			credentials: map[string]interface{}{"admin_password": "s3cret"},
+		},
+		{
+			name:        "Private key",
+			credentials: map[string]interface{}{"ssh_private_key": "private-key"},
+		},
+		{
+			name:        "Missing credentials",
+			credentials: map[string]interface{}{},
+			expectError: true,
+		},
+		{
+			name:        "Password and private key",
+			credentials: map[string]interface{}{"admin_password": "s3cret", "ssh_private_key": "private-key"},
+			expectError: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := testVirtualMachineComputeConfig()
+			delete(config, "ssh_private_key")
+			delete(config, "ssh_public_key")
+			for k, v := range tc.credentials {
+				config[k] = v
+			}
+			diags := resourceVirtualMachineCompute().Validate(terraform.NewResourceConfigRaw(config))
+			if diags.HasError() != tc.expectError {
+				t.Errorf("expected error: %t, got %v", tc.expectError, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,479 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"sort"
+	"strings"
+)
+
+func GetAllowedWorkspaceConnectionAuthTypes() []string {
+	return []string{
+		"None",
+		"PAT",
+		"UsernamePassword",
+		"SAS",
+		"ServicePrincipal",
+		"ApiKey",
+		"ManagedIdentity",
+	}
+}
+
+// workspaceConnectionCredentials maps each authentication type of a workspace connection to the required and
+// the optional arguments of its credentials block.
+var workspaceConnectionCredentials = map[string]struct {
+	required []string
+	optional []string
+}{
+	"None":             {},
+	"PAT":              {required: []string{"pat"}},
+	"UsernamePassword": {required: []string{"username", "password"}},
+	"SAS":              {required: []string{"sas"}},
+	"ServicePrincipal": {required: []string{"client_id", "client_secret", "tenant_id"}},
+	"ApiKey":           {required: []string{"key"}},
+	"ManagedIdentity":  {required: []string{"client_id"}, optional: []string{"resource_id"}},
+}
+
+// workspaceConnectionSecrets are the arguments of the credentials block that can be set through a write-only
+// argument, so that they are not stored in the state.
+var workspaceConnectionSecrets = []string{"pat", "password", "sas", "client_secret", "key"}
+
+func resourceWorkspaceConnection() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a Connection of an Azure ML Workspace to an external service, such as a Git " +
+			"repository, a container registry, Azure OpenAI or Snowflake.",
+
+		CreateContext: resourceWorkspaceConnectionCreate,
+		ReadContext:   resourceWorkspaceConnectionRead,
+		UpdateContext: resourceWorkspaceConnectionUpdate,
+		DeleteContext: resourceWorkspaceConnectionDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceChild("connections"),
+		},
+
+		CustomizeDiff: resourceWorkspaceConnectionCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the connection belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the connection belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the connection.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the connection.",
+			},
+			"category": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: "The category of the connection, i.e. the kind of the external service (e.g. `Git`, " +
+					"`ContainerRegistry`, `AzureOpenAI` or `Snowflake`).",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"target": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The target of the connection, usually the URL of the external service.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"auth_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				Description: fmt.Sprintf(
+					"The type of authentication used for connecting to the external service. Possible values are: %+q.",
+					GetAllowedWorkspaceConnectionAuthTypes(),
+				),
+				ValidateFunc: validation.StringInSlice(GetAllowedWorkspaceConnectionAuthTypes(), false),
+			},
+			"is_shared_to_all": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Is the connection shared with all the users of the workspace?",
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "The metadata of the connection, whose keys depend on its category (e.g. `ApiType`).",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"credentials": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "The credentials used for connecting to the external service. The required arguments " +
+					"depend on `auth_type`: `pat` for `PAT`, `username` and `password` for `UsernamePassword`, `sas` " +
+					"for `SAS`, `client_id`, `client_secret` and `tenant_id` for `ServicePrincipal`, `key` for `ApiKey`, " +
+					"`client_id` and optionally `resource_id` for `ManagedIdentity`. Each secret can be set instead " +
+					"through its write-only `_wo` argument. The credentials are not returned by Azure ML, hence changes " +
+					"made outside of Terraform are not detected.",
+				Elem: &schema.Resource{
+					Schema: withWriteOnlyCredentials(map[string]*schema.Schema{
+						"pat": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The personal access token.",
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The username.",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The password.",
+						},
+						"sas": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The shared access signature token.",
+						},
+						"tenant_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ID of the tenant to which the service principal belongs to.",
+						},
+						"client_id": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Description: "The application ID of the service principal, or the client ID of the " +
+								"managed identity.",
+						},
+						"client_secret": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The client secret of the service principal.",
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The API key.",
+						},
+						"resource_id": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The ID of the user assigned managed identity.",
+						},
+					}, workspaceConnectionSecrets...),
+				},
+			},
+		},
+	}
+}
+
+// resourceWorkspaceConnectionCustomizeDiff checks that the credentials block sets exactly the arguments
+// required by the authentication type of the connection.
+func resourceWorkspaceConnectionCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("auth_type") {
+		return nil
+	}
+	// The raw configuration is used since the credentials may be unknown until apply
+	setArguments := make(map[string]bool)
+	credentials := d.GetRawConfig().GetAttr("credentials")
+	if credentials.IsKnown() && !credentials.IsNull() && credentials.LengthInt() > 0 {
+		block := credentials.Index(cty.NumberIntVal(0))
+		for name := range block.Type().AttributeTypes() {
+			if strings.HasSuffix(name, "_wo_version") || block.GetAttr(name).IsNull() {
+				continue
+			}
+			setArguments[strings.TrimSuffix(name, "_wo")] = true
+		}
+	}
+	return validateWorkspaceConnectionCredentials(d.Get("auth_type").(string), setArguments)
+}
+
+// validateWorkspaceConnectionCredentials checks that the credentials arguments set are the ones required or
+// allowed by the authentication type provided as argument.
+func validateWorkspaceConnectionCredentials(authType string, setArguments map[string]bool) error {
+	credentials, ok := workspaceConnectionCredentials[authType]
+	if !ok {
+		return fmt.Errorf("unsupported authentication type %q", authType)
+	}
+
+	allowed := make(map[string]bool)
+	var missing []string
+	for _, name := range credentials.required {
+		allowed[name] = true
+		if !setArguments[name] {
+			missing = append(missing, name)
+		}
+	}
+	for _, name := range credentials.optional {
+		allowed[name] = true
+	}
+	var unexpected []string
+	for name := range setArguments {
+		if !allowed[name] {
+			unexpected = append(unexpected, name)
+		}
+	}
+	sort.Strings(unexpected)
+
+	if len(missing) > 0 {
+		return fmt.Errorf(
+			"credentials %s are required when %q is %q",
+			strings.Join(missing, ", "),
+			"auth_type",
+			authType,
+		)
+	}
+	if len(unexpected) > 0 {
+		return fmt.Errorf(
+			"credentials %s cannot be set when %q is %q",
+			strings.Join(unexpected, ", "),
+			"auth_type",
+			authType,
+		)
+	}
+	return nil
+}
+
+func resourceWorkspaceConnectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	path := workspaceConnectionPath(client.arm, resourceGroupName, workspaceName, name)
+	if _, err := client.arm.put(ctx, path, resourceWorkspaceConnectionGetResourceData(d), nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(path)
+	return resourceWorkspaceConnectionRead(ctx, d, meta)
+}
+
+func resourceWorkspaceConnectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	connection := new(workspaceConnectionResource)
+	err := client.arm.get(ctx, workspaceConnectionPath(client.arm, resourceGroupName, workspaceName, name), connection)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading workspace connection %s", name),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	d.SetId(connection.Id)
+	return resourceWorkspaceConnectionSetResourceData(d, connection)
+}
+
+func resourceWorkspaceConnectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	// The credentials are not returned by Azure ML, hence the whole connection must always be submitted
+	path := workspaceConnectionPath(client.arm, resourceGroupName, workspaceName, name)
+	if _, err := client.arm.put(ctx, path, resourceWorkspaceConnectionGetResourceData(d), nil); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceWorkspaceConnectionRead(ctx, d, meta)
+}
+
+func resourceWorkspaceConnectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	_, err := client.arm.delete(ctx, workspaceConnectionPath(client.arm, resourceGroupName, workspaceName, name))
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting workspace connection %s.", name),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+func resourceWorkspaceConnectionGetResourceData(d *schema.ResourceData) *workspaceConnectionResource {
+	authType := d.Get("auth_type").(string)
+	return &workspaceConnectionResource{
+		Properties: workspaceConnectionProperties{
+			AuthType:      authType,
+			Category:      d.Get("category").(string),
+			Target:        d.Get("target").(string),
+			IsSharedToAll: d.Get("is_shared_to_all").(bool),
+			Metadata:      expandStringMap(d.Get("metadata").(map[string]interface{})),
+			Credentials: expandWorkspaceConnectionCredentials(
+				authType,
+				setWorkspaceConnectionWriteOnlyCredentials(d.Get("credentials").([]interface{}), d.GetRawConfig().GetAttr("credentials")),
+			),
+		},
+	}
+}
+
+// withWriteOnlyCredentials adds to the schema of the credentials block a write-only argument for each of the
+// secrets provided as argument, together with the version whose changes cause the secret to be submitted again.
+func withWriteOnlyCredentials(s map[string]*schema.Schema, secrets ...string) map[string]*schema.Schema {
+	for _, name := range secrets {
+		s[name].ConflictsWith = []string{"credentials.0." + name + "_wo"}
+		s[name+"_wo"] = &schema.Schema{
+			Type:          schema.TypeString,
+			Optional:      true,
+			WriteOnly:     true,
+			ConflictsWith: []string{"credentials.0." + name},
+			Description: fmt.Sprintf(
+				"%s Write-only alternative to `%s`, never stored in the state. Requires Terraform 1.11 or later.",
+				s[name].Description,
+				name,
+			),
+		}
+		s[name+"_wo_version"] = &schema.Schema{
+			Type:     schema.TypeInt,
+			Optional: true,
+			Description: fmt.Sprintf(
+				"The version of `%s_wo`. Since write-only arguments are not stored in the state, the value is "+
+					"submitted again only when the version changes.",
+				name,
+			),
+		}
+	}
+	return s
+}
+
+// setWorkspaceConnectionWriteOnlyCredentials returns the credentials block in which the secrets are replaced by the
+// values of the corresponding write-only arguments of the raw configuration, as the write-only arguments are never
+// part of the plan.
+func setWorkspaceConnectionWriteOnlyCredentials(l []interface{}, rawCredentials cty.Value) []interface{} {
+	if len(l) == 0 || l[0] == nil ||
+		!rawCredentials.IsWhollyKnown() || rawCredentials.IsNull() || rawCredentials.LengthInt() == 0 {
+		return l
+	}
+	block := rawCredentials.Index(cty.NumberIntVal(0))
+	data := make(map[string]interface{})
+	for name, value := range l[0].(map[string]interface{}) {
+		data[name] = value
+	}
+	for _, name := range workspaceConnectionSecrets {
+		if v := block.GetAttr(name + "_wo"); !v.IsNull() {
+			data[name] = v.AsString()
+		}
+	}
+	return []interface{}{data}
+}
+
+// expandWorkspaceConnectionCredentials returns the credentials of a workspace connection in the form expected
+// by the authentication type provided as argument.
+func expandWorkspaceConnectionCredentials(authType string, l []interface{}) map[string]string {
+	if len(l) == 0 || l[0] == nil {
+		return nil
+	}
+	data := l[0].(map[string]interface{})
+	var credentials map[string]string
+	switch authType {
+	case "PAT":
+		credentials = map[string]string{"pat": data["pat"].(string)}
+	case "UsernamePassword":
+		credentials = map[string]string{
+			"username": data["username"].(string),
+			"password": data["password"].(string),
+		}
+	case "SAS":
+		credentials = map[string]string{"sas": data["sas"].(string)}
+	case "ServicePrincipal":
+		credentials = map[string]string{
+			"clientId":     data["client_id"].(string),
+			"clientSecret": data["client_secret"].(string),
+			"tenantId":     data["tenant_id"].(string),
+		}
+	case "ApiKey":
+		credentials = map[string]string{"key": data["key"].(string)}
+	case "ManagedIdentity":
+		credentials = map[string]string{"clientId": data["client_id"].(string)}
+		if resourceId := data["resource_id"].(string); resourceId != "" {
+			credentials["resourceId"] = resourceId
+		}
+	}
+	return credentials
+}
+
+func resourceWorkspaceConnectionSetResourceData(d *schema.ResourceData, connection *workspaceConnectionResource) diag.Diagnostics {
+	props := connection.Properties
+	if err := d.Set("category", props.Category); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("target", props.Target); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("auth_type", props.AuthType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("is_shared_to_all", props.IsSharedToAll); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("metadata", props.Metadata); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func workspaceConnectionPath(c *armClient, resourceGroupName, workspaceName, name string) string {
+	return fmt.Sprintf("%s/connections/%s", c.workspaceId(resourceGroupName, workspaceName), name)
+}
+
+type workspaceConnectionResource struct {
+	Id         string                        `json:"id,omitempty"`
+	Name       string                        `json:"name,omitempty"`
+	Properties workspaceConnectionProperties `json:"properties"`
+}
+
+type workspaceConnectionProperties struct {
+	AuthType      string            `json:"authType"`
+	Category      string            `json:"category"`
+	Target        string            `json:"target"`
+	IsSharedToAll bool              `json:"isSharedToAll"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+	Credentials   map[string]string `json:"credentials,omitempty"`
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"testing"
+)
+
+func TestValidateWorkspaceConnectionCredentials(t *testing.T) {
+	valid := map[string]map[string]bool{
+		"None":             {},
+		"PAT":              {"pat": true},
+		"UsernamePassword": {"username": true, "password": true},
+		"SAS":              {"sas": true},
+		"ServicePrincipal": {"client_id": true, "client_secret": true, "tenant_id": true},
+		"ApiKey":           {"key": true},
+		"ManagedIdentity":  {"client_id": true, "resource_id": true},
+	}
+	for authType, setArguments := range valid {
+		if err := validateWorkspaceConnectionCredentials(authType, setArguments); err != nil {
+			t.Errorf("unexpected error for %s: %v", authType, err)
+		}
+	}
+
+	invalid := map[string]map[string]bool{
+		"PAT":              {},
+		"UsernamePassword": {"username": true},
+		"ServicePrincipal": {"client_id": true, "client_secret": true, "tenant_id": true, "key": true},
+		"ApiKey":           {"key": true, "pat": true},
+		"None":             {"sas": true},
+		"Unknown":          {},
+	}
+	for authType, setArguments := range invalid {
+		if err := validateWorkspaceConnectionCredentials(authType, setArguments); err == nil {
+			t.Errorf("expected error for %s with credentials %v", authType, setArguments)
+		}
+	}
+}
+
+func TestExpandWorkspaceConnectionCredentials(t *testing.T) {
+	data := []interface{}{
+		map[string]interface{}{
+			"pat":           "",
+			"username":      "",
+			"password":      "",
+			"sas":           "",
+			"tenant_id":     "tenant",
+			"client_id":     "client",
+			"client_secret": "secret",
+			"key":           "",
+			"resource_id":   "",
+		},
+	}
+	credentials := expandWorkspaceConnectionCredentials("ServicePrincipal", data)
+	if credentials["clientId"] != "client" || credentials["clientSecret"] != "secret" || credentials["tenantId"] != "tenant" {
+		t.Errorf("unexpected credentials %v", credentials)
+	}
+	if _, ok := expandWorkspaceConnectionCredentials("ManagedIdentity", data)["resourceId"]; ok {
+		t.Errorf("unexpected empty resource ID")
+	}
+	if credentials := expandWorkspaceConnectionCredentials("None", nil); credentials != nil {
+		t.Errorf("unexpected credentials %v", credentials)
+	}
+}
+
+func TestSetWorkspaceConnectionWriteOnlyCredentials(t *testing.T) {
+	data := []interface{}{map[string]interface{}{"client_id": "client", "client_secret": "", "tenant_id": "tenant"}}
+	attributes := map[string]cty.Value{}
+	for _, name := range workspaceConnectionSecrets {
+		attributes[name+"_wo"] = cty.NullVal(cty.String)
+	}
+	attributes["client_secret_wo"] = cty.StringVal("secret")
+	rawCredentials := cty.ListVal([]cty.Value{cty.ObjectVal(attributes)})
+
+	credentials := expandWorkspaceConnectionCredentials(
+		"ServicePrincipal",
+		setWorkspaceConnectionWriteOnlyCredentials(data, rawCredentials),
+	)
+	if credentials["clientSecret"] != "secret" || credentials["clientId"] != "client" {
+		t.Errorf("unexpected credentials %v", credentials)
+	}
+	if data[0].(map[string]interface{})["client_secret"] != "" {
+		t.Errorf("the credentials provided as argument have been modified")
+	}
+
+	rawCredentials = cty.NullVal(rawCredentials.Type())
+	if l := setWorkspaceConnectionWriteOnlyCredentials(data, rawCredentials); l[0].(map[string]interface{})["client_secret"] != "" {
+		t.Errorf("unexpected credentials %v", l)
+	}
+}
@@ -0,0 +1,464 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"time"
+)
+
+const (
+	outboundRuleTypeFqdn            = "FQDN"
+	outboundRuleTypePrivateEndpoint = "PrivateEndpoint"
+	outboundRuleTypeServiceTag      = "ServiceTag"
+)
+
+func GetAllowedOutboundRuleProtocols() []string {
+	return []string{
+		"TCP",
+		"UDP",
+		"ICMP",
+		"*",
+	}
+}
+
+func resourceWorkspaceOutboundRule() *schema.Resource {
+	return &schema.Resource{
+		Description: "Manages a user defined outbound rule of the managed virtual network of an Azure ML Workspace, " +
+			"independently of the definition of the workspace. The workspace must use a managed virtual network, " +
+			"i.e. its isolation mode must be either `AllowInternetOutbound` or `AllowOnlyApprovedOutbound`.",
+
+		CreateContext: resourceWorkspaceOutboundRuleCreate,
+		ReadContext:   resourceWorkspaceOutboundRuleRead,
+		UpdateContext: resourceWorkspaceOutboundRuleUpdate,
+		DeleteContext: resourceWorkspaceOutboundRuleDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: importWorkspaceChild("outboundRules"),
+		},
+
+		CustomizeDiff: resourceWorkspaceOutboundRuleCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the resource group of the Azure ML Workspace to which the outbound rule belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"workspace_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the Azure ML Workspace to which the outbound rule belongs to.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the outbound rule.",
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the outbound rule.",
+			},
+			"fqdn": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The fully qualified domain name allowed by the rule (e.g. `pypi.org` or `*.pythonhosted.org`). " +
+					"Conflicts with `private_endpoint` and `service_tag`.",
+				ValidateFunc: validation.StringIsNotEmpty,
+				ExactlyOneOf: []string{"fqdn", "private_endpoint", "service_tag"},
+			},
+			"private_endpoint": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "The Azure resource reached through a private endpoint created in the managed virtual " +
+					"network. Conflicts with `fqdn` and `service_tag`.",
+				ExactlyOneOf: []string{"fqdn", "private_endpoint", "service_tag"},
+				Elem: &schema.Resource{
+					Schema: outboundRulePrivateEndpointSchema(false),
+				},
+			},
+			"service_tag": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "The Azure service tag, protocol and ports allowed by the rule. Conflicts with `fqdn` " +
+					"and `private_endpoint`.",
+				ExactlyOneOf: []string{"fqdn", "private_endpoint", "service_tag"},
+				Elem: &schema.Resource{
+					Schema: outboundRuleServiceTagSchema(false),
+				},
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the outbound rule, i.e. `FQDN`, `PrivateEndpoint` or `ServiceTag`.",
+			},
+			"category": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The category of the outbound rule, which is always `UserDefined` for the rules managed by this resource.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the outbound rule, i.e. `Active` once the managed virtual network is provisioned, `Inactive` otherwise.",
+			},
+		},
+	}
+}
+
+// outboundRulePrivateEndpointSchema returns the schema of the destination of a private endpoint outbound rule,
+// whose arguments are all computed when used by a data source.
+func outboundRulePrivateEndpointSchema(computed bool) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"service_resource_id": {
+			Type:        schema.TypeString,
+			Required:    !computed,
+			Computed:    computed,
+			Description: "The ID of the Azure resource reached through the private endpoint.",
+		},
+		"subresource_target": {
+			Type:        schema.TypeString,
+			Required:    !computed,
+			Computed:    computed,
+			Description: "The sub-resource of the Azure resource reached through the private endpoint (e.g. `blob` or `vault`).",
+		},
+		"spark_enabled": {
+			Type:        schema.TypeBool,
+			Optional:    !computed,
+			Computed:    computed,
+			Description: "Is the private endpoint also reachable from serverless Spark jobs?",
+		},
+		"spark_status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The status of the private endpoint for serverless Spark jobs.",
+		},
+	}
+	if !computed {
+		s["service_resource_id"].ValidateFunc = validation.StringIsNotEmpty
+		s["subresource_target"].ValidateFunc = validation.StringIsNotEmpty
+		s["spark_enabled"].Default = false
+	}
+	return s
+}
+
+// outboundRuleServiceTagSchema returns the schema of the destination of a service tag outbound rule, whose
+// arguments are all computed when used by a data source.
+func outboundRuleServiceTagSchema(computed bool) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"service_tag": {
+			Type:        schema.TypeString,
+			Required:    !computed,
+			Computed:    computed,
+			Description: "The Azure service tag allowed by the rule (e.g. `AzureMonitor` or `Storage.WestEurope`).",
+		},
+		"protocol": {
+			Type:     schema.TypeString,
+			Optional: !computed,
+			Computed: computed,
+			Description: fmt.Sprintf(
+				"The network protocol allowed by the rule. Possible values are: %+q.",
+				GetAllowedOutboundRuleProtocols(),
+			),
+		},
+		"port_ranges": {
+			Type:        schema.TypeString,
+			Optional:    !computed,
+			Computed:    computed,
+			Description: "The ports allowed by the rule, as a comma separated list of ports and port ranges (e.g. `80,443,8080-8090`) or `*`.",
+		},
+		"address_prefixes": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The IP address prefixes to which the service tag resolves.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+	if !computed {
+		s["service_tag"].ValidateFunc = validation.StringIsNotEmpty
+		s["protocol"].Default = "TCP"
+		s["protocol"].ValidateFunc = validation.StringInSlice(GetAllowedOutboundRuleProtocols(), false)
+		s["port_ranges"].Default = "*"
+		s["port_ranges"].ValidateFunc = validation.StringIsNotEmpty
+	}
+	return s
+}
+
+// resourceWorkspaceOutboundRuleCustomizeDiff forces the replacement of the outbound rule when its type changes,
+// since Azure ML does not allow changing the type of an existing rule.
+func resourceWorkspaceOutboundRuleCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if d.HasChange("fqdn") {
+		old, new := d.GetChange("fqdn")
+		if old.(string) == "" || new.(string) == "" {
+			return d.ForceNew("fqdn")
+		}
+	}
+	for _, name := range []string{"private_endpoint", "service_tag"} {
+		if d.HasChange(name) {
+			old, new := d.GetChange(name)
+			if len(old.([]interface{})) != len(new.([]interface{})) {
+				return d.ForceNew(name)
+			}
+		}
+	}
+	return nil
+}
+
+func resourceWorkspaceOutboundRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	path := workspaceOutboundRulePath(client.arm, resourceGroupName, workspaceName, name)
+	if diags := putWorkspaceOutboundRule(ctx, d, client.arm, path, d.Timeout(schema.TimeoutCreate)); diags.HasError() {
+		return diags
+	}
+
+	d.SetId(path)
+	return resourceWorkspaceOutboundRuleRead(ctx, d, meta)
+}
+
+func resourceWorkspaceOutboundRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	rule := new(outboundRuleResource)
+	err := client.arm.get(ctx, workspaceOutboundRulePath(client.arm, resourceGroupName, workspaceName, name), rule)
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			d.SetId("")
+		} else {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Error reading outbound rule %s", name),
+				Detail:   err.Error(),
+			})
+		}
+		return diags
+	}
+
+	if rule.Id != "" {
+		d.SetId(rule.Id)
+	}
+	flattened, err := flattenOutboundRule(rule.Name, rule.Properties)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, k := range []string{"fqdn", "private_endpoint", "service_tag", "type", "category", "status"} {
+		if err := d.Set(k, flattened[k]); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	return nil
+}
+
+func resourceWorkspaceOutboundRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+
+	path := workspaceOutboundRulePath(client.arm, resourceGroupName, workspaceName, name)
+	if diags := putWorkspaceOutboundRule(ctx, d, client.arm, path, d.Timeout(schema.TimeoutUpdate)); diags.HasError() {
+		return diags
+	}
+	return resourceWorkspaceOutboundRuleRead(ctx, d, meta)
+}
+
+func resourceWorkspaceOutboundRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+	client := meta.(*apiClient)
+	resourceGroupName := d.Get("resource_group_name").(string)
+	workspaceName := d.Get("workspace_name").(string)
+	name := d.Get("name").(string)
+	path := workspaceOutboundRulePath(client.arm, resourceGroupName, workspaceName, name)
+
+	resp, err := client.arm.delete(ctx, path)
+	if err == nil {
+		err = waitForDeletion(ctx, client.arm, resp, path, d.Timeout(schema.TimeoutDelete))
+	}
+	if err != nil {
+		var notFoundErr *resourceNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return diags
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Error deleting outbound rule %s.", name),
+			Detail:   err.Error(),
+		})
+	}
+	return diags
+}
+
+// putWorkspaceOutboundRule creates or replaces the outbound rule at the path provided as argument and waits
+// until the update of the managed virtual network is completed.
+func putWorkspaceOutboundRule(ctx context.Context, d *schema.ResourceData, c *armClient, path string, timeout time.Duration) diag.Diagnostics {
+	properties, err := expandOutboundRule(
+		d.Get("fqdn").(string),
+		d.Get("private_endpoint").([]interface{}),
+		d.Get("service_tag").([]interface{}),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resp, err := c.put(ctx, path, &outboundRuleResource{Properties: *properties}, nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err := waitForCreation(ctx, c, resp, path, timeout); err != nil {
+		return diag.FromErr(fmt.Errorf("error waiting for outbound rule %s to be applied: %w", d.Get("name").(string), err))
+	}
+	return nil
+}
+
+// expandOutboundRule returns the properties of a user defined outbound rule whose destination is the one of
+// the arguments provided which is set.
+func expandOutboundRule(fqdn string, privateEndpoint, serviceTag []interface{}) (*outboundRuleProperties, error) {
+	var ruleType string
+	var destination interface{}
+	switch {
+	case fqdn != "":
+		ruleType = outboundRuleTypeFqdn
+		destination = fqdn
+	case len(privateEndpoint) > 0 && privateEndpoint[0] != nil:
+		data := privateEndpoint[0].(map[string]interface{})
+		ruleType = outboundRuleTypePrivateEndpoint
+		destination = outboundRulePrivateEndpointDestination{
+			ServiceResourceId: data["service_resource_id"].(string),
+			SubresourceTarget: data["subresource_target"].(string),
+			SparkEnabled:      data["spark_enabled"].(bool),
+		}
+	case len(serviceTag) > 0 && serviceTag[0] != nil:
+		data := serviceTag[0].(map[string]interface{})
+		ruleType = outboundRuleTypeServiceTag
+		destination = outboundRuleServiceTagDestination{
+			ServiceTag: data["service_tag"].(string),
+			Protocol:   data["protocol"].(string),
+			PortRanges: data["port_ranges"].(string),
+		}
+	default:
+		return nil, fmt.Errorf("one of %q, %q or %q must be set", "fqdn", "private_endpoint", "service_tag")
+	}
+
+	b, err := json.Marshal(destination)
+	if err != nil {
+		return nil, err
+	}
+	return &outboundRuleProperties{
+		Type:        ruleType,
+		Category:    "UserDefined",
+		Destination: b,
+	}, nil
+}
+
+// flattenOutboundRule returns the attributes of the outbound rule provided as argument, with the destination
+// flattened into the attribute matching the type of the rule.
+func flattenOutboundRule(name string, properties outboundRuleProperties) (map[string]interface{}, error) {
+	data := map[string]interface{}{
+		"name":             name,
+		"type":             properties.Type,
+		"category":         properties.Category,
+		"status":           properties.Status,
+		"fqdn":             "",
+		"private_endpoint": []interface{}{},
+		"service_tag":      []interface{}{},
+	}
+	if len(properties.Destination) == 0 || string(properties.Destination) == "null" {
+		return data, nil
+	}
+
+	switch properties.Type {
+	case outboundRuleTypeFqdn:
+		var fqdn string
+		if err := json.Unmarshal(properties.Destination, &fqdn); err != nil {
+			return nil, fmt.Errorf("unable to parse the destination of outbound rule %s: %w", name, err)
+		}
+		data["fqdn"] = fqdn
+	case outboundRuleTypePrivateEndpoint:
+		destination := new(outboundRulePrivateEndpointDestination)
+		if err := json.Unmarshal(properties.Destination, destination); err != nil {
+			return nil, fmt.Errorf("unable to parse the destination of outbound rule %s: %w", name, err)
+		}
+		data["private_endpoint"] = []interface{}{
+			map[string]interface{}{
+				"service_resource_id": destination.ServiceResourceId,
+				"subresource_target":  destination.SubresourceTarget,
+				"spark_enabled":       destination.SparkEnabled,
+				"spark_status":        destination.SparkStatus,
+			},
+		}
+	case outboundRuleTypeServiceTag:
+		destination := new(outboundRuleServiceTagDestination)
+		if err := json.Unmarshal(properties.Destination, destination); err != nil {
+			return nil, fmt.Errorf("unable to parse the destination of outbound rule %s: %w", name, err)
+		}
+		data["service_tag"] = []interface{}{
+			map[string]interface{}{
+				"service_tag":      destination.ServiceTag,
+				"protocol":         destination.Protocol,
+				"port_ranges":      destination.PortRanges,
+				"address_prefixes": destination.AddressPrefixes,
+			},
+		}
+	}
+	return data, nil
+}
+
+func workspaceOutboundRulePath(c *armClient, resourceGroupName, workspaceName, name string) string {
+	return fmt.Sprintf("%s/outboundRules/%s", c.workspaceId(resourceGroupName, workspaceName), name)
+}
+
+type outboundRuleResource struct {
+	Id         string                 `json:"id,omitempty"`
+	Name       string                 `json:"name,omitempty"`
+	Properties outboundRuleProperties `json:"properties"`
+}
+
+type outboundRuleProperties struct {
+	Type        string          `json:"type"`
+	Category    string          `json:"category,omitempty"`
+	Status      string          `json:"status,omitempty"`
+	Destination json.RawMessage `json:"destination,omitempty"`
+}
+
+type outboundRulePrivateEndpointDestination struct {
+	ServiceResourceId string `json:"serviceResourceId"`
+	SubresourceTarget string `json:"subresourceTarget"`
+	SparkEnabled      bool   `json:"sparkEnabled"`
+	SparkStatus       string `json:"sparkStatus,omitempty"`
+}
+
+type outboundRuleServiceTagDestination struct {
+	ServiceTag      string   `json:"serviceTag"`
+	Protocol        string   `json:"protocol"`
+	PortRanges      string   `json:"portRanges"`
+	AddressPrefixes []string `json:"addressPrefixes,omitempty"`
+}
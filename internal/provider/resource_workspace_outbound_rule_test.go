@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandFlattenOutboundRule(t *testing.T) {
+	privateEndpoint := []interface{}{
+		map[string]interface{}{
+			"service_resource_id": "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/sa",
+			"subresource_target":  "blob",
+			"spark_enabled":       true,
+		},
+	}
+	serviceTag := []interface{}{
+		map[string]interface{}{
+			"service_tag": "AzureMonitor",
+			"protocol":    "TCP",
+			"port_ranges": "80,443",
+		},
+	}
+
+	cases := []struct {
+		fqdn            string
+		privateEndpoint []interface{}
+		serviceTag      []interface{}
+		ruleType        string
+		destination     string
+	}{
+		{
+			fqdn:        "pypi.org",
+			ruleType:    "FQDN",
+			destination: `"pypi.org"`,
+		},
+		{
+			privateEndpoint: privateEndpoint,
+			ruleType:        "PrivateEndpoint",
+			destination: `{"serviceResourceId":"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/sa",` +
+				`"subresourceTarget":"blob","sparkEnabled":true}`,
+		},
+		{
+			serviceTag:  serviceTag,
+			ruleType:    "ServiceTag",
+			destination: `{"serviceTag":"AzureMonitor","protocol":"TCP","portRanges":"80,443"}`,
+		},
+	}
+	for _, c := range cases {
+		properties, err := expandOutboundRule(c.fqdn, c.privateEndpoint, c.serviceTag)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", c.ruleType, err)
+		}
+		if properties.Type != c.ruleType || properties.Category != "UserDefined" {
+			t.Errorf("unexpected type and category %s, %s", properties.Type, properties.Category)
+		}
+		if string(properties.Destination) != c.destination {
+			t.Errorf("unexpected destination %s, expected %s", properties.Destination, c.destination)
+		}
+
+		flattened, err := flattenOutboundRule("rule", *properties)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", c.ruleType, err)
+		}
+		if flattened["type"] != c.ruleType || flattened["fqdn"] != c.fqdn {
+			t.Errorf("unexpected flattened rule %v", flattened)
+		}
+		if c.privateEndpoint != nil {
+			pe := flattened["private_endpoint"].([]interface{})[0].(map[string]interface{})
+			delete(pe, "spark_status")
+			if !reflect.DeepEqual(pe, c.privateEndpoint[0]) {
+				t.Errorf("unexpected private endpoint %v", pe)
+			}
+		}
+		if c.serviceTag != nil {
+			st := flattened["service_tag"].([]interface{})[0].(map[string]interface{})
+			delete(st, "address_prefixes")
+			if !reflect.DeepEqual(st, c.serviceTag[0]) {
+				t.Errorf("unexpected service tag %v", st)
+			}
+		}
+	}
+
+	if _, err := expandOutboundRule("", nil, nil); err == nil {
+		t.Errorf("expected error for a rule without destination")
+	}
+}
+
+func TestFlattenOutboundRuleInvalidDestination(t *testing.T) {
+	properties := outboundRuleProperties{
+		Type:        "FQDN",
+		Destination: []byte(`{"serviceTag":"AzureMonitor"}`),
+	}
+	if _, err := flattenOutboundRule("rule", properties); err == nil {
+		t.Errorf("expected error for an FQDN rule with an object destination")
+	}
+}
@@ -1,9 +1,16 @@
 package provider
 
 import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"hash/fnv"
+	"io/ioutil"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 func contains(s []string, str string) bool {
@@ -32,3 +39,425 @@ func hash(s string) (uint32, error) {
 	}
 	return h.Sum32(), nil
 }
+
+// parseWorkspaceChildId parses the ARM ID of an object belonging to an Azure ML Workspace (e.g. a datastore
+// or a compute) and returns the resource group name, the workspace name and the name of the object.
+// The childType argument is the collection to which the object belongs to (e.g. "datastores").
+func parseWorkspaceChildId(id, childType string) (resourceGroupName, workspaceName, name string, err error) {
+	resourceGroupName, workspaceName, segments, err := splitWorkspaceId(id)
+	if err != nil || len(segments) != 2 || !strings.EqualFold(segments[0], childType) {
+		return "", "", "", fmt.Errorf(
+			"invalid ID %q, expected format is "+
+				"/subscriptions/<subscription-id>/resourceGroups/<resource-group>/providers/"+
+				"Microsoft.MachineLearningServices/workspaces/<workspace>/%s/<name>",
+			id,
+			childType,
+		)
+	}
+	return resourceGroupName, workspaceName, segments[1], nil
+}
+
+// parseWorkspaceAssetVersionId parses the ARM ID of a version of an asset belonging to an Azure ML Workspace
+// (e.g. an environment or a model) and returns the resource group name, the workspace name, the name and the
+// version of the asset. The assetType argument is the collection to which the asset belongs to (e.g. "models").
+func parseWorkspaceAssetVersionId(id, assetType string) (resourceGroupName, workspaceName, name, version string, err error) {
+	resourceGroupName, workspaceName, segments, err := splitWorkspaceId(id)
+	if err != nil ||
+		len(segments) != 4 ||
+		!strings.EqualFold(segments[0], assetType) ||
+		!strings.EqualFold(segments[2], "versions") {
+		return "", "", "", "", fmt.Errorf(
+			"invalid ID %q, expected format is "+
+				"/subscriptions/<subscription-id>/resourceGroups/<resource-group>/providers/"+
+				"Microsoft.MachineLearningServices/workspaces/<workspace>/%s/<name>/versions/<version>",
+			id,
+			assetType,
+		)
+	}
+	return resourceGroupName, workspaceName, segments[1], segments[3], nil
+}
+
+// splitWorkspaceId splits an ARM ID prefixed by the ID of an Azure ML Workspace, returning the resource group
+// name, the workspace name and the remaining segments of the ID.
+func splitWorkspaceId(id string) (resourceGroupName, workspaceName string, segments []string, err error) {
+	segments = strings.Split(strings.Trim(id, "/"), "/")
+	if len(segments) < 8 ||
+		!strings.EqualFold(segments[0], "subscriptions") ||
+		!strings.EqualFold(segments[2], "resourceGroups") ||
+		!strings.EqualFold(segments[4], "providers") ||
+		!strings.EqualFold(segments[5], "Microsoft.MachineLearningServices") ||
+		!strings.EqualFold(segments[6], "workspaces") {
+		return "", "", nil, fmt.Errorf("invalid Azure ML Workspace ID %q", id)
+	}
+	for _, s := range segments {
+		if stringIsEmpty(s) {
+			return "", "", nil, fmt.Errorf("invalid ID %q, the ID cannot contain empty segments", id)
+		}
+	}
+	return segments[3], segments[7], segments[8:], nil
+}
+
+// splitRegistryId splits an ARM ID prefixed by the ID of an Azure ML Registry, returning the resource group
+// name, the registry name and the remaining segments of the ID.
+func splitRegistryId(id string) (resourceGroupName, registryName string, segments []string, err error) {
+	segments = strings.Split(strings.Trim(id, "/"), "/")
+	if len(segments) < 8 ||
+		!strings.EqualFold(segments[0], "subscriptions") ||
+		!strings.EqualFold(segments[2], "resourceGroups") ||
+		!strings.EqualFold(segments[4], "providers") ||
+		!strings.EqualFold(segments[5], "Microsoft.MachineLearningServices") ||
+		!strings.EqualFold(segments[6], "registries") {
+		return "", "", nil, fmt.Errorf("invalid Azure ML Registry ID %q", id)
+	}
+	for _, s := range segments {
+		if stringIsEmpty(s) {
+			return "", "", nil, fmt.Errorf("invalid ID %q, the ID cannot contain empty segments", id)
+		}
+	}
+	return segments[3], segments[7], segments[8:], nil
+}
+
+// importWorkspaceChild returns an import function which sets the resource group name, the workspace name
+// and the name of the imported object from its ID.
+func importWorkspaceChild(childType string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		resourceGroupName, workspaceName, name, err := parseWorkspaceChildId(d.Id(), childType)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.Set("resource_group_name", resourceGroupName); err != nil {
+			return nil, err
+		}
+		if err := d.Set("workspace_name", workspaceName); err != nil {
+			return nil, err
+		}
+		if err := d.Set("name", name); err != nil {
+			return nil, err
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// importWorkspaceAssetVersion returns an import function which sets the resource group name, the workspace
+// name, the name and the version of the imported asset version from its ID.
+func importWorkspaceAssetVersion(assetType string) schema.StateContextFunc {
+	return importWorkspaceAssetVersionAs(assetType, "workspace_name")
+}
+
+// importWorkspaceAssetVersionAs is like importWorkspaceAssetVersion, but sets the workspace name to the argument
+// provided, for the assets of the workspaces of a specific kind (e.g. the feature sets of feature stores).
+func importWorkspaceAssetVersionAs(assetType, workspaceNameArgument string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		resourceGroupName, workspaceName, name, version, err := parseWorkspaceAssetVersionId(d.Id(), assetType)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.Set("resource_group_name", resourceGroupName); err != nil {
+			return nil, err
+		}
+		if err := d.Set(workspaceNameArgument, workspaceName); err != nil {
+			return nil, err
+		}
+		if err := d.Set("name", name); err != nil {
+			return nil, err
+		}
+		if err := d.Set("version", version); err != nil {
+			return nil, err
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// importAssetVersion returns an import function for the asset versions which may belong either to an Azure ML
+// Workspace or to an Azure ML Registry. It sets the resource group name, the workspace or the registry name,
+// the name and the version of the imported asset version from its ID.
+func importAssetVersion(assetType string) schema.StateContextFunc {
+	importWorkspaceAsset := importWorkspaceAssetVersion(assetType)
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		resourceGroupName, registryName, segments, err := splitRegistryId(d.Id())
+		if err != nil {
+			return importWorkspaceAsset(ctx, d, meta)
+		}
+		if len(segments) != 4 || !strings.EqualFold(segments[0], assetType) || !strings.EqualFold(segments[2], "versions") {
+			return nil, fmt.Errorf(
+				"invalid ID %q, expected format is "+
+					"/subscriptions/<subscription-id>/resourceGroups/<resource-group>/providers/"+
+					"Microsoft.MachineLearningServices/registries/<registry>/%s/<name>/versions/<version>",
+				d.Id(),
+				assetType,
+			)
+		}
+		if err := d.Set("resource_group_name", resourceGroupName); err != nil {
+			return nil, err
+		}
+		if err := d.Set("registry_name", registryName); err != nil {
+			return nil, err
+		}
+		if err := d.Set("name", segments[1]); err != nil {
+			return nil, err
+		}
+		if err := d.Set("version", segments[3]); err != nil {
+			return nil, err
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// formatIsoDurationMinutes returns the ISO 8601 representation of a duration expressed in minutes.
+func formatIsoDurationMinutes(minutes int) string {
+	return fmt.Sprintf("PT%dM", minutes)
+}
+
+// parseIsoDurationMinutes returns the number of minutes of an ISO 8601 duration such as "PT1H30M" or "P1D".
+// Seconds are truncated.
+func parseIsoDurationMinutes(s string) (int, error) {
+	d, err := parseIsoDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	return int(d / time.Minute), nil
+}
+
+// formatIsoDuration returns the ISO 8601 representation of the duration provided as argument, expressed
+// in seconds (e.g. "PT90S" or "PT0.5S").
+func formatIsoDuration(d time.Duration) string {
+	return fmt.Sprintf("PT%sS", strconv.FormatFloat(d.Seconds(), 'f', -1, 64))
+}
+
+// parseIsoDuration parses an ISO 8601 duration such as "PT1H30M", "PT0.5S" or "P1D".
+func parseIsoDuration(s string) (time.Duration, error) {
+	matches := isoDurationRegexp.FindStringSubmatch(strings.ToUpper(s))
+	if matches == nil || s == "P" || strings.HasSuffix(strings.ToUpper(s), "T") {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+	var d time.Duration
+	for i, unit := range []time.Duration{24 * time.Hour, time.Hour, time.Minute} {
+		if matches[i+1] == "" {
+			continue
+		}
+		v, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q: %w", s, err)
+		}
+		d += time.Duration(v) * unit
+	}
+	if matches[4] != "" {
+		v, err := strconv.ParseFloat(matches[4], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q: %w", s, err)
+		}
+		d += time.Duration(v * float64(time.Second))
+	}
+	return d, nil
+}
+
+var isoDurationRegexp = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+func expandStringMap(m map[string]interface{}) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(m))
+	for k, v := range m {
+		result[k] = v.(string)
+	}
+	return result
+}
+
+func expandIntMap(m map[string]interface{}) map[string]int {
+	if len(m) == 0 {
+		return nil
+	}
+	result := make(map[string]int, len(m))
+	for k, v := range m {
+		result[k] = v.(int)
+	}
+	return result
+}
+
+func expandIntList(l []interface{}) []int {
+	result := make([]int, len(l))
+	for i, v := range l {
+		result[i] = v.(int)
+	}
+	return result
+}
+
+func expandStringList(l []interface{}) []string {
+	result := make([]string, len(l))
+	for i, v := range l {
+		result[i] = v.(string)
+	}
+	return result
+}
+
+// suppressWhitespaceDiff suppresses the diff of string attributes that differ only by leading or
+// trailing whitespace (e.g. the content of files normalized by Azure ML).
+func suppressWhitespaceDiff(k, old, new string, d *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
+
+// fileSha256 returns the hex-encoded SHA-256 hash of the content of the file at the path provided as argument.
+func fileSha256(path string) (string, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", sha256.Sum256(content)), nil
+}
+
+// datastoreUri is a URI referencing a path of a datastore of an Azure ML Workspace.
+type datastoreUri struct {
+	SubscriptionId    string
+	ResourceGroupName string
+	WorkspaceName     string
+	DatastoreName     string
+	Path              string
+}
+
+var (
+	datastoreUriShortRegexp = regexp.MustCompile(`^azureml://datastores/([^/]+)/paths/(.*)$`)
+	datastoreUriLongRegexp  = regexp.MustCompile(
+		`^azureml://subscriptions/([^/]+)/resource[gG]roups/([^/]+)/(?:providers/Microsoft\.MachineLearningServices/)?workspaces/([^/]+)/datastores/([^/]+)/paths/(.*)$`,
+	)
+)
+
+// parseDatastoreUri parses a URI in the short form azureml://datastores/<datastore>/paths/<path>, or in the
+// long form azureml://subscriptions/<subscription-id>/resourcegroups/<resource-group>/workspaces/<workspace>/datastores/<datastore>/paths/<path>.
+func parseDatastoreUri(uri string) (*datastoreUri, error) {
+	if m := datastoreUriShortRegexp.FindStringSubmatch(uri); m != nil {
+		return &datastoreUri{DatastoreName: m[1], Path: m[2]}, nil
+	}
+	if m := datastoreUriLongRegexp.FindStringSubmatch(uri); m != nil {
+		return &datastoreUri{
+			SubscriptionId:    m[1],
+			ResourceGroupName: m[2],
+			WorkspaceName:     m[3],
+			DatastoreName:     m[4],
+			Path:              m[5],
+		}, nil
+	}
+	return nil, fmt.Errorf(
+		"invalid datastore URI %q, expected format is azureml://datastores/<datastore>/paths/<path>",
+		uri,
+	)
+}
+
+// String returns the long form of the URI if the workspace is known, and the short form otherwise.
+func (u datastoreUri) String() string {
+	if u.WorkspaceName == "" {
+		return fmt.Sprintf("azureml://datastores/%s/paths/%s", u.DatastoreName, u.Path)
+	}
+	return fmt.Sprintf(
+		"azureml://subscriptions/%s/resourcegroups/%s/workspaces/%s/datastores/%s/paths/%s",
+		u.SubscriptionId,
+		u.ResourceGroupName,
+		u.WorkspaceName,
+		u.DatastoreName,
+		u.Path,
+	)
+}
+
+// datastoreUrisAreEquivalent returns true if the two URIs provided as argument reference the same path of
+// the same datastore, regardless of the form in which they are expressed. The short form references the
+// workspace provided as argument, i.e. the workspace of the asset, whose subscription is compared only if known.
+func datastoreUrisAreEquivalent(a, b string, subscriptionId, resourceGroupName, workspaceName string) bool {
+	if a == b {
+		return true
+	}
+	uriA, err := parseDatastoreUri(a)
+	if err != nil {
+		return false
+	}
+	uriB, err := parseDatastoreUri(b)
+	if err != nil {
+		return false
+	}
+	for _, u := range []*datastoreUri{uriA, uriB} {
+		if u.WorkspaceName == "" {
+			u.SubscriptionId, u.ResourceGroupName, u.WorkspaceName = subscriptionId, resourceGroupName, workspaceName
+		}
+	}
+	if uriA.SubscriptionId != "" && uriB.SubscriptionId != "" && !strings.EqualFold(uriA.SubscriptionId, uriB.SubscriptionId) {
+		return false
+	}
+	return strings.EqualFold(uriA.ResourceGroupName, uriB.ResourceGroupName) &&
+		strings.EqualFold(uriA.WorkspaceName, uriB.WorkspaceName) &&
+		uriA.DatastoreName == uriB.DatastoreName &&
+		strings.TrimSuffix(uriA.Path, "/") == strings.TrimSuffix(uriB.Path, "/")
+}
+
+// suppressEquivalentDatastoreUriDiff returns a function suppressing the diff of URIs referencing the same path of a
+// datastore. The short URIs reference the workspace of the resource, whose name is the argument provided as argument.
+func suppressEquivalentDatastoreUriDiff(workspaceArgument string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		return datastoreUrisAreEquivalent(
+			old,
+			new,
+			resourceSubscriptionId(d),
+			d.Get("resource_group_name").(string),
+			d.Get(workspaceArgument).(string),
+		)
+	}
+}
+
+// resourceSubscriptionId returns the subscription of the resource provided as argument, taken from its ARM ID, or
+// an empty string if the resource has not been created yet.
+func resourceSubscriptionId(d *schema.ResourceData) string {
+	segments := strings.Split(strings.Trim(d.Id(), "/"), "/")
+	if len(segments) < 2 || !strings.EqualFold(segments[0], "subscriptions") {
+		return ""
+	}
+	return segments[1]
+}
+
+// suppressEquivalentAssetReferenceDiff returns a function suppressing the diff between a reference to a version of
+// an asset in the azureml:<name>:<version> form and the ARM ID of the same version, which is returned by Azure ML in
+// place of the reference. The reference is resolved against the workspace of the resource. The assetType argument
+// is the collection to which the asset belongs to (e.g. "models").
+func suppressEquivalentAssetReferenceDiff(assetType string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		reference, id := new, old
+		if !assetUriRegexp.MatchString(reference) {
+			reference, id = old, new
+		}
+		m := assetUriRegexp.FindStringSubmatch(reference)
+		if m == nil || m[2] == "" {
+			return false
+		}
+		resourceGroupName, workspaceName, name, version, err := parseWorkspaceAssetVersionId(id, assetType)
+		if err != nil {
+			return false
+		}
+		idSegments := strings.Split(strings.Trim(id, "/"), "/")
+		if subscriptionId := resourceSubscriptionId(d); subscriptionId != "" && !strings.EqualFold(idSegments[1], subscriptionId) {
+			return false
+		}
+		return strings.EqualFold(resourceGroupName, d.Get("resource_group_name").(string)) &&
+			strings.EqualFold(workspaceName, d.Get("workspace_name").(string)) &&
+			name == m[1] && version == m[2]
+	}
+}
+
+var (
+	storageChildIdRegexp  = regexp.MustCompile(`(?i)^/subscriptions/[^/]+/resourceGroups/[^/]+/providers/Microsoft\.Storage/storageAccounts/([^/]+)/(?:blobServices|fileServices)/default/(?:containers|shares)/([^/]+)$`)
+	storageChildUrlRegexp = regexp.MustCompile(`^https://([^./]+)\.(?:blob|dfs|file)\.core\.windows\.net/([^/]+)/?$`)
+)
+
+// parseStorageChildId parses the ID of a container or of a file share of a Storage Account, either in the ARM
+// form or in the form of the URL of its endpoint, and returns the name of the Storage Account and the name of
+// the container or of the file share.
+func parseStorageChildId(id string) (storageAccountName, name string, err error) {
+	if m := storageChildIdRegexp.FindStringSubmatch(id); m != nil {
+		return m[1], m[2], nil
+	}
+	if m := storageChildUrlRegexp.FindStringSubmatch(id); m != nil {
+		return m[1], m[2], nil
+	}
+	return "", "", fmt.Errorf(
+		"invalid ID %q, expected format is "+
+			"/subscriptions/<subscription-id>/resourceGroups/<resource-group>/providers/"+
+			"Microsoft.Storage/storageAccounts/<account>/blobServices/default/containers/<container>",
+		id,
+	)
+}
@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseWorkspaceChildId(t *testing.T) {
+	id := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/computes/ci-1"
+	resourceGroupName, workspaceName, name, err := parseWorkspaceChildId(id, "computes")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resourceGroupName != "rg" || workspaceName != "ws" || name != "ci-1" {
+		t.Errorf("unexpected result: %q %q %q", resourceGroupName, workspaceName, name)
+	}
+
+	invalidIds := []string{
+		"",
+		"ci-1",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/datastores/ci-1",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/workspaces/ws/computes/ci-1",
+		"/subscriptions/sub/resourceGroups//providers/Microsoft.MachineLearningServices/workspaces/ws/computes/ci-1",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/computes/ci-1/extra",
+	}
+	for _, invalidId := range invalidIds {
+		if _, _, _, err := parseWorkspaceChildId(invalidId, "computes"); err == nil {
+			t.Errorf("expected error for ID %q", invalidId)
+		}
+	}
+}
+
+func TestParseIsoDurationMinutes(t *testing.T) {
+	testCases := map[string]int{
+		"PT30M":     30,
+		"PT1H":      60,
+		"PT1H30M":   90,
+		"P1D":       1440,
+		"P1DT2H":    1560,
+		"PT15M30S":  15,
+		"pt45m":     45,
+		"PT0.5S":    0,
+		"P3DT0H0M0": -1,
+		"P":         -1,
+		"PT":        -1,
+		"30":        -1,
+	}
+	for duration, expected := range testCases {
+		minutes, err := parseIsoDurationMinutes(duration)
+		if expected < 0 {
+			if err == nil {
+				t.Errorf("expected error for duration %q", duration)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("unexpected error for duration %q: %v", duration, err)
+		} else if minutes != expected {
+			t.Errorf("duration %q: expected %d minutes, got %d", duration, expected, minutes)
+		}
+	}
+	if formatted := formatIsoDurationMinutes(90); formatted != "PT90M" {
+		t.Errorf("unexpected formatted duration %q", formatted)
+	}
+}
+
+func TestParseWorkspaceAssetVersionId(t *testing.T) {
+	id := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/environments/env/versions/3"
+	resourceGroupName, workspaceName, name, version, err := parseWorkspaceAssetVersionId(id, "environments")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resourceGroupName != "rg" || workspaceName != "ws" || name != "env" || version != "3" {
+		t.Errorf("unexpected result: %q %q %q %q", resourceGroupName, workspaceName, name, version)
+	}
+
+	invalidIds := []string{
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/environments/env",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/models/env/versions/3",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/environments/env/labels/3",
+	}
+	for _, invalidId := range invalidIds {
+		if _, _, _, _, err := parseWorkspaceAssetVersionId(invalidId, "environments"); err == nil {
+			t.Errorf("expected error for ID %q", invalidId)
+		}
+	}
+}
+
+func TestSplitRegistryId(t *testing.T) {
+	id := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/registries/reg/models/m/versions/1"
+	resourceGroupName, registryName, segments, err := splitRegistryId(id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resourceGroupName != "rg" || registryName != "reg" || len(segments) != 4 || segments[1] != "m" {
+		t.Errorf("unexpected result: %q %q %q", resourceGroupName, registryName, segments)
+	}
+
+	invalidIds := []string{
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/models/m",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/registries",
+		"/subscriptions/sub/resourceGroups//providers/Microsoft.MachineLearningServices/registries/reg",
+	}
+	for _, invalidId := range invalidIds {
+		if _, _, _, err := splitRegistryId(invalidId); err == nil {
+			t.Errorf("expected error for ID %q", invalidId)
+		}
+	}
+}
+
+func TestParseDatastoreUri(t *testing.T) {
+	short, err := parseDatastoreUri("azureml://datastores/workspaceblobstore/paths/data/train/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if short.DatastoreName != "workspaceblobstore" || short.Path != "data/train/" || short.WorkspaceName != "" {
+		t.Errorf("unexpected result %+v", short)
+	}
+	if short.String() != "azureml://datastores/workspaceblobstore/paths/data/train/" {
+		t.Errorf("unexpected short form %q", short.String())
+	}
+
+	long, err := parseDatastoreUri(
+		"azureml://subscriptions/sub/resourcegroups/rg/workspaces/ws/datastores/workspaceblobstore/paths/data/train",
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if long.SubscriptionId != "sub" || long.ResourceGroupName != "rg" || long.WorkspaceName != "ws" ||
+		long.DatastoreName != "workspaceblobstore" || long.Path != "data/train" {
+		t.Errorf("unexpected result %+v", long)
+	}
+
+	if !datastoreUrisAreEquivalent(short.String(), long.String(), "sub", "rg", "ws") {
+		t.Errorf("expected %q and %q to be equivalent", short, long)
+	}
+	if datastoreUrisAreEquivalent(short.String(), "azureml://datastores/other/paths/data/train/", "sub", "rg", "ws") {
+		t.Error("expected URIs of different datastores not to be equivalent")
+	}
+	otherWorkspace := *long
+	otherWorkspace.WorkspaceName = "other"
+	if datastoreUrisAreEquivalent(long.String(), otherWorkspace.String(), "sub", "rg", "ws") {
+		t.Errorf("expected %q and %q not to be equivalent", long, otherWorkspace)
+	}
+	// The short form references the workspace of the asset
+	if datastoreUrisAreEquivalent(otherWorkspace.String(), short.String(), "sub", "rg", "ws") {
+		t.Errorf("expected %q and %q not to be equivalent", otherWorkspace, short)
+	}
+	if datastoreUrisAreEquivalent(long.String(), short.String(), "other-sub", "rg", "ws") {
+		t.Errorf("expected %q and %q not to be equivalent in another subscription", long, short)
+	}
+	if !datastoreUrisAreEquivalent(long.String(), short.String(), "", "rg", "WS") {
+		t.Errorf("expected %q and %q to be equivalent when the subscription is not known", long, short)
+	}
+	if !datastoreUrisAreEquivalent(long.String(), strings.Replace(long.String(), "/rg/", "/RG/", 1)+"/", "", "", "") {
+		t.Errorf("expected %q to be equivalent to its form with a different case and a trailing slash", long)
+	}
+
+	for _, invalidUri := range []string{"", "azureml://datastores/ds", "https://account.blob.core.windows.net/c/p"} {
+		if _, err := parseDatastoreUri(invalidUri); err == nil {
+			t.Errorf("expected error for URI %q", invalidUri)
+		}
+	}
+}
+
+func TestFormatIsoDuration(t *testing.T) {
+	for _, duration := range []string{"PT90S", "PT0.5S", "PT10S"} {
+		d, err := parseIsoDuration(duration)
+		if err != nil {
+			t.Fatalf("unexpected error for duration %q: %v", duration, err)
+		}
+		if formatted := formatIsoDuration(d); formatted != duration {
+			t.Errorf("expected %q, got %q", duration, formatted)
+		}
+	}
+}
+
+func TestParseStorageChildId(t *testing.T) {
+	for id, expected := range map[string][2]string{
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account/blobServices/default/containers/c": {"account", "c"},
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account/fileServices/default/shares/s":     {"account", "s"},
+		"https://account.blob.core.windows.net/c":  {"account", "c"},
+		"https://account.dfs.core.windows.net/fs/": {"account", "fs"},
+	} {
+		storageAccountName, name, err := parseStorageChildId(id)
+		if err != nil {
+			t.Errorf("unexpected error for ID %q: %v", id, err)
+			continue
+		}
+		if storageAccountName != expected[0] || name != expected[1] {
+			t.Errorf("unexpected result for ID %q: %q %q", id, storageAccountName, name)
+		}
+	}
+
+	for _, invalidId := range []string{
+		"",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account",
+		"/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account/queueServices/default/queues/q",
+		"https://account.blob.core.windows.net/c/path",
+	} {
+		if _, _, err := parseStorageChildId(invalidId); err == nil {
+			t.Errorf("expected error for ID %q", invalidId)
+		}
+	}
+}
+
+func TestSuppressEquivalentAssetReferenceDiff(t *testing.T) {
+	d := resourceOnlineDeployment().TestResourceData()
+	d.SetId("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/onlineEndpoints/e/deployments/blue")
+	for name, value := range map[string]string{"resource_group_name": "rg", "workspace_name": "ws"} {
+		if err := d.Set(name, value); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+	suppress := suppressEquivalentAssetReferenceDiff("models")
+	id := "/subscriptions/sub/resourceGroups/RG/providers/Microsoft.MachineLearningServices/workspaces/ws/models/model/versions/3"
+
+	for _, c := range []struct {
+		old, new string
+		expected bool
+	}{
+		{id, "azureml:model:3", true},
+		{"azureml:model:3", id, true},
+		{id, "azureml:model:4", false},
+		{id, "azureml:other:3", false},
+		{id, "azureml:model@latest", false},
+		{strings.Replace(id, "/workspaces/ws/", "/workspaces/other/", 1), "azureml:model:3", false},
+		{strings.Replace(id, "/subscriptions/sub/", "/subscriptions/other/", 1), "azureml:model:3", false},
+		{strings.Replace(id, "/models/", "/environments/", 1), "azureml:model:3", false},
+	} {
+		if suppress("model", c.old, c.new, d) != c.expected {
+			t.Errorf("expected the diff from %q to %q to be suppressed: %t", c.old, c.new, c.expected)
+		}
+	}
+}
+
+func TestSuppressEquivalentDatastoreUriDiff(t *testing.T) {
+	d := resourceDataAsset().TestResourceData()
+	d.SetId("/subscriptions/sub/resourceGroups/rg/providers/Microsoft.MachineLearningServices/workspaces/ws/data/d/versions/1")
+	for name, value := range map[string]string{"resource_group_name": "rg", "workspace_name": "ws"} {
+		if err := d.Set(name, value); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+	}
+	suppress := suppressEquivalentDatastoreUriDiff("workspace_name")
+	short := "azureml://datastores/ds/paths/data"
+	if !suppress("path", "azureml://subscriptions/sub/resourcegroups/rg/workspaces/ws/datastores/ds/paths/data", short, d) {
+		t.Error("expected the diff to the URI of the workspace of the resource to be suppressed")
+	}
+	if suppress("path", "azureml://subscriptions/sub/resourcegroups/rg/workspaces/other/datastores/ds/paths/data", short, d) {
+		t.Error("expected the diff from the URI of another workspace to be shown")
+	}
+}
@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// provisionedResource contains the properties shared by the ARM resources that are provisioned asynchronously.
+type provisionedResource struct {
+	Id         string `json:"id"`
+	Properties struct {
+		ProvisioningState string `json:"provisioningState"`
+	} `json:"properties"`
+}
+
+// waitForProvisioning waits until the provisioning of the resource at the path provided as argument
+// is completed successfully, following first the long-running operation started by the request whose
+// response is provided as argument, if any.
+func waitForProvisioning(ctx context.Context, c *armClient, resp *armResponse, path string, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := waitForOperation(ctx, c, resp, 0); err != nil {
+		return err
+	}
+	return pollUntilDone(ctx, "the provisioning of "+path, 0, func(ctx context.Context) (bool, time.Duration, error) {
+		r := new(provisionedResource)
+		if err := c.get(ctx, path, r); err != nil {
+			return false, 0, err
+		}
+		return provisioningDone(path, r.Properties.ProvisioningState, false)
+	})
+}
+
+// waitForCreation waits until the resource at the path provided as argument, whose creation has been
+// accepted asynchronously, exists and its provisioning is completed successfully. Unlike waitForProvisioning,
+// resources not exposing a provisioning state are considered provisioned as soon as they exist.
+func waitForCreation(ctx context.Context, c *armClient, resp *armResponse, path string, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := waitForOperation(ctx, c, resp, 0); err != nil {
+		return err
+	}
+	return pollUntilDone(ctx, "the creation of "+path, 0, func(ctx context.Context) (bool, time.Duration, error) {
+		r := new(provisionedResource)
+		if err := c.get(ctx, path, r); err != nil {
+			var notFoundErr *resourceNotFoundError
+			if errors.As(err, &notFoundErr) {
+				return false, 0, nil
+			}
+			return false, 0, err
+		}
+		return provisioningDone(path, r.Properties.ProvisioningState, true)
+	})
+}
+
+// waitForDeletion waits until the resource at the path provided as argument does not exist anymore,
+// following first the long-running operation started by the request whose response is provided as
+// argument, if any.
+func waitForDeletion(ctx context.Context, c *armClient, resp *armResponse, path string, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if err := waitForOperation(ctx, c, resp, 0); err != nil {
+		return err
+	}
+	return pollUntilDone(ctx, "the deletion of "+path, 0, func(ctx context.Context) (bool, time.Duration, error) {
+		r := new(provisionedResource)
+		if err := c.get(ctx, path, r); err != nil {
+			var notFoundErr *resourceNotFoundError
+			if errors.As(err, &notFoundErr) {
+				return true, 0, nil
+			}
+			return false, 0, err
+		}
+		return false, 0, nil
+	})
+}
+
+// provisioningDone returns whether the provisioning state provided as argument is terminal, and an error if the
+// provisioning did not succeed. An empty state is considered terminal only if emptyIsSucceeded is set.
+func provisioningDone(path, state string, emptyIsSucceeded bool) (bool, time.Duration, error) {
+	switch state {
+	case "Succeeded":
+		return true, 0, nil
+	case "":
+		return emptyIsSucceeded, 0, nil
+	case "Failed", "Canceled":
+		return false, 0, &operationError{Status: state, Resource: path}
+	}
+	return false, 0, nil
+}
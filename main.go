@@ -3,9 +3,10 @@ package main
 import (
 	"context"
 	"flag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/tf6server"
 	"github.com/orobix/terraform-provider-azureml/internal/provider"
 	"log"
+	"os"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -28,20 +29,46 @@ var (
 )
 
 func main() {
-	var debugMode bool
+	var debugMode, generateConfig bool
+	var resourceGroupName, workspaceName string
 
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
+	flag.BoolVar(&generateConfig, "generate-config", false, "set to true to write to the standard output the "+
+		"configuration importing the objects of the workspace given by -resource-group and -workspace, "+
+		"authenticating with the ARM_CLIENT_ID, ARM_CLIENT_SECRET, ARM_TENANT_ID and ARM_SUBSCRIPTION_ID "+
+		"environment variables")
+	flag.StringVar(&resourceGroupName, "resource-group", "", "the resource group of the workspace whose configuration is generated")
+	flag.StringVar(&workspaceName, "workspace", "", "the name of the workspace whose configuration is generated")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{ProviderFunc: provider.New(version)}
-
-	if debugMode {
-		err := plugin.Debug(context.Background(), "registry.terraform.io/orobix/azureml", opts)
+	if generateConfig {
+		err := provider.GenerateConfig(context.Background(), os.Stdout, provider.GenerateConfigOptions{
+			ClientId:          os.Getenv("ARM_CLIENT_ID"),
+			ClientSecret:      os.Getenv("ARM_CLIENT_SECRET"),
+			TenantId:          os.Getenv("ARM_TENANT_ID"),
+			SubscriptionId:    os.Getenv("ARM_SUBSCRIPTION_ID"),
+			ResourceGroupName: resourceGroupName,
+			WorkspaceName:     workspaceName,
+		})
 		if err != nil {
 			log.Fatal(err.Error())
 		}
 		return
 	}
 
-	plugin.Serve(opts)
+	// The SDKv2 provider is served together with the terraform-plugin-framework one
+	muxServer, err := provider.NewMuxServer(context.Background(), version)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	var serveOpts []tf6server.ServeOpt
+	if debugMode {
+		serveOpts = append(serveOpts, tf6server.WithManagedDebug())
+	}
+
+	err = tf6server.Serve("registry.terraform.io/orobix/azureml", muxServer, serveOpts...)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 }